@@ -0,0 +1,67 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterTelemetryProfileSpec defines the desired state of ClusterTelemetryProfile
+type ClusterTelemetryProfileSpec struct {
+	// NamespaceSelector restricts the profile to namespaces whose labels match.
+	// When unset the profile applies to every namespace.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	TelemetryProfileSpec `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+
+// ClusterTelemetryProfile is the Schema for the clustertelemetryprofiles API. It is the cluster-scoped
+// variant of TelemetryProfile, applied to the Pods of every namespace matched by
+// its namespaceSelector.
+type ClusterTelemetryProfile struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of ClusterTelemetryProfile
+	// +required
+	Spec ClusterTelemetryProfileSpec `json:"spec"`
+
+	// status defines the observed state of ClusterTelemetryProfile
+	// +optional
+	Status TelemetryProfileStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterTelemetryProfileList contains a list of ClusterTelemetryProfile
+type ClusterTelemetryProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterTelemetryProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterTelemetryProfile{}, &ClusterTelemetryProfileList{})
+}
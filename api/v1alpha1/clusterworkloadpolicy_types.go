@@ -0,0 +1,70 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterWorkloadPolicySpec defines the desired state of ClusterWorkloadPolicy
+// +kubebuilder:validation:XValidation:rule="!has(self.horizontalScaling)",message="horizontalScaling is only supported on namespaced WorkloadPolicies"
+// +kubebuilder:validation:XValidation:rule="!has(self.generateLimitRange) || !self.generateLimitRange",message="generateLimitRange is only supported on namespaced WorkloadPolicies"
+// +kubebuilder:validation:XValidation:rule="!has(self.quota)",message="quota is only supported on namespaced WorkloadPolicies"
+type ClusterWorkloadPolicySpec struct {
+	// NamespaceSelector restricts the policy to namespaces whose labels match.
+	// When unset the policy applies to every namespace.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	WorkloadPolicySpec `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+
+// ClusterWorkloadPolicy is the Schema for the clusterworkloadpolicies API. It is the cluster-scoped
+// variant of WorkloadPolicy, applied to the Pods of every namespace matched by
+// its namespaceSelector.
+type ClusterWorkloadPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of ClusterWorkloadPolicy
+	// +required
+	Spec ClusterWorkloadPolicySpec `json:"spec"`
+
+	// status defines the observed state of ClusterWorkloadPolicy
+	// +optional
+	Status WorkloadPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterWorkloadPolicyList contains a list of ClusterWorkloadPolicy
+type ClusterWorkloadPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterWorkloadPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterWorkloadPolicy{}, &ClusterWorkloadPolicyList{})
+}
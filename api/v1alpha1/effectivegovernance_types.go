@@ -0,0 +1,220 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EffectiveGovernanceName is the name of the EffectiveGovernance the operator
+// maintains in every namespace.
+const EffectiveGovernanceName = "default"
+
+// Container kinds of an EffectiveResourceDefault.
+const (
+	// ContainerKindContainers are the regular containers of a Pod.
+	ContainerKindContainers = "containers"
+	// ContainerKindInitContainers are init containers that run to completion.
+	ContainerKindInitContainers = "initContainers"
+	// ContainerKindSidecars are native sidecars, init containers with
+	// restartPolicy Always.
+	ContainerKindSidecars = "sidecars"
+	// ContainerKindMatched are the containers, init containers and sidecars
+	// a containerResources rule matches.
+	ContainerKindMatched = "matched"
+)
+
+// PolicySource names the policy a governance setting comes from.
+type PolicySource struct {
+	// Kind of the policy, e.g. WorkloadPolicy or ClusterSecurityBaseline.
+	Kind string `json:"kind"`
+
+	// Namespace of the policy; empty for cluster-scoped policies.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the policy.
+	Name string `json:"name"`
+}
+
+// EffectiveResourceDefault is a default resource request or limit Pods of the
+// namespace get.
+type EffectiveResourceDefault struct {
+	// Containers is the kind of containers the default applies to:
+	// containers, initContainers, sidecars, or matched for the containers
+	// matched by Selector.
+	Containers string `json:"containers"`
+
+	// Selector matches the containers of a containerResources rule. Unset
+	// for the defaults of a whole kind of containers.
+	// +optional
+	Selector *ContainerSelector `json:"selector,omitempty"`
+
+	// Type is requests or limits.
+	Type string `json:"type"`
+
+	// Resource is the name of the resource, e.g. cpu.
+	Resource string `json:"resource"`
+
+	// Value is the default quantity.
+	Value string `json:"value"`
+
+	// Source is the policy the default comes from.
+	Source PolicySource `json:"source"`
+}
+
+// EffectiveLabel is a mandatory label Pods of the namespace get.
+type EffectiveLabel struct {
+	// Key of the label.
+	Key string `json:"key"`
+
+	// Value the label defaults to.
+	Value string `json:"value"`
+
+	// Source is the policy the label comes from.
+	Source PolicySource `json:"source"`
+}
+
+// EffectiveHorizontalScaling is the HPA behavior Deployments of the namespace
+// get.
+type EffectiveHorizontalScaling struct {
+	HorizontalScalingPolicy `json:",inline"`
+
+	// Source is the policy the behavior comes from.
+	Source PolicySource `json:"source"`
+}
+
+// EffectiveEnvVar is a telemetry environment variable injected into the
+// containers of Pods of the namespace.
+type EffectiveEnvVar struct {
+	// Name of the environment variable.
+	Name string `json:"name"`
+
+	// Value of the environment variable.
+	Value string `json:"value"`
+
+	// Source is the profile the variable comes from.
+	Source PolicySource `json:"source"`
+}
+
+// EffectiveSecurityRule is a rule Pods of the namespace must satisfy.
+type EffectiveSecurityRule struct {
+	// Rule is the name of the baseline rule.
+	Rule SecurityBaselineRule `json:"rule"`
+
+	// EnforcementAction defines how violations of the rule are handled.
+	EnforcementAction EnforcementAction `json:"enforcementAction"`
+
+	// Remediation defines whether missing settings are filled in.
+	// +optional
+	Remediation RemediationMode `json:"remediation,omitempty"`
+
+	// Source is the baseline the rule comes from.
+	Source PolicySource `json:"source"`
+}
+
+// EffectiveExemption is a rule of a SecurityBaseline that an active
+// PolicyException exempts Pods of the namespace from.
+type EffectiveExemption struct {
+	// Rule is the name of the exempted baseline rule.
+	Rule SecurityBaselineRule `json:"rule"`
+
+	// Baseline is the SecurityBaseline the rule comes from.
+	Baseline PolicySource `json:"baseline"`
+
+	// Source is the PolicyException granting the exemption.
+	Source PolicySource `json:"source"`
+
+	// ExpiresAt is when the exemption stops being honored.
+	ExpiresAt metav1.Time `json:"expiresAt"`
+
+	// Selective is true when the PolicyException has a podSelector and only
+	// exempts the Pods it selects.
+	// +optional
+	Selective bool `json:"selective,omitempty"`
+}
+
+// EffectiveGovernanceStatus is the governance of a namespace: what the
+// WorkloadPolicies, TelemetryProfiles and SecurityBaselines applying to every
+// Pod of the namespace, and their cluster variants, combine to.
+type EffectiveGovernanceStatus struct {
+	// ResourceDefaults are the default resource requests and limits, after
+	// merging the WorkloadPolicies by priority and mergeStrategy. Defaulted
+	// init container requests may be capped further per Pod.
+	// +optional
+	ResourceDefaults []EffectiveResourceDefault `json:"resourceDefaults,omitempty"`
+
+	// MandatoryLabels are the labels Pods get when they do not set them.
+	// +optional
+	MandatoryLabels []EffectiveLabel `json:"mandatoryLabels,omitempty"`
+
+	// HorizontalScaling is the HPA behavior of Deployments, from the highest
+	// priority WorkloadPolicy with horizontalScaling.
+	// +optional
+	HorizontalScaling *EffectiveHorizontalScaling `json:"horizontalScaling,omitempty"`
+
+	// TelemetryEnv are the telemetry environment variables containers get
+	// when they do not set them.
+	// +optional
+	TelemetryEnv []EffectiveEnvVar `json:"telemetryEnv,omitempty"`
+
+	// SecurityRules are the rules of the SecurityBaselines and
+	// ClusterSecurityBaselines applying to the namespace. A Pod must satisfy
+	// every rule listed, so rules may be listed once per baseline.
+	// +optional
+	SecurityRules []EffectiveSecurityRule `json:"securityRules,omitempty"`
+
+	// Exemptions are the SecurityRules that active PolicyExceptions exempt
+	// Pods from. An exemption is listed until its PolicyException expires.
+	// +optional
+	Exemptions []EffectiveExemption `json:"exemptions,omitempty"`
+
+	// SelectivePolicies are the policies with a podSelector. They only apply
+	// to the Pods they select and are not reflected in the other fields.
+	// +optional
+	SelectivePolicies []PolicySource `json:"selectivePolicies,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// EffectiveGovernance is the Schema for the effectivegovernances API. The
+// operator maintains one, named default, in every namespace; it is read-only.
+type EffectiveGovernance struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// status is the governance in effect in the namespace
+	// +optional
+	Status EffectiveGovernanceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EffectiveGovernanceList contains a list of EffectiveGovernance
+type EffectiveGovernanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EffectiveGovernance `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EffectiveGovernance{}, &EffectiveGovernanceList{})
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ImageVerificationPolicySpec defines the desired state of ImageVerificationPolicy
+type ImageVerificationPolicySpec struct {
+	// EnforcementAction controls what happens when a Pod runs an image without
+	// a valid signature. Enforce denies the Pod, Warn admits it with admission
+	// warnings, and Audit admits it silently while recording the failure.
+	// +kubebuilder:default=Enforce
+	// +optional
+	EnforcementAction EnforcementAction `json:"enforcementAction,omitempty"`
+
+	// Images lists the registries or repository prefixes whose images must be
+	// signed, e.g. "registry.example.com" or "ghcr.io/my-org". Docker Hub short
+	// names are expanded before matching. Images outside these prefixes are not
+	// verified by this policy. Images within them must be referenced by digest,
+	// since a tag may be moved to an unverified image after admission.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Images []string `json:"images"`
+
+	// PublicKeys lists the cosign public keys (PEM encoded) accepted as signers.
+	// An image is verified when it carries a valid signature from any of them.
+	// Keys are read from Secrets in the namespace the operator runs in, or
+	// from ConfigMaps in the policy namespace.
+	// +kubebuilder:validation:MinItems=1
+	PublicKeys []PublicKeySource `json:"publicKeys"`
+
+	// InsecureRegistries lists registry hosts (e.g. "localhost:5001") that are
+	// contacted over plain HTTP instead of HTTPS. Intended for local registries
+	// in development and e2e environments.
+	// +listType=set
+	// +optional
+	InsecureRegistries []string `json:"insecureRegistries,omitempty"`
+}
+
+// PublicKeySource selects a PEM encoded public key. Exactly one of
+// SecretKeyRef or ConfigMapKeyRef must be set.
+type PublicKeySource struct {
+	// SecretKeyRef selects a key of a Secret in the namespace the operator
+	// runs in, the only namespace the operator may read Secrets from.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef selects a key of a ConfigMap in the policy namespace.
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// ImageVerificationPolicyStatus defines the observed state of ImageVerificationPolicy.
+type ImageVerificationPolicyStatus struct {
+	// For Kubernetes API conventions, see:
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#typical-status-properties
+
+	// conditions represent the current state of the ImageVerificationPolicy resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	//
+	// Standard condition types include:
+	// - "Available": the resource is fully functional
+	// - "Progressing": the resource is being created or updated
+	// - "Degraded": the resource failed to reach or maintain its desired state
+	//
+	// The status of each condition is one of True, False, or Unknown.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ImageVerificationPolicy is the Schema for the imageverificationpolicies API
+type ImageVerificationPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of ImageVerificationPolicy
+	// +required
+	Spec ImageVerificationPolicySpec `json:"spec"`
+
+	// status defines the observed state of ImageVerificationPolicy
+	// +optional
+	Status ImageVerificationPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ImageVerificationPolicyList contains a list of ImageVerificationPolicy
+type ImageVerificationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ImageVerificationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ImageVerificationPolicy{}, &ImageVerificationPolicyList{})
+}
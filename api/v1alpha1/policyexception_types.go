@@ -0,0 +1,147 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PolicyExceptionConditionActive is the condition type reporting whether a
+// PolicyException is currently honored.
+const PolicyExceptionConditionActive = "Active"
+
+// Reasons of the Active condition of a PolicyException.
+const (
+	// PolicyExceptionReasonActive means the exception is honored.
+	PolicyExceptionReasonActive = "Active"
+	// PolicyExceptionReasonExpiringSoon means the exception is honored but
+	// expires within the warning window.
+	PolicyExceptionReasonExpiringSoon = "ExpiringSoon"
+	// PolicyExceptionReasonExpired means expiresAt has passed and the exception
+	// is no longer honored.
+	PolicyExceptionReasonExpired = "Expired"
+)
+
+// PolicyExceptionSpec defines the desired state of PolicyException
+type PolicyExceptionSpec struct {
+	// Baseline references the SecurityBaseline in the exception namespace
+	// whose rules are exempted.
+	// +required
+	Baseline BaselineReference `json:"baseline"`
+
+	// Rules lists the baseline rules the selected Pods are exempted from.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Rules []SecurityBaselineRule `json:"rules"`
+
+	// PodSelector restricts the exception to Pods in the exception namespace
+	// whose labels match. When unset every Pod in the namespace is exempted.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// Justification explains why the exception is needed.
+	// +kubebuilder:validation:MinLength=1
+	Justification string `json:"justification"`
+
+	// Owner is the team or person accountable for the exception, e.g. an email
+	// address or a team name.
+	// +kubebuilder:validation:MinLength=1
+	Owner string `json:"owner"`
+
+	// ExpiresAt is the time after which the exception is no longer honored.
+	ExpiresAt metav1.Time `json:"expiresAt"`
+
+	// Approval records who approved the exception.
+	// +optional
+	Approval *PolicyExceptionApproval `json:"approval,omitempty"`
+}
+
+// BaselineReference names a SecurityBaseline in the namespace of the exception.
+type BaselineReference struct {
+	// Kind of the referenced baseline. Only SecurityBaselines can be exempted:
+	// ClusterSecurityBaselines are owned by the platform, which exempts
+	// namespaces through their excludedNamespaces or namespaceSelector.
+	// +kubebuilder:validation:Enum=SecurityBaseline
+	// +kubebuilder:default=SecurityBaseline
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the referenced baseline.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// PolicyExceptionApproval records the approval of a PolicyException.
+type PolicyExceptionApproval struct {
+	// ApprovedBy is the person or group that approved the exception.
+	// +kubebuilder:validation:MinLength=1
+	ApprovedBy string `json:"approvedBy"`
+
+	// Reference points to the approval record, e.g. a ticket or pull request URL.
+	// +optional
+	Reference string `json:"reference,omitempty"`
+}
+
+// PolicyExceptionStatus defines the observed state of PolicyException.
+type PolicyExceptionStatus struct {
+	// conditions represent the current state of the PolicyException resource.
+	//
+	// The "Active" condition is True while the exception is honored (with
+	// reason ExpiringSoon shortly before expiresAt) and False with reason
+	// Expired once expiresAt has passed.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Baseline",type=string,JSONPath=`.spec.baseline.name`
+// +kubebuilder:printcolumn:name="Owner",type=string,JSONPath=`.spec.owner`
+// +kubebuilder:printcolumn:name="Expires",type=string,format=date-time,JSONPath=`.spec.expiresAt`
+// +kubebuilder:printcolumn:name="Active",type=string,JSONPath=`.status.conditions[?(@.type=="Active")].status`
+
+// PolicyException is the Schema for the policyexceptions API
+type PolicyException struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of PolicyException
+	// +required
+	Spec PolicyExceptionSpec `json:"spec"`
+
+	// status defines the observed state of PolicyException
+	// +optional
+	Status PolicyExceptionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PolicyExceptionList contains a list of PolicyException
+type PolicyExceptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PolicyException `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PolicyException{}, &PolicyExceptionList{})
+}
@@ -17,24 +17,387 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// EnforcementAction defines how violations of a SecurityBaseline are handled.
+// +kubebuilder:validation:Enum=Enforce;Warn;Audit
+type EnforcementAction string
+
+const (
+	// EnforcementActionEnforce denies Pods that violate the baseline.
+	EnforcementActionEnforce EnforcementAction = "Enforce"
+	// EnforcementActionWarn admits violating Pods but returns admission warnings to the client.
+	EnforcementActionWarn EnforcementAction = "Warn"
+	// EnforcementActionAudit admits violating Pods silently and only records the violation.
+	EnforcementActionAudit EnforcementAction = "Audit"
+)
+
+// RemediationMode defines whether the operator fixes violations of a
+// SecurityBaseline instead of only reporting them.
+// +kubebuilder:validation:Enum=None;Mutate
+type RemediationMode string
+
+const (
+	// RemediationNone leaves Pods unchanged; violations are handled by the
+	// enforcement action only.
+	RemediationNone RemediationMode = "None"
+	// RemediationMutate makes the mutating webhook fill in missing security
+	// settings with secure defaults before the Pod is validated.
+	RemediationMutate RemediationMode = "Mutate"
+)
+
+// PodSecurityProfile names a Pod Security Standards level.
+// +kubebuilder:validation:Enum=privileged;baseline;restricted
+type PodSecurityProfile string
+
+const (
+	// PodSecurityProfilePrivileged is the unrestricted Pod Security Standards level.
+	PodSecurityProfilePrivileged PodSecurityProfile = "privileged"
+	// PodSecurityProfileBaseline prevents known privilege escalations.
+	PodSecurityProfileBaseline PodSecurityProfile = "baseline"
+	// PodSecurityProfileRestricted enforces current Pod hardening best practices.
+	PodSecurityProfileRestricted PodSecurityProfile = "restricted"
+
+	// PodSecurityProfileVersionLatest evaluates the profile using the newest
+	// Pod Security Standards definition known to the operator.
+	PodSecurityProfileVersionLatest = "latest"
+)
+
+// SecurityBaselineRule identifies a single check of a SecurityBaseline, e.g.
+// to exempt Pods from it with a PolicyException. Rules backed by a spec field
+// are named after it; the remaining ones are only enabled through a profile.
+// +kubebuilder:validation:Enum=runAsNonRoot;readOnlyRootFilesystem;disallowPrivilegeEscalation;disallowPrivileged;capabilities;disallowHostNamespaces;disallowHostPorts;hostPath;images;hostProcess;appArmor;seLinux;procMount;sysctls;seccomp;volumeTypes;runAsUser;runAsGroup;fsGroup;supplementalGroups;serviceAccounts
+type SecurityBaselineRule string
+
+// Rules of a SecurityBaseline.
+const (
+	RuleRunAsNonRoot                SecurityBaselineRule = "runAsNonRoot"
+	RuleReadOnlyRootFilesystem      SecurityBaselineRule = "readOnlyRootFilesystem"
+	RuleDisallowPrivilegeEscalation SecurityBaselineRule = "disallowPrivilegeEscalation"
+	RuleDisallowPrivileged          SecurityBaselineRule = "disallowPrivileged"
+	RuleCapabilities                SecurityBaselineRule = "capabilities"
+	RuleDisallowHostNamespaces      SecurityBaselineRule = "disallowHostNamespaces"
+	RuleDisallowHostPorts           SecurityBaselineRule = "disallowHostPorts"
+	RuleHostPath                    SecurityBaselineRule = "hostPath"
+	RuleImages                      SecurityBaselineRule = "images"
+	RuleHostProcess                 SecurityBaselineRule = "hostProcess"
+	RuleAppArmor                    SecurityBaselineRule = "appArmor"
+	RuleSELinux                     SecurityBaselineRule = "seLinux"
+	RuleProcMount                   SecurityBaselineRule = "procMount"
+	RuleSysctls                     SecurityBaselineRule = "sysctls"
+	RuleSeccomp                     SecurityBaselineRule = "seccomp"
+	RuleVolumeTypes                 SecurityBaselineRule = "volumeTypes"
+	RuleRunAsUser                   SecurityBaselineRule = "runAsUser"
+	RuleRunAsGroup                  SecurityBaselineRule = "runAsGroup"
+	RuleFSGroup                     SecurityBaselineRule = "fsGroup"
+	RuleSupplementalGroups          SecurityBaselineRule = "supplementalGroups"
+	RuleServiceAccounts             SecurityBaselineRule = "serviceAccounts"
+)
+
+// SecurityBaselineRules lists every SecurityBaselineRule, in the order of the
+// validation enum.
+var SecurityBaselineRules = []SecurityBaselineRule{
+	RuleRunAsNonRoot,
+	RuleReadOnlyRootFilesystem,
+	RuleDisallowPrivilegeEscalation,
+	RuleDisallowPrivileged,
+	RuleCapabilities,
+	RuleDisallowHostNamespaces,
+	RuleDisallowHostPorts,
+	RuleHostPath,
+	RuleImages,
+	RuleHostProcess,
+	RuleAppArmor,
+	RuleSELinux,
+	RuleProcMount,
+	RuleSysctls,
+	RuleSeccomp,
+	RuleVolumeTypes,
+	RuleRunAsUser,
+	RuleRunAsGroup,
+	RuleFSGroup,
+	RuleSupplementalGroups,
+	RuleServiceAccounts,
+}
+
 // SecurityBaselineSpec defines the desired state of SecurityBaseline
 type SecurityBaselineSpec struct {
-	// Require running as non-root
-	// +kubebuilder:default=true
-	RunAsNonRoot bool `json:"runAsNonRoot"`
+	// PodSelector restricts the baseline to Pods whose labels match. When unset the
+	// baseline applies to every Pod in its namespace.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
 
-	// Require a read-only root filesystem
-	// +kubebuilder:default=true
-	ReadOnlyRootFilesystem bool `json:"readOnlyRootFilesystem"`
+	// EnforcementAction controls what happens when a Pod violates this baseline.
+	// Enforce denies the Pod, Warn admits it with admission warnings, and Audit
+	// admits it silently while recording the violation as an event and metric.
+	// +kubebuilder:default=Enforce
+	// +optional
+	EnforcementAction EnforcementAction `json:"enforcementAction,omitempty"`
+
+	// Remediation controls whether violations caused by missing security
+	// settings are fixed at admission. Mutate sets runAsNonRoot: true,
+	// readOnlyRootFilesystem: true, allowPrivilegeEscalation: false, a
+	// RuntimeDefault seccomp profile and capabilities.drop: [ALL] where the
+	// baseline requires them and the Pod leaves them unset. Explicitly
+	// insecure values are never overwritten and are still handled by
+	// EnforcementAction.
+	// +kubebuilder:default=None
+	// +optional
+	Remediation RemediationMode `json:"remediation,omitempty"`
+
+	// Profile expands to the checks of the given Pod Security Standards level.
+	// The individual rule fields below are applied on top of the profile:
+	// boolean rules left unset inherit the profile, true tightens it and false
+	// relaxes it, while capabilities, hostPath, seccomp, appArmor and runAsUser
+	// replace the profile's policy when set.
+	// +optional
+	Profile PodSecurityProfile `json:"profile,omitempty"`
+
+	// ProfileVersion pins the Pod Security Standards version used to evaluate
+	// Profile, e.g. "v1.30". Defaults to "latest".
+	// +kubebuilder:validation:Pattern=`^(latest|v1\.[0-9]+)$`
+	// +optional
+	ProfileVersion string `json:"profileVersion,omitempty"`
+
+	// RunAsNonRoot requires Pods to run as non-root. Unset inherits Profile.
+	// +optional
+	RunAsNonRoot *bool `json:"runAsNonRoot,omitempty"`
+
+	// ReadOnlyRootFilesystem requires every container to have a read-only root
+	// filesystem. Unset inherits Profile.
+	// +optional
+	ReadOnlyRootFilesystem *bool `json:"readOnlyRootFilesystem,omitempty"`
+
+	// DisallowPrivilegeEscalation requires every container to explicitly set
+	// securityContext.allowPrivilegeEscalation to false. Unset inherits Profile.
+	// +optional
+	DisallowPrivilegeEscalation *bool `json:"disallowPrivilegeEscalation,omitempty"`
+
+	// DisallowPrivileged forbids containers from running with securityContext.privileged
+	// set to true. Unset inherits Profile.
+	// +optional
+	DisallowPrivileged *bool `json:"disallowPrivileged,omitempty"`
 
-	// ExcludedNamespaces list namespaces to bypass this baseline
+	// Capabilities restricts the Linux capabilities containers may hold.
+	// +optional
+	Capabilities *CapabilitiesPolicy `json:"capabilities,omitempty"`
+
+	// DisallowHostNamespaces forbids Pods from sharing the host network, PID or
+	// IPC namespaces (spec.hostNetwork, spec.hostPID and spec.hostIPC). Unset
+	// inherits Profile.
+	// +optional
+	DisallowHostNamespaces *bool `json:"disallowHostNamespaces,omitempty"`
+
+	// DisallowHostPorts forbids containers from binding host ports. Unset inherits Profile.
+	// +optional
+	DisallowHostPorts *bool `json:"disallowHostPorts,omitempty"`
+
+	// HostPath restricts the use of hostPath volumes.
+	// +optional
+	HostPath *HostPathPolicy `json:"hostPath,omitempty"`
+
+	// Seccomp requires containers to run with a seccomp profile of an allowed
+	// type. It replaces the seccomp policy of Profile when set.
+	// +optional
+	Seccomp *SeccompPolicy `json:"seccomp,omitempty"`
+
+	// AppArmor requires containers to run with an AppArmor profile of an
+	// allowed type. It replaces the AppArmor policy of Profile when set.
+	// +optional
+	AppArmor *AppArmorPolicy `json:"appArmor,omitempty"`
+
+	// RunAsUser restricts the UIDs containers run as. A container's own
+	// securityContext.runAsUser takes precedence over the Pod's, and one of
+	// them must be set. It replaces the non-root UID check of Profile when set.
+	// +optional
+	RunAsUser *IDRangePolicy `json:"runAsUser,omitempty"`
+
+	// RunAsGroup restricts the primary GIDs containers run as. A container's
+	// own securityContext.runAsGroup takes precedence over the Pod's, and one
+	// of them must be set.
+	// +optional
+	RunAsGroup *IDRangePolicy `json:"runAsGroup,omitempty"`
+
+	// FSGroup restricts spec.securityContext.fsGroup when it is set.
+	// +optional
+	FSGroup *IDRangePolicy `json:"fsGroup,omitempty"`
+
+	// SupplementalGroups restricts every GID in
+	// spec.securityContext.supplementalGroups.
+	// +optional
+	SupplementalGroups *IDRangePolicy `json:"supplementalGroups,omitempty"`
+
+	// ServiceAccounts restricts the ServiceAccount Pods run as and the
+	// mounting of its API token.
+	// +optional
+	ServiceAccounts *ServiceAccountPolicy `json:"serviceAccounts,omitempty"`
+
+	// Images restricts which container images Pods may run.
+	// +optional
+	Images *ImagePolicy `json:"images,omitempty"`
+
+	// Debug turns the baseline into a break-glass baseline. It then no longer
+	// applies to Pods, only to ephemeral containers that the listed users and
+	// groups add to running Pods, e.g. with kubectl debug. For those requests
+	// the debug baselines replace the regular baselines of the same kind, so a
+	// debug SecurityBaseline relaxes SecurityBaselines but never a
+	// ClusterSecurityBaseline.
+	// +optional
+	Debug *DebugPolicy `json:"debug,omitempty"`
+
+	// ExcludedNamespaces lists namespaces this baseline does not apply to. It is
+	// intended for ClusterSecurityBaseline; a namespaced SecurityBaseline only
+	// ever applies to its own namespace.
 	// +optional
 	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
 }
 
+// CapabilitiesPolicy restricts the Linux capabilities of containers.
+type CapabilitiesPolicy struct {
+	// RequireDropAll requires every container to drop ALL capabilities via
+	// securityContext.capabilities.drop.
+	// +optional
+	RequireDropAll bool `json:"requireDropAll,omitempty"`
+
+	// AllowedAdd lists the only capabilities containers may add via
+	// securityContext.capabilities.add (e.g. NET_BIND_SERVICE). An empty list
+	// forbids adding any capability.
+	// +listType=set
+	// +optional
+	AllowedAdd []corev1.Capability `json:"allowedAdd,omitempty"`
+}
+
+// HostPathPolicy restricts hostPath volumes.
+type HostPathPolicy struct {
+	// AllowedReadOnlyPathPrefixes lists host path prefixes (e.g. /var/log) that
+	// may be used as hostPath volumes, provided every mount of the volume is
+	// read-only. An empty list forbids all hostPath volumes.
+	// +listType=set
+	// +optional
+	AllowedReadOnlyPathPrefixes []string `json:"allowedReadOnlyPathPrefixes,omitempty"`
+}
+
+// SeccompPolicy requires a seccomp profile. The profile of a container is its
+// own securityContext.seccompProfile, or the Pod's when it sets none.
+type SeccompPolicy struct {
+	// AllowedTypes lists the seccomp profile types containers may use.
+	// Defaults to RuntimeDefault and Localhost.
+	// +kubebuilder:validation:items:Enum=RuntimeDefault;Localhost
+	// +listType=set
+	// +optional
+	AllowedTypes []corev1.SeccompProfileType `json:"allowedTypes,omitempty"`
+
+	// AllowedLocalhostProfiles lists the localhostProfile paths Localhost
+	// profiles may use, e.g. "profiles/audit.json". Entries may contain
+	// path.Match wildcards such as "profiles/*". Empty allows any profile.
+	// +listType=set
+	// +optional
+	AllowedLocalhostProfiles []string `json:"allowedLocalhostProfiles,omitempty"`
+}
+
+// AppArmorPolicy requires an AppArmor profile. The profile of a container is
+// its own securityContext.appArmorProfile, then its legacy
+// container.apparmor.security.beta.kubernetes.io annotation, and finally the
+// Pod's securityContext.appArmorProfile.
+type AppArmorPolicy struct {
+	// AllowedTypes lists the AppArmor profile types containers may use.
+	// Defaults to RuntimeDefault and Localhost.
+	// +kubebuilder:validation:items:Enum=RuntimeDefault;Localhost
+	// +listType=set
+	// +optional
+	AllowedTypes []corev1.AppArmorProfileType `json:"allowedTypes,omitempty"`
+
+	// AllowedLocalhostProfiles lists the localhostProfile names Localhost
+	// profiles may use. Entries may contain path.Match wildcards. Empty allows
+	// any profile.
+	// +listType=set
+	// +optional
+	AllowedLocalhostProfiles []string `json:"allowedLocalhostProfiles,omitempty"`
+}
+
+// IDRangePolicy restricts a user or group ID to allowed ranges.
+type IDRangePolicy struct {
+	// Ranges lists the allowed IDs.
+	// +optional
+	Ranges []IDRange `json:"ranges,omitempty"`
+
+	// NamespaceAnnotation names an annotation of the Pod's namespace holding
+	// the IDs allowed in that namespace, such as openshift.io/sa.scc.uid-range.
+	// The value is a comma-separated list of "<start>/<size>" or "<min>-<max>"
+	// blocks. When the annotation is present its ranges replace Ranges; a
+	// malformed value allows no IDs.
+	// +optional
+	NamespaceAnnotation string `json:"namespaceAnnotation,omitempty"`
+}
+
+// IDRange is an inclusive range of user or group IDs.
+type IDRange struct {
+	// Min is the lowest allowed ID.
+	// +kubebuilder:validation:Minimum=0
+	Min int64 `json:"min"`
+
+	// Max is the highest allowed ID.
+	// +kubebuilder:validation:Minimum=0
+	Max int64 `json:"max"`
+}
+
+// ServiceAccountPolicy restricts the ServiceAccount of Pods.
+type ServiceAccountPolicy struct {
+	// DisallowTokenAutomount requires Pods to set
+	// spec.automountServiceAccountToken: false, unless they are annotated with
+	// core.platform.f3nr1r.io/automount-service-account-token: "true". The
+	// setting of the ServiceAccount itself is not consulted.
+	// +optional
+	DisallowTokenAutomount bool `json:"disallowTokenAutomount,omitempty"`
+
+	// DisallowDefault forbids the default ServiceAccount, which Pods also run
+	// as when they set no spec.serviceAccountName.
+	// +optional
+	DisallowDefault bool `json:"disallowDefault,omitempty"`
+
+	// Allowed lists the ServiceAccounts Pods may run as. Entries may contain
+	// path.Match wildcards such as "ci-*". Empty allows any ServiceAccount.
+	// +listType=set
+	// +optional
+	Allowed []string `json:"allowed,omitempty"`
+}
+
+// DebugPolicy lists who may add ephemeral containers under a debug baseline.
+type DebugPolicy struct {
+	// Users lists the usernames allowed to break glass.
+	// +listType=set
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Groups lists the groups whose members are allowed to break glass.
+	// +listType=set
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// ImagePolicy restricts the container images of containers, init containers
+// and ephemeral containers.
+type ImagePolicy struct {
+	// AllowedRegistries lists the registries or repository prefixes images must
+	// come from, e.g. "registry.example.com" or "ghcr.io/my-org". Docker Hub
+	// short names are expanded (nginx becomes docker.io/library/nginx) before
+	// matching. Empty allows any registry.
+	// +listType=set
+	// +optional
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+
+	// DisallowLatestTag forbids images tagged :latest and images without a tag
+	// or digest, which implicitly resolve to :latest.
+	// +optional
+	DisallowLatestTag bool `json:"disallowLatestTag,omitempty"`
+
+	// RequireDigest requires images to be pinned by an @sha256 digest.
+	// +optional
+	RequireDigest bool `json:"requireDigest,omitempty"`
+}
+
 // SecurityBaselineStatus defines the observed state of SecurityBaseline.
 type SecurityBaselineStatus struct {
 	// For Kubernetes API conventions, see:
@@ -53,6 +416,68 @@ type SecurityBaselineStatus struct {
 	// +listMapKey=type
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Compliance summarizes the last background scan of the running Pods the
+	// baseline applies to.
+	// +optional
+	Compliance *ComplianceStatus `json:"compliance,omitempty"`
+}
+
+// ComplianceStatus summarizes how many running Pods violate a baseline.
+type ComplianceStatus struct {
+	// ObservedGeneration is the baseline generation the scan evaluated.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastChangeTime is when the scan results last changed. Scans that find
+	// the same results do not update the status.
+	// +optional
+	LastChangeTime *metav1.Time `json:"lastChangeTime,omitempty"`
+
+	// ScannedPods is the number of running Pods the baseline applies to.
+	ScannedPods int32 `json:"scannedPods"`
+
+	// ViolatingPods is the number of scanned Pods with at least one violation.
+	ViolatingPods int32 `json:"violatingPods"`
+
+	// Violations is the total number of violations across all scanned Pods.
+	Violations int32 `json:"violations"`
+
+	// ExemptedViolations is the number of violations exempted by a PolicyException.
+	// +optional
+	ExemptedViolations int32 `json:"exemptedViolations,omitempty"`
+
+	// ViolatingWorkloads is the number of workloads with violating Pods. It may
+	// exceed the length of Offenders, which is bounded.
+	// +optional
+	ViolatingWorkloads int32 `json:"violatingWorkloads,omitempty"`
+
+	// Offenders lists a bounded number of workloads with violating Pods,
+	// sorted by namespace, kind and name.
+	// +listType=atomic
+	// +optional
+	Offenders []ComplianceOffender `json:"offenders,omitempty"`
+}
+
+// ComplianceOffender identifies a workload whose Pods violate a baseline.
+type ComplianceOffender struct {
+	// Kind of the workload, e.g. Deployment, StatefulSet, DaemonSet, or Pod
+	// for Pods without a supported owner.
+	Kind string `json:"kind"`
+
+	// Namespace of the workload.
+	Namespace string `json:"namespace"`
+
+	// Name of the workload.
+	Name string `json:"name"`
+
+	// Pods is the number of violating Pods of the workload.
+	Pods int32 `json:"pods"`
+
+	// Violations lists a bounded number of the violations of the workload's Pods.
+	// +listType=atomic
+	// +optional
+	Violations []string `json:"violations,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,80 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestSecurityBaselineRulesListsEveryRule keeps SecurityBaselineRules in sync
+// with the Rule constants and the validation enum, so a new rule is never
+// rejected as unknown.
+func TestSecurityBaselineRulesListsEveryRule(t *testing.T) {
+	t.Parallel()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "securitybaseline_types.go", nil, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("failed to parse securitybaseline_types.go: %v", err)
+	}
+
+	var constants, enum []SecurityBaselineRule
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			switch spec := spec.(type) {
+			case *ast.ValueSpec:
+				if ident, ok := spec.Type.(*ast.Ident); gen.Tok != token.CONST || !ok || ident.Name != "SecurityBaselineRule" {
+					continue
+				}
+				for _, value := range spec.Values {
+					rule, err := strconv.Unquote(value.(*ast.BasicLit).Value)
+					if err != nil {
+						t.Fatalf("failed to unquote %s: %v", spec.Names[0].Name, err)
+					}
+					constants = append(constants, SecurityBaselineRule(rule))
+				}
+			case *ast.TypeSpec:
+				if spec.Name.Name != "SecurityBaselineRule" {
+					continue
+				}
+				for _, comment := range gen.Doc.List {
+					if values, ok := strings.CutPrefix(comment.Text, "// +kubebuilder:validation:Enum="); ok {
+						for value := range strings.SplitSeq(values, ";") {
+							enum = append(enum, SecurityBaselineRule(value))
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if !slices.Equal(SecurityBaselineRules, constants) {
+		t.Errorf("SecurityBaselineRules = %v, want the Rule constants %v", SecurityBaselineRules, constants)
+	}
+	if !slices.Equal(SecurityBaselineRules, enum) {
+		t.Errorf("SecurityBaselineRules = %v, want the validation enum %v", SecurityBaselineRules, enum)
+	}
+}
@@ -22,6 +22,11 @@ import (
 
 // TelemetryProfileSpec defines the desired state of TelemetryProfile
 type TelemetryProfileSpec struct {
+	// PodSelector restricts the profile to Pods whose labels match. When unset the
+	// profile applies to every Pod in its namespace.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
 	// TracingEndpoint specifies the OpenTelemetry OTLP endpoint to inject
 	// +optional
 	TracingEndpoint string `json:"tracingEndpoint,omitempty"`
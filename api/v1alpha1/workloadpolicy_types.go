@@ -20,8 +20,31 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// MergeStrategy defines how the defaults of a WorkloadPolicy combine with
+// those of lower priority policies selecting the same Pod.
+// +kubebuilder:validation:Enum=Merge;Override;HighestPriorityOnly
+type MergeStrategy string
+
+const (
+	// MergeStrategyMerge lets lower priority policies fill in, key by key,
+	// whatever defaults the policy leaves unset.
+	MergeStrategyMerge MergeStrategy = "Merge"
+	// MergeStrategyOverride applies each default the policy sets as a whole:
+	// lower priority policies do not add keys to it, but still fill in the
+	// defaults the policy does not set.
+	MergeStrategyOverride MergeStrategy = "Override"
+	// MergeStrategyHighestPriorityOnly ignores every lower priority policy.
+	MergeStrategyHighestPriorityOnly MergeStrategy = "HighestPriorityOnly"
+)
+
 // WorkloadPolicySpec defines the desired state of WorkloadPolicy
 type WorkloadPolicySpec struct {
+	// PodSelector restricts the policy to Pods whose labels match, and the
+	// horizontal scaling defaults to Deployments whose Pod template labels
+	// match. When unset the policy applies to every Pod in its namespace.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
 	// DefaultRequests defines the default resource requests applied to containers
 	// +optional
 	DefaultRequests map[string]string `json:"defaultRequests,omitempty"`
@@ -30,16 +53,79 @@ type WorkloadPolicySpec struct {
 	// +optional
 	DefaultLimits map[string]string `json:"defaultLimits,omitempty"`
 
+	// InitContainerResources defines the default resource requests and limits
+	// applied to init containers that run to completion. Defaulted requests are
+	// capped so that the init phase never requests more than the running Pod.
+	// When unset, such init containers are left alone.
+	// +optional
+	InitContainerResources *ContainerResourceDefaults `json:"initContainerResources,omitempty"`
+
+	// SidecarResources defines the default resource requests and limits applied
+	// to native sidecars, init containers with restartPolicy Always. When unset,
+	// sidecars are left alone.
+	// +optional
+	SidecarResources *ContainerResourceDefaults `json:"sidecarResources,omitempty"`
+
+	// ContainerResources defines resource defaults for specific containers,
+	// init containers and sidecars, e.g. a service mesh proxy. The first rule
+	// matching a container applies; the defaults of its kind of containers
+	// then fill in the resources the rule leaves unset.
+	// +listType=atomic
+	// +optional
+	ContainerResources []ContainerResourceRule `json:"containerResources,omitempty"`
+
+	// MinRequests defines the minimum resource requests of every container,
+	// init container and sidecar. Pods with a container that requests less, or
+	// does not request the resource at all, are denied.
+	// +optional
+	MinRequests map[string]string `json:"minRequests,omitempty"`
+
+	// MaxLimits defines the maximum resource limits of every container, init
+	// container and sidecar. Pods with a container that sets a higher limit, or
+	// no limit for the resource at all, are denied.
+	// +optional
+	MaxLimits map[string]string `json:"maxLimits,omitempty"`
+
+	// MaxLimitRequestRatio defines, per resource, how many times its request
+	// the limit of a container may be, e.g. "4". Pods with a container that
+	// sets a limit for the resource without requesting it are denied.
+	// +optional
+	MaxLimitRequestRatio map[string]string `json:"maxLimitRequestRatio,omitempty"`
+
+	// GenerateLimitRange makes the operator maintain a LimitRange in the
+	// namespace mirroring the defaultRequests, defaultLimits and resource
+	// bounds of the policy, so they still apply to containers while the
+	// webhooks are unavailable. The API server applies its defaults before the
+	// webhooks run, so they take precedence over those of other policies. Only
+	// supported on policies without a podSelector.
+	// +optional
+	GenerateLimitRange bool `json:"generateLimitRange,omitempty"`
+
+	// Quota defines a ResourceQuota the operator maintains in the namespace.
+	// Only supported on policies without a podSelector.
+	// +optional
+	Quota *QuotaPolicy `json:"quota,omitempty"`
+
 	// MandatoryLabels defines a map of labels and their default values that must be present on workloads
 	// +optional
 	MandatoryLabels map[string]string `json:"mandatoryLabels,omitempty"`
 
 	// Priority determines the precedence of the policy when multiple apply.
-	// Higher numbers indicate higher priority.
+	// Higher numbers indicate higher priority; policies of equal priority are
+	// ordered by name.
 	// +kubebuilder:default=0
 	// +optional
 	Priority int32 `json:"priority,omitempty"`
 
+	// MergeStrategy defines how the defaults of this policy combine with those
+	// of lower priority policies of the same scope selecting a Pod. Cluster
+	// policies always fill in what namespaced policies leave unset. Resource
+	// bounds are not defaults: those of every policy selecting a Pod are
+	// enforced.
+	// +kubebuilder:default=Merge
+	// +optional
+	MergeStrategy MergeStrategy `json:"mergeStrategy,omitempty"`
+
 	// HorizontalScaling defines default Horizontal Pod Autoscaler (HPA) behavior
 	// for Deployment workloads governed by this policy.
 	// +optional
@@ -55,6 +141,51 @@ const (
 	DefaultHPATargetCPU int32 = 80
 )
 
+// ContainerResourceDefaults defines the default resource requests and limits
+// of a kind of containers.
+type ContainerResourceDefaults struct {
+	// DefaultRequests defines the default resource requests applied to the containers
+	// +optional
+	DefaultRequests map[string]string `json:"defaultRequests,omitempty"`
+
+	// DefaultLimits defines the default resource limits applied to the containers
+	// +optional
+	DefaultLimits map[string]string `json:"defaultLimits,omitempty"`
+}
+
+// ContainerSelector matches containers by name or image. A container matches
+// when it matches every field that is set.
+type ContainerSelector struct {
+	// Name is a glob pattern matched against the container name, e.g.
+	// "istio-proxy" or "log-*".
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// ImagePrefix is matched against the start of the container image as
+	// written in the Pod, e.g. "docker.io/istio/".
+	// +optional
+	ImagePrefix string `json:"imagePrefix,omitempty"`
+}
+
+// ContainerResourceRule defines the default resource requests and limits of
+// the containers it matches.
+// +kubebuilder:validation:XValidation:rule="has(self.name) || has(self.imagePrefix)",message="a containerResources rule must set name or imagePrefix"
+type ContainerResourceRule struct {
+	ContainerSelector `json:",inline"`
+
+	ContainerResourceDefaults `json:",inline"`
+}
+
+// QuotaPolicy defines the ResourceQuota generated for the namespace of a
+// WorkloadPolicy.
+type QuotaPolicy struct {
+	// Hard is the set of hard limits for each named resource, e.g.
+	// requests.cpu, limits.memory or pods, as in a ResourceQuota.
+	// +kubebuilder:validation:MinProperties=1
+	// +required
+	Hard map[string]string `json:"hard"`
+}
+
 // HorizontalScalingPolicy defines default HPA behavior for workloads.
 type HorizontalScalingPolicy struct {
 	// EnabledByDefault indicates whether HPA should be created for workloads
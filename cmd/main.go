@@ -20,6 +20,7 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -37,6 +38,8 @@ import (
 
 	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
 	"github.com/f3nr1r/platform-governance-operator/internal/controller"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport"
+	wgpolicyv1alpha2 "github.com/f3nr1r/platform-governance-operator/internal/policyreport/v1alpha2"
 	corewebhook "github.com/f3nr1r/platform-governance-operator/internal/webhook/core"
 	webhookv1alpha1 "github.com/f3nr1r/platform-governance-operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
@@ -51,6 +54,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(corev1alpha1.AddToScheme(scheme))
+	utilruntime.Must(wgpolicyv1alpha2.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -63,6 +67,9 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var complianceScanInterval time.Duration
+	var policyReportInterval, policyReportRetention time.Duration
+	var keyNamespace string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -82,6 +89,16 @@ func main() {
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	opts := zap.Options{}
+	flag.DurationVar(&complianceScanInterval, "compliance-scan-interval", 10*time.Minute,
+		"How often running Pods are re-evaluated against SecurityBaselines and ClusterSecurityBaselines. "+
+			"Set to 0 to only scan when a baseline changes.")
+	flag.DurationVar(&policyReportInterval, "policy-report-interval", 30*time.Second,
+		"How often changed PolicyReports (wgpolicyk8s.io/v1alpha2) are written. Set to 0 to disable PolicyReports.")
+	flag.DurationVar(&policyReportRetention, "policy-report-retention", 24*time.Hour,
+		"How long admission results stay in PolicyReports after they were last seen. Set to 0 to keep them.")
+	flag.StringVar(&keyNamespace, "image-verification-key-namespace", os.Getenv("POD_NAMESPACE"),
+		"The namespace Secrets holding ImageVerificationPolicy public keys are read from. "+
+			"Defaults to the namespace the operator runs in.")
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
@@ -178,11 +195,28 @@ func main() {
 		os.Exit(1)
 	}
 
+	var reports *policyreport.Store
+	if policyReportInterval > 0 {
+		reports = policyreport.NewStore(mgr.Elected())
+		if err := mgr.Add(&policyreport.Writer{
+			Client:    mgr.GetClient(),
+			Reader:    mgr.GetAPIReader(),
+			Store:     reports,
+			Interval:  policyReportInterval,
+			Retention: policyReportRetention,
+		}); err != nil {
+			setupLog.Error(err, "Failed to add PolicyReport writer")
+			os.Exit(1)
+		}
+	}
+
 	if err := (&controller.SecurityBaselineReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 		//nolint:staticcheck // controller-runtime recorder migration pending
-		Recorder: mgr.GetEventRecorderFor("securitybaseline-controller"),
+		Recorder:     mgr.GetEventRecorderFor("securitybaseline-controller"),
+		ScanInterval: complianceScanInterval,
+		Reports:      reports,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "Failed to create controller", "controller", "SecurityBaseline")
 		os.Exit(1)
@@ -205,6 +239,60 @@ func main() {
 		setupLog.Error(err, "Failed to create controller", "controller", "TelemetryProfile")
 		os.Exit(1)
 	}
+	if err := (&controller.ImageVerificationPolicyReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		//nolint:staticcheck // controller-runtime recorder migration pending
+		Recorder: mgr.GetEventRecorderFor("imageverificationpolicy-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Failed to create controller", "controller", "ImageVerificationPolicy")
+		os.Exit(1)
+	}
+	if err := (&controller.ClusterSecurityBaselineReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		//nolint:staticcheck // controller-runtime recorder migration pending
+		Recorder:     mgr.GetEventRecorderFor("clustersecuritybaseline-controller"),
+		ScanInterval: complianceScanInterval,
+		Reports:      reports,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Failed to create controller", "controller", "ClusterSecurityBaseline")
+		os.Exit(1)
+	}
+	if err := (&controller.ClusterWorkloadPolicyReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		//nolint:staticcheck // controller-runtime recorder migration pending
+		Recorder: mgr.GetEventRecorderFor("clusterworkloadpolicy-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Failed to create controller", "controller", "ClusterWorkloadPolicy")
+		os.Exit(1)
+	}
+	if err := (&controller.ClusterTelemetryProfileReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		//nolint:staticcheck // controller-runtime recorder migration pending
+		Recorder: mgr.GetEventRecorderFor("clustertelemetryprofile-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Failed to create controller", "controller", "ClusterTelemetryProfile")
+		os.Exit(1)
+	}
+	if err := (&controller.PolicyExceptionReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		//nolint:staticcheck // controller-runtime recorder migration pending
+		Recorder: mgr.GetEventRecorderFor("policyexception-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Failed to create controller", "controller", "PolicyException")
+		os.Exit(1)
+	}
+	if err := (&controller.EffectiveGovernanceReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Failed to create controller", "controller", "EffectiveGovernance")
+		os.Exit(1)
+	}
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		if err := webhookv1alpha1.SetupSecurityBaselineWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "Failed to create webhook", "webhook", "SecurityBaseline")
@@ -218,14 +306,42 @@ func main() {
 			setupLog.Error(err, "Failed to create webhook", "webhook", "TelemetryProfile")
 			os.Exit(1)
 		}
-		if err := corewebhook.SetupPodWebhookWithManager(mgr); err != nil {
+		if err := webhookv1alpha1.SetupImageVerificationPolicyWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Failed to create webhook", "webhook", "ImageVerificationPolicy")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupClusterSecurityBaselineWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Failed to create webhook", "webhook", "ClusterSecurityBaseline")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupClusterWorkloadPolicyWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Failed to create webhook", "webhook", "ClusterWorkloadPolicy")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupClusterTelemetryProfileWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Failed to create webhook", "webhook", "ClusterTelemetryProfile")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupPolicyExceptionWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Failed to create webhook", "webhook", "PolicyException")
+			os.Exit(1)
+		}
+		if err := corewebhook.SetupPodWebhookWithManager(mgr, reports, keyNamespace); err != nil {
 			setupLog.Error(err, "Failed to create webhook", "webhook", "Pod")
 			os.Exit(1)
 		}
-		if err := corewebhook.SetupPodMutatorWebhookWithManager(mgr); err != nil {
+		if err := corewebhook.SetupPodMutatorWebhookWithManager(mgr, reports); err != nil {
 			setupLog.Error(err, "Failed to create mutator webhook", "webhook", "Pod")
 			os.Exit(1)
 		}
+		if err := corewebhook.SetupWorkloadMutatorWebhookWithManager(mgr, reports); err != nil {
+			setupLog.Error(err, "Failed to create mutator webhook", "webhook", "Workload")
+			os.Exit(1)
+		}
+		if err := corewebhook.SetupWorkloadWebhookWithManager(mgr, reports); err != nil {
+			setupLog.Error(err, "Failed to create webhook", "webhook", "Workload")
+			os.Exit(1)
+		}
 	}
 	// +kubebuilder:scaffold:builder
 
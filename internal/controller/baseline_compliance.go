@@ -0,0 +1,350 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/governance"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport"
+)
+
+const (
+	// maxComplianceOffenders bounds the workloads listed in a baseline's
+	// compliance status, keeping the object well below the etcd size limit.
+	maxComplianceOffenders = 20
+	// maxOffenderViolations bounds the violations listed per workload.
+	maxOffenderViolations = 10
+)
+
+// +kubebuilder:rbac:groups="",resources=pods;namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=policyexceptions,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// workloadRef identifies the workload owning a Pod.
+type workloadRef struct {
+	Kind      string
+	Namespace string
+	Name      string
+	UID       types.UID
+}
+
+// subject returns the workload as the subject of a PolicyReport result.
+func (w workloadRef) subject() corev1.ObjectReference {
+	apiVersion := "v1"
+	switch w.Kind {
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet":
+		apiVersion = "apps/v1"
+	case "Job", "CronJob":
+		apiVersion = "batch/v1"
+	}
+	return corev1.ObjectReference{APIVersion: apiVersion, Kind: w.Kind, Namespace: w.Namespace, Name: w.Name, UID: w.UID}
+}
+
+// violationEvents remembers the violations last reported per baseline and
+// workload in a BaselineViolation event, so that a workload staying
+// non-compliant is not reported again on every scan. The zero value is ready
+// to use.
+type violationEvents struct {
+	mu       sync.Mutex
+	reported map[policyreport.PolicyRef]map[workloadRef]string
+}
+
+// changed records the violations of the offenders of baseline found by a scan
+// and reports which offenders' violations differ from the last scan.
+// Workloads that are no longer offenders are forgotten.
+func (e *violationEvents) changed(baseline policyreport.PolicyRef, offenders []*offender) []*offender {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	previous := e.reported[baseline]
+	current := make(map[workloadRef]string, len(offenders))
+	var changed []*offender
+	for _, o := range offenders {
+		violations := strings.Join(slices.Sorted(slices.Values(o.violations)), "\n")
+		current[o.workload] = violations
+		if reported, ok := previous[o.workload]; !ok || reported != violations {
+			changed = append(changed, o)
+		}
+	}
+	if e.reported == nil {
+		e.reported = map[policyreport.PolicyRef]map[workloadRef]string{}
+	}
+	e.reported[baseline] = current
+	return changed
+}
+
+// forget drops what was reported for a deleted baseline.
+func (e *violationEvents) forget(baseline policyreport.PolicyRef) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.reported, baseline)
+}
+
+// complianceScanner re-evaluates running Pods against a baseline, so Pods
+// admitted before the baseline was created or changed are reported too.
+type complianceScanner struct {
+	client   client.Client
+	recorder record.EventRecorder
+	// events limits BaselineViolation events to offenders whose violations
+	// changed since the last scan. Every offender is reported when nil.
+	events *violationEvents
+	// reports receives a result per baseline rule and workload, if set.
+	reports *policyreport.Store
+	// owners caches ReplicaSet and Job owner lookups for a single scan.
+	owners map[types.UID]workloadRef
+	// namespaceAnnotations caches the annotations of the scanned namespaces.
+	namespaceAnnotations map[string]map[string]string
+}
+
+func newComplianceScanner(c client.Client, recorder record.EventRecorder, events *violationEvents,
+	reports *policyreport.Store) *complianceScanner {
+	return &complianceScanner{
+		client:               c,
+		recorder:             recorder,
+		events:               events,
+		reports:              reports,
+		owners:               map[types.UID]workloadRef{},
+		namespaceAnnotations: map[string]map[string]string{},
+	}
+}
+
+// offender accumulates the violations of a workload during a scan.
+type offender struct {
+	workload   workloadRef
+	pods       int32
+	violations []string
+}
+
+// scan evaluates pods against the baseline, records a BaselineViolation event
+// on every offending workload whose violations changed, replaces the baseline's background results in
+// the PolicyReports and returns the resulting compliance status. exceptions
+// holds the PolicyExceptions of every scanned namespace.
+func (s *complianceScanner) scan(ctx context.Context, kind string, baseline *corev1alpha1.SecurityBaseline,
+	pods []corev1.Pod, exceptions []corev1alpha1.PolicyException, now time.Time) (*corev1alpha1.ComplianceStatus, error) {
+	exceptionsByNamespace := map[string][]corev1alpha1.PolicyException{}
+	for _, exception := range exceptions {
+		exceptionsByNamespace[exception.Namespace] = append(exceptionsByNamespace[exception.Namespace], exception)
+	}
+
+	status := &corev1alpha1.ComplianceStatus{ObservedGeneration: baseline.Generation}
+	offenders := map[workloadRef]*offender{}
+	var results []policyreport.Result
+	for i := range pods {
+		pod := &pods[i]
+		if !podIsRunning(pod) || !governance.BaselineSelectsPod(baseline, pod) {
+			continue
+		}
+		status.ScannedPods++
+
+		annotations, err := s.annotationsOf(ctx, pod.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		violations, exempted := governance.EvaluateBaseline(pod, kind, baseline, annotations, exceptionsByNamespace[pod.Namespace], now)
+		status.ExemptedViolations += int32(len(exempted))
+		if len(violations) == 0 && s.reports == nil {
+			continue
+		}
+
+		workload, err := s.owningWorkload(ctx, pod)
+		if err != nil {
+			return nil, err
+		}
+		if s.reports != nil {
+			results = append(results, governance.BaselineReportResults(workload.subject(), kind, baseline, violations, exempted)...)
+		}
+		if len(violations) == 0 {
+			continue
+		}
+		status.ViolatingPods++
+		status.Violations += int32(len(violations))
+
+		o, ok := offenders[workload]
+		if !ok {
+			o = &offender{workload: workload}
+			offenders[workload] = o
+		}
+		o.pods++
+		for _, violation := range violations {
+			if text := violation.String(); !slices.Contains(o.violations, text) {
+				o.violations = append(o.violations, text)
+			}
+		}
+	}
+
+	ref := policyreport.PolicyRef{Kind: kind, Namespace: baseline.Namespace, Name: baseline.Name}
+	s.reports.Replace(policyreport.TriggerBackground, ref, now, results)
+
+	sorted := make([]*offender, 0, len(offenders))
+	for _, o := range offenders {
+		sorted = append(sorted, o)
+	}
+	slices.SortFunc(sorted, func(a, b *offender) int {
+		return cmp.Or(
+			strings.Compare(a.workload.Namespace, b.workload.Namespace),
+			strings.Compare(a.workload.Kind, b.workload.Kind),
+			strings.Compare(a.workload.Name, b.workload.Name),
+		)
+	})
+	status.ViolatingWorkloads = int32(len(sorted))
+
+	changed := sorted
+	if s.events != nil {
+		changed = s.events.changed(ref, sorted)
+	}
+	for _, o := range changed {
+		s.recordViolationEvent(kind, baseline.Name, o)
+	}
+	for _, o := range sorted[:min(len(sorted), maxComplianceOffenders)] {
+		status.Offenders = append(status.Offenders, corev1alpha1.ComplianceOffender{
+			Kind:       o.workload.Kind,
+			Namespace:  o.workload.Namespace,
+			Name:       o.workload.Name,
+			Pods:       o.pods,
+			Violations: o.violations[:min(len(o.violations), maxOffenderViolations)],
+		})
+	}
+	return status, nil
+}
+
+// recordViolationEvent emits a BaselineViolation event on the offending workload.
+func (s *complianceScanner) recordViolationEvent(kind, baselineName string, o *offender) {
+	if s.recorder == nil {
+		return
+	}
+	object := workloadObject(o.workload)
+	lines := []string{fmt.Sprintf("%d Pod(s) violate %s %s with %d violation(s):", o.pods, kind, baselineName, len(o.violations))}
+	for _, violation := range o.violations[:min(len(o.violations), maxOffenderViolations)] {
+		lines = append(lines, "- "+violation)
+	}
+	s.recorder.Event(object, corev1.EventTypeWarning, "BaselineViolation", strings.Join(lines, "\n"))
+}
+
+// owningWorkload resolves the top-level workload of a Pod: the Deployment of
+// a ReplicaSet, the CronJob of a Job, or its StatefulSet or DaemonSet. Pods
+// without a controller, or controlled by other kinds, are their own workload.
+func (s *complianceScanner) owningWorkload(ctx context.Context, pod *corev1.Pod) (workloadRef, error) {
+	podRef := workloadRef{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name, UID: pod.UID}
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return podRef, nil
+	}
+	ref := workloadRef{Kind: owner.Kind, Namespace: pod.Namespace, Name: owner.Name, UID: owner.UID}
+
+	var intermediate client.Object
+	switch {
+	case owner.APIVersion == "apps/v1" && (owner.Kind == "StatefulSet" || owner.Kind == "DaemonSet"):
+		return ref, nil
+	case owner.APIVersion == "apps/v1" && owner.Kind == "ReplicaSet":
+		intermediate = &appsv1.ReplicaSet{}
+	case owner.APIVersion == "batch/v1" && owner.Kind == "Job":
+		intermediate = &batchv1.Job{}
+	default:
+		return podRef, nil
+	}
+
+	if cached, ok := s.owners[owner.UID]; ok {
+		return cached, nil
+	}
+	err := s.client.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: owner.Name}, intermediate)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return workloadRef{}, err
+	default:
+		if parent := metav1.GetControllerOf(intermediate); parent != nil && (parent.Kind == "Deployment" || parent.Kind == "CronJob") {
+			ref = workloadRef{Kind: parent.Kind, Namespace: pod.Namespace, Name: parent.Name, UID: parent.UID}
+		}
+	}
+	s.owners[owner.UID] = ref
+	return ref, nil
+}
+
+// annotationsOf returns the annotations of the named namespace, which may hold
+// the ID ranges allowed in it. A namespace that no longer exists has none.
+func (s *complianceScanner) annotationsOf(ctx context.Context, name string) (map[string]string, error) {
+	if annotations, ok := s.namespaceAnnotations[name]; ok {
+		return annotations, nil
+	}
+	var namespace corev1.Namespace
+	if err := s.client.Get(ctx, types.NamespacedName{Name: name}, &namespace); client.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+	s.namespaceAnnotations[name] = namespace.Annotations
+	return namespace.Annotations, nil
+}
+
+// workloadObject returns an object identifying the workload, suitable as the
+// subject of an event.
+func workloadObject(workload workloadRef) runtime.Object {
+	meta := metav1.ObjectMeta{Name: workload.Name, Namespace: workload.Namespace, UID: workload.UID}
+	switch workload.Kind {
+	case "Deployment":
+		return &appsv1.Deployment{ObjectMeta: meta}
+	case "StatefulSet":
+		return &appsv1.StatefulSet{ObjectMeta: meta}
+	case "DaemonSet":
+		return &appsv1.DaemonSet{ObjectMeta: meta}
+	case "ReplicaSet":
+		return &appsv1.ReplicaSet{ObjectMeta: meta}
+	case "Job":
+		return &batchv1.Job{ObjectMeta: meta}
+	case "CronJob":
+		return &batchv1.CronJob{ObjectMeta: meta}
+	default:
+		return &corev1.Pod{ObjectMeta: meta}
+	}
+}
+
+func podIsRunning(pod *corev1.Pod) bool {
+	return pod.DeletionTimestamp == nil && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed
+}
+
+// setComplianceStatus stores the scan result and reports whether it differs
+// from the previous one. LastChangeTime only moves when the results change.
+func setComplianceStatus(status *corev1alpha1.SecurityBaselineStatus, compliance *corev1alpha1.ComplianceStatus, now time.Time) bool {
+	if status.Compliance != nil {
+		compliance.LastChangeTime = status.Compliance.LastChangeTime
+		if equality.Semantic.DeepEqual(status.Compliance, compliance) {
+			return false
+		}
+	}
+	compliance.LastChangeTime = &metav1.Time{Time: now}
+	status.Compliance = compliance
+	return true
+}
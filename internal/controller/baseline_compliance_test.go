@@ -0,0 +1,255 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport/v1alpha2"
+)
+
+func newComplianceScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	s := newStatusHelperScheme(t)
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatalf("failed to add client-go types to scheme: %v", err)
+	}
+	return s
+}
+
+func rootPod(name string, owner *metav1.OwnerReference) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", UID: types.UID(name)},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx"}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if owner != nil {
+		pod.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return pod
+}
+
+func TestComplianceScanAttributesPodsToDeployment(t *testing.T) {
+	t.Parallel()
+
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-5d8f",
+			Namespace: "team-a",
+			UID:       "rs-uid",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "deploy-uid", Controller: ptr.To(true),
+			}},
+		},
+	}
+	rsOwner := &metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-5d8f", UID: "rs-uid", Controller: ptr.To(true)}
+	finished := rootPod("web-old", rsOwner)
+	finished.Status.Phase = corev1.PodSucceeded
+	pods := []corev1.Pod{rootPod("web-1", rsOwner), rootPod("web-2", rsOwner), rootPod("standalone", nil), finished}
+
+	c := fake.NewClientBuilder().WithScheme(newComplianceScheme(t)).WithObjects(replicaSet).Build()
+	recorder := record.NewFakeRecorder(10)
+	baseline := &corev1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted", Namespace: "team-a", Generation: 3},
+		Spec:       corev1alpha1.SecurityBaselineSpec{RunAsNonRoot: ptr.To(true)},
+	}
+
+	status, err := newComplianceScanner(c, recorder, nil, nil).scan(context.Background(), "SecurityBaseline", baseline, pods, nil, time.Now())
+	if err != nil {
+		t.Fatalf("scan returned error: %v", err)
+	}
+	if status.ObservedGeneration != 3 || status.ScannedPods != 3 || status.ViolatingPods != 3 || status.ViolatingWorkloads != 2 {
+		t.Fatalf("unexpected counts: %+v", status)
+	}
+	if len(status.Offenders) != 2 {
+		t.Fatalf("expected 2 offenders, got %+v", status.Offenders)
+	}
+	deployment := status.Offenders[0]
+	if deployment.Kind != "Deployment" || deployment.Name != "web" || deployment.Pods != 2 || len(deployment.Violations) != 1 {
+		t.Fatalf("expected the ReplicaSet Pods to be attributed to Deployment web, got %+v", deployment)
+	}
+	if standalone := status.Offenders[1]; standalone.Kind != "Pod" || standalone.Name != "standalone" {
+		t.Fatalf("expected the standalone Pod to be its own workload, got %+v", standalone)
+	}
+
+	event := <-recorder.Events
+	if !strings.HasPrefix(event, "Warning BaselineViolation 2 Pod(s) violate SecurityBaseline restricted") {
+		t.Fatalf("unexpected event: %q", event)
+	}
+}
+
+func TestComplianceScanOnlyReportsChangedOffenders(t *testing.T) {
+	t.Parallel()
+
+	c := fake.NewClientBuilder().WithScheme(newComplianceScheme(t)).Build()
+	recorder := record.NewFakeRecorder(10)
+	events := &violationEvents{}
+	baseline := &corev1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted", Namespace: "team-a"},
+		Spec:       corev1alpha1.SecurityBaselineSpec{RunAsNonRoot: ptr.To(true)},
+	}
+	scan := func(pods ...corev1.Pod) {
+		t.Helper()
+		if _, err := newComplianceScanner(c, recorder, events, nil).scan(context.Background(), "SecurityBaseline", baseline,
+			pods, nil, time.Now()); err != nil {
+			t.Fatalf("scan returned error: %v", err)
+		}
+	}
+
+	scan(rootPod("api", nil))
+	scan(rootPod("api", nil))
+	if len(recorder.Events) != 1 {
+		t.Fatalf("expected an unchanged offender to be reported once, got %d events", len(recorder.Events))
+	}
+	<-recorder.Events
+
+	baseline.Spec.ReadOnlyRootFilesystem = ptr.To(true)
+	scan(rootPod("api", nil))
+	if len(recorder.Events) != 1 {
+		t.Fatalf("expected changed violations to be reported again, got %d events", len(recorder.Events))
+	}
+	<-recorder.Events
+
+	scan()
+	scan(rootPod("api", nil))
+	if len(recorder.Events) != 1 {
+		t.Fatalf("expected an offender to be reported again after becoming compliant, got %d events", len(recorder.Events))
+	}
+}
+
+func TestComplianceScanReportsResultsPerWorkload(t *testing.T) {
+	t.Parallel()
+
+	scheme := newComplianceScheme(t)
+	if err := v1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add wgpolicyk8s.io/v1alpha2 to scheme: %v", err)
+	}
+	compliant := rootPod("api", nil)
+	compliant.Spec.SecurityContext = &corev1.PodSecurityContext{RunAsNonRoot: ptr.To(true)}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	store := policyreport.NewStore(nil)
+	baseline := &corev1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted", Namespace: "team-a"},
+		Spec:       corev1alpha1.SecurityBaselineSpec{RunAsNonRoot: ptr.To(true)},
+	}
+
+	if _, err := newComplianceScanner(c, nil, nil, store).scan(context.Background(), "SecurityBaseline", baseline,
+		[]corev1.Pod{rootPod("web", nil), compliant}, nil, time.Now()); err != nil {
+		t.Fatalf("scan returned error: %v", err)
+	}
+	(&policyreport.Writer{Client: c, Reader: c, Store: store}).Flush(context.Background(), time.Now())
+
+	report := &v1alpha2.PolicyReport{}
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: "team-a", Name: policyreport.ReportName}, report); err != nil {
+		t.Fatalf("expected a PolicyReport: %v", err)
+	}
+	if report.Summary != (v1alpha2.PolicyReportSummary{Pass: 1, Fail: 1}) {
+		t.Fatalf("expected one pass and one fail, got %+v", report.Results)
+	}
+	if failed := report.Results[0]; failed.Subjects[0].Name != "web" || failed.Properties["trigger"] != "background" {
+		t.Fatalf("expected a background fail for Pod web, got %+v", failed)
+	}
+}
+
+func TestComplianceScanCountsExemptions(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	exception := corev1alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "team-a"},
+		Spec: corev1alpha1.PolicyExceptionSpec{
+			Baseline:  corev1alpha1.BaselineReference{Kind: "SecurityBaseline", Name: "restricted"},
+			Rules:     []corev1alpha1.SecurityBaselineRule{corev1alpha1.RuleRunAsNonRoot},
+			ExpiresAt: metav1.NewTime(now.Add(time.Hour)),
+		},
+	}
+	baseline := &corev1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted", Namespace: "team-a"},
+		Spec:       corev1alpha1.SecurityBaselineSpec{RunAsNonRoot: ptr.To(true)},
+	}
+	c := fake.NewClientBuilder().WithScheme(newComplianceScheme(t)).Build()
+
+	status, err := newComplianceScanner(c, nil, nil, nil).scan(context.Background(), "SecurityBaseline", baseline,
+		[]corev1.Pod{rootPod("legacy-1", nil)}, []corev1alpha1.PolicyException{exception}, now)
+	if err != nil {
+		t.Fatalf("scan returned error: %v", err)
+	}
+	if status.ScannedPods != 1 || status.ViolatingPods != 0 || status.ExemptedViolations != 1 || len(status.Offenders) != 0 {
+		t.Fatalf("expected the violation to be exempted, got %+v", status)
+	}
+}
+
+func TestComplianceScanBoundsOffenders(t *testing.T) {
+	t.Parallel()
+
+	pods := make([]corev1.Pod, 0, maxComplianceOffenders+5)
+	for i := range maxComplianceOffenders + 5 {
+		pods = append(pods, rootPod(fmt.Sprintf("pod-%02d", i), nil))
+	}
+	baseline := &corev1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted", Namespace: "team-a"},
+		Spec:       corev1alpha1.SecurityBaselineSpec{RunAsNonRoot: ptr.To(true)},
+	}
+	c := fake.NewClientBuilder().WithScheme(newComplianceScheme(t)).Build()
+
+	status, err := newComplianceScanner(c, nil, nil, nil).scan(context.Background(), "SecurityBaseline", baseline, pods, nil, time.Now())
+	if err != nil {
+		t.Fatalf("scan returned error: %v", err)
+	}
+	if status.ViolatingWorkloads != maxComplianceOffenders+5 || len(status.Offenders) != maxComplianceOffenders {
+		t.Fatalf("expected %d listed of %d offenders, got %d of %d",
+			maxComplianceOffenders, maxComplianceOffenders+5, len(status.Offenders), status.ViolatingWorkloads)
+	}
+}
+
+func TestSetComplianceStatusOnlyChangesOnNewResults(t *testing.T) {
+	t.Parallel()
+
+	first := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	status := &corev1alpha1.SecurityBaselineStatus{}
+	if !setComplianceStatus(status, &corev1alpha1.ComplianceStatus{ScannedPods: 2}, first) {
+		t.Fatal("expected the first scan to change the status")
+	}
+	if setComplianceStatus(status, &corev1alpha1.ComplianceStatus{ScannedPods: 2}, first.Add(time.Hour)) {
+		t.Fatal("expected an identical scan not to change the status")
+	}
+	if !status.Compliance.LastChangeTime.Time.Equal(first) {
+		t.Fatalf("expected lastChangeTime to stay at %s, got %s", first, status.Compliance.LastChangeTime)
+	}
+	if !setComplianceStatus(status, &corev1alpha1.ComplianceStatus{ScannedPods: 3}, first.Add(2*time.Hour)) {
+		t.Fatal("expected a different scan to change the status")
+	}
+	if !status.Compliance.LastChangeTime.Time.Equal(first.Add(2 * time.Hour)) {
+		t.Fatalf("expected lastChangeTime to move, got %s", status.Compliance.LastChangeTime)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/governance"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport"
+)
+
+// ClusterSecurityBaselineReconciler reconciles a ClusterSecurityBaseline object
+type ClusterSecurityBaselineReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// ScanInterval is how often running Pods are re-evaluated against the
+	// baseline. Zero only scans when the baseline is reconciled.
+	ScanInterval time.Duration
+	// Reports receives the background scan results for the PolicyReports.
+	// Reporting is disabled when nil.
+	Reports *policyreport.Store
+
+	violationEvents violationEvents
+}
+
+// +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=clustersecuritybaselines,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=clustersecuritybaselines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=clustersecuritybaselines/finalizers,verbs=update
+
+// Reconcile reconciles a ClusterSecurityBaseline object by updating its status condition
+// to Available once the resource is observed and scanning the running Pods it
+// applies to, recording the results in status.compliance and as events on the
+// offending workloads. Admission enforcement is delegated to the Pod validating
+// webhook (PodValidator).
+func (r *ClusterSecurityBaselineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var baseline corev1alpha1.ClusterSecurityBaseline
+	if err := r.Get(ctx, req.NamespacedName, &baseline); err != nil {
+		if apierrors.IsNotFound(err) {
+			ref := policyreport.PolicyRef{Kind: "ClusterSecurityBaseline", Name: req.Name}
+			r.Reports.Forget(ref)
+			r.violationEvents.forget(ref)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	log.Info("Reconciling ClusterSecurityBaseline", "name", baseline.Name)
+
+	now := time.Now()
+	compliance, err := r.scanCompliance(ctx, &baseline, now)
+	if err != nil {
+		log.Error(err, "Failed to scan Pods for ClusterSecurityBaseline compliance")
+		return ctrl.Result{}, err
+	}
+	complianceChanged := setComplianceStatus(&baseline.Status, compliance, now)
+
+	updated, err := updateAvailableStatusIfChanged(
+		ctx,
+		r.Status(),
+		r.Recorder,
+		&baseline,
+		&baseline.Status.Conditions,
+		"ClusterSecurityBaseline is available and being enforced",
+	)
+	if err != nil {
+		log.Error(err, "Failed to update ClusterSecurityBaseline status")
+		return ctrl.Result{}, err
+	}
+	switch {
+	case updated:
+		// The compliance status was written together with the condition.
+	case complianceChanged:
+		if err := r.Status().Update(ctx, &baseline); err != nil {
+			log.Error(err, "Failed to update ClusterSecurityBaseline compliance status")
+			return ctrl.Result{}, err
+		}
+	default:
+		log.V(1).Info("Skipping status update; ClusterSecurityBaseline already marked Available and compliance unchanged", "name", baseline.Name)
+	}
+
+	return ctrl.Result{RequeueAfter: r.ScanInterval}, nil
+}
+
+// scanCompliance evaluates the running Pods of every namespace selected by the
+// baseline's namespaceSelector.
+func (r *ClusterSecurityBaselineReconciler) scanCompliance(ctx context.Context, clusterBaseline *corev1alpha1.ClusterSecurityBaseline,
+	now time.Time) (*corev1alpha1.ComplianceStatus, error) {
+	var namespaces corev1.NamespaceList
+	if err := r.List(ctx, &namespaces); err != nil {
+		return nil, err
+	}
+	selected := map[string]bool{}
+	for _, namespace := range namespaces.Items {
+		if governance.SelectsNamespace(clusterBaseline.Spec.NamespaceSelector, namespace.Labels) {
+			selected[namespace.Name] = true
+		}
+	}
+
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods); err != nil {
+		return nil, err
+	}
+	pods.Items = slices.DeleteFunc(pods.Items, func(pod corev1.Pod) bool {
+		return !selected[pod.Namespace]
+	})
+	var exceptions corev1alpha1.PolicyExceptionList
+	if err := r.List(ctx, &exceptions); err != nil {
+		return nil, err
+	}
+
+	baseline := &corev1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterBaseline.Name, Generation: clusterBaseline.Generation},
+		Spec:       clusterBaseline.Spec.SecurityBaselineSpec,
+	}
+	return newComplianceScanner(r.Client, r.Recorder, &r.violationEvents, r.Reports).scan(ctx, "ClusterSecurityBaseline", baseline, pods.Items, exceptions.Items, now)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterSecurityBaselineReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1alpha1.ClusterSecurityBaseline{}).
+		Named("clustersecuritybaseline").
+		Complete(r)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/governance"
+)
+
+// EffectiveGovernanceReconciler maintains the EffectiveGovernance of every
+// namespace.
+type EffectiveGovernanceReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=effectivegovernances,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=effectivegovernances/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=policyexceptions,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// Reconcile computes the governance in effect in a namespace and records it
+// in the status of the namespace's EffectiveGovernance, creating it if needed.
+// Requests are keyed by namespace name. The namespace is requeued when the
+// first of its exemptions expires.
+func (r *EffectiveGovernanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var namespace corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: req.Name}, &namespace); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !namespace.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	status, err := governance.EffectiveGovernance(ctx, r, namespace.Name, now)
+	if err != nil {
+		log.Error(err, "Failed to compute effective governance", "namespace", namespace.Name)
+		return ctrl.Result{}, err
+	}
+	result := ctrl.Result{RequeueAfter: untilFirstExpiry(status.Exemptions, now)}
+
+	effective := &corev1alpha1.EffectiveGovernance{}
+	err = r.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: corev1alpha1.EffectiveGovernanceName}, effective)
+	if apierrors.IsNotFound(err) {
+		effective = &corev1alpha1.EffectiveGovernance{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace.Name, Name: corev1alpha1.EffectiveGovernanceName},
+		}
+		if err := r.Create(ctx, effective); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if equality.Semantic.DeepEqual(effective.Status, status) {
+		return result, nil
+	}
+	log.V(1).Info("Updating effective governance", "namespace", namespace.Name)
+	effective.Status = status
+	return result, r.Status().Update(ctx, effective)
+}
+
+// untilFirstExpiry returns the time left until the first of the exemptions
+// expires, or zero without exemptions.
+func untilFirstExpiry(exemptions []corev1alpha1.EffectiveExemption, now time.Time) time.Duration {
+	var first time.Time
+	for _, exemption := range exemptions {
+		if first.IsZero() || exemption.ExpiresAt.Time.Before(first) {
+			first = exemption.ExpiresAt.Time
+		}
+	}
+	if first.IsZero() {
+		return 0
+	}
+	return first.Sub(now)
+}
+
+// SetupWithManager sets up the controller with the Manager. Namespaced
+// policies requeue their namespace and cluster policies every namespace. Only
+// spec changes count: the status written by the compliance scan does not feed
+// the effective governance.
+func (r *EffectiveGovernanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ofNamespace := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: obj.GetNamespace()}}}
+	})
+	allNamespaces := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+		var namespaces corev1.NamespaceList
+		if err := r.List(ctx, &namespaces); err != nil {
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(namespaces.Items))
+		for _, namespace := range namespaces.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: namespace.Name}})
+		}
+		return requests
+	})
+
+	specChanged := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Namespace{}).
+		Watches(&corev1alpha1.EffectiveGovernance{}, ofNamespace).
+		Watches(&corev1alpha1.WorkloadPolicy{}, ofNamespace, specChanged).
+		Watches(&corev1alpha1.TelemetryProfile{}, ofNamespace, specChanged).
+		Watches(&corev1alpha1.SecurityBaseline{}, ofNamespace, specChanged).
+		Watches(&corev1alpha1.PolicyException{}, ofNamespace, specChanged).
+		Watches(&corev1alpha1.ClusterWorkloadPolicy{}, allNamespaces, specChanged).
+		Watches(&corev1alpha1.ClusterTelemetryProfile{}, allNamespaces, specChanged).
+		Watches(&corev1alpha1.ClusterSecurityBaseline{}, allNamespaces, specChanged).
+		Named("effectivegovernance").
+		Complete(r)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+func TestEffectiveGovernanceReconcileCreatesAndUpdatesStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	scheme := newStatusHelperScheme(t)
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add corev1 to scheme: %v", err)
+	}
+	policy := &corev1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "team-a"},
+		Spec:       corev1alpha1.WorkloadPolicySpec{DefaultRequests: map[string]string{"cpu": "500m"}},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&corev1alpha1.EffectiveGovernance{}).
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}, policy).
+		Build()
+	reconciler := &EffectiveGovernanceReconciler{Client: cl, Scheme: scheme}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "team-a"}}
+	key := types.NamespacedName{Namespace: "team-a", Name: corev1alpha1.EffectiveGovernanceName}
+
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	var governance corev1alpha1.EffectiveGovernance
+	if err := cl.Get(ctx, key, &governance); err != nil {
+		t.Fatalf("expected the EffectiveGovernance to be created: %v", err)
+	}
+	if defaults := governance.Status.ResourceDefaults; len(defaults) != 1 || defaults[0].Value != "500m" ||
+		defaults[0].Source.Name != "defaults" {
+		t.Fatalf("expected the cpu default of the policy, got %+v", defaults)
+	}
+
+	policy.Spec.DefaultRequests["cpu"] = "250m"
+	if err := cl.Update(ctx, policy); err != nil {
+		t.Fatalf("failed to update policy: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	if err := cl.Get(ctx, key, &governance); err != nil {
+		t.Fatalf("failed to get EffectiveGovernance: %v", err)
+	}
+	if defaults := governance.Status.ResourceDefaults; len(defaults) != 1 || defaults[0].Value != "250m" {
+		t.Fatalf("expected the updated cpu default, got %+v", defaults)
+	}
+
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "gone"}}); err != nil {
+		t.Fatalf("expected a deleted namespace to be ignored, got %v", err)
+	}
+}
+
+func TestEffectiveGovernanceReconcileRequeuesAtFirstExemptionExpiry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	scheme := newStatusHelperScheme(t)
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add corev1 to scheme: %v", err)
+	}
+	baseline := &corev1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
+		Spec:       corev1alpha1.SecurityBaselineSpec{RunAsNonRoot: ptr.To(true), ReadOnlyRootFilesystem: ptr.To(true)},
+	}
+	exception := func(name string, rule corev1alpha1.SecurityBaselineRule, expiresIn time.Duration) *corev1alpha1.PolicyException {
+		return &corev1alpha1.PolicyException{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+			Spec: corev1alpha1.PolicyExceptionSpec{
+				Baseline:  corev1alpha1.BaselineReference{Kind: "SecurityBaseline", Name: "baseline"},
+				Rules:     []corev1alpha1.SecurityBaselineRule{rule},
+				ExpiresAt: metav1.NewTime(time.Now().Add(expiresIn)),
+			},
+		}
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&corev1alpha1.EffectiveGovernance{}).
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}, baseline,
+			exception("legacy", corev1alpha1.RuleReadOnlyRootFilesystem, time.Hour),
+			exception("migration", corev1alpha1.RuleRunAsNonRoot, 10*time.Minute)).
+		Build()
+	reconciler := &EffectiveGovernanceReconciler{Client: cl, Scheme: scheme}
+
+	result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "team-a"}})
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	if result.RequeueAfter <= 0 || result.RequeueAfter > 10*time.Minute {
+		t.Fatalf("expected a requeue when the migration exception expires, got %s", result.RequeueAfter)
+	}
+	var governance corev1alpha1.EffectiveGovernance
+	key := types.NamespacedName{Namespace: "team-a", Name: corev1alpha1.EffectiveGovernanceName}
+	if err := cl.Get(ctx, key, &governance); err != nil {
+		t.Fatalf("failed to get EffectiveGovernance: %v", err)
+	}
+	if exemptions := governance.Status.Exemptions; len(exemptions) != 2 {
+		t.Fatalf("expected both exemptions to be listed, got %+v", exemptions)
+	}
+}
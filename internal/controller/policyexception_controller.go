@@ -0,0 +1,116 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// PolicyExceptionExpiryWarning is how long before expiresAt a PolicyException
+// is reported as ExpiringSoon.
+const PolicyExceptionExpiryWarning = 7 * 24 * time.Hour
+
+// PolicyExceptionReconciler reconciles a PolicyException object
+type PolicyExceptionReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=policyexceptions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=policyexceptions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=policyexceptions/finalizers,verbs=update
+
+// Reconcile tracks the expiry of a PolicyException. It sets the Active
+// condition, emits an ExpiringSoon event once the exception enters the warning
+// window and an Expired event when it expires, and requeues itself for the next
+// transition. Honoring the exception is delegated to the Pod validating webhook
+// (PodValidator), which checks expiresAt on every admission.
+func (r *PolicyExceptionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var exception corev1alpha1.PolicyException
+	if err := r.Get(ctx, req.NamespacedName, &exception); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	log.Info("Reconciling PolicyException", "name", exception.Name, "namespace", exception.Namespace)
+
+	condition, eventType, requeueAfter := policyExceptionState(&exception, time.Now())
+	if meta.SetStatusCondition(&exception.Status.Conditions, condition) {
+		if err := r.Status().Update(ctx, &exception); err != nil {
+			log.Error(err, "Failed to update PolicyException status")
+			return ctrl.Result{}, err
+		}
+		if r.Recorder != nil {
+			r.Recorder.Event(&exception, eventType, condition.Reason, condition.Message)
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// policyExceptionState returns the Active condition of the exception at now,
+// the type of the event announcing it and how long until the next transition
+// (zero once the exception has expired).
+func policyExceptionState(exception *corev1alpha1.PolicyException, now time.Time) (metav1.Condition, string, time.Duration) {
+	expiresAt := exception.Spec.ExpiresAt.Time
+	expiry := expiresAt.UTC().Format(time.RFC3339)
+	condition := metav1.Condition{
+		Type:               corev1alpha1.PolicyExceptionConditionActive,
+		ObservedGeneration: exception.GetGeneration(),
+	}
+
+	remaining := expiresAt.Sub(now)
+	switch {
+	case remaining <= 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = corev1alpha1.PolicyExceptionReasonExpired
+		condition.Message = fmt.Sprintf("PolicyException expired at %s and is no longer honored", expiry)
+		return condition, "Warning", 0
+	case remaining <= PolicyExceptionExpiryWarning:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = corev1alpha1.PolicyExceptionReasonExpiringSoon
+		condition.Message = fmt.Sprintf("PolicyException expires at %s; renew it or remediate the exempted workloads", expiry)
+		return condition, "Warning", remaining
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = corev1alpha1.PolicyExceptionReasonActive
+		condition.Message = fmt.Sprintf("PolicyException is honored until %s", expiry)
+		return condition, "Normal", remaining - PolicyExceptionExpiryWarning
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PolicyExceptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1alpha1.PolicyException{}).
+		Named("policyexception").
+		Complete(r)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+func TestPolicyExceptionState(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		expiresAt   time.Time
+		status      metav1.ConditionStatus
+		reason      string
+		requeueTime time.Duration
+	}{
+		{
+			name:        "active",
+			expiresAt:   now.Add(30 * 24 * time.Hour),
+			status:      metav1.ConditionTrue,
+			reason:      corev1alpha1.PolicyExceptionReasonActive,
+			requeueTime: 23 * 24 * time.Hour,
+		},
+		{
+			name:        "expiring soon",
+			expiresAt:   now.Add(time.Hour),
+			status:      metav1.ConditionTrue,
+			reason:      corev1alpha1.PolicyExceptionReasonExpiringSoon,
+			requeueTime: time.Hour,
+		},
+		{
+			name:      "expired",
+			expiresAt: now,
+			status:    metav1.ConditionFalse,
+			reason:    corev1alpha1.PolicyExceptionReasonExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			exception := &corev1alpha1.PolicyException{
+				Spec: corev1alpha1.PolicyExceptionSpec{ExpiresAt: metav1.NewTime(tt.expiresAt)},
+			}
+			condition, _, requeueAfter := policyExceptionState(exception, now)
+			if condition.Status != tt.status || condition.Reason != tt.reason {
+				t.Fatalf("expected %s/%s, got %s/%s", tt.status, tt.reason, condition.Status, condition.Reason)
+			}
+			if requeueAfter != tt.requeueTime {
+				t.Fatalf("expected requeue after %s, got %s", tt.requeueTime, requeueAfter)
+			}
+		})
+	}
+}
+
+func TestPolicyExceptionReconcileEmitsExpiryEventsOnce(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	scheme := newStatusHelperScheme(t)
+	exception := &corev1alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "default"},
+		Spec: corev1alpha1.PolicyExceptionSpec{
+			Baseline:      corev1alpha1.BaselineReference{Name: "baseline"},
+			Rules:         []corev1alpha1.SecurityBaselineRule{corev1alpha1.RuleReadOnlyRootFilesystem},
+			Justification: "legacy image",
+			Owner:         "team-a",
+			ExpiresAt:     metav1.NewTime(time.Now().Add(time.Hour)),
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(exception).WithObjects(exception).Build()
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &PolicyExceptionReconciler{Client: cl, Scheme: scheme, Recorder: recorder}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "legacy", Namespace: "default"}}
+
+	for range 2 {
+		result, err := reconciler.Reconcile(ctx, req)
+		if err != nil {
+			t.Fatalf("unexpected reconcile error: %v", err)
+		}
+		if result.RequeueAfter <= 0 || result.RequeueAfter > time.Hour {
+			t.Fatalf("expected a requeue at expiry, got %s", result.RequeueAfter)
+		}
+	}
+
+	var reconciled corev1alpha1.PolicyException
+	if err := cl.Get(ctx, req.NamespacedName, &reconciled); err != nil {
+		t.Fatalf("failed to get exception: %v", err)
+	}
+	condition := meta.FindStatusCondition(reconciled.Status.Conditions, corev1alpha1.PolicyExceptionConditionActive)
+	if condition == nil || condition.Reason != corev1alpha1.PolicyExceptionReasonExpiringSoon {
+		t.Fatalf("expected an ExpiringSoon Active condition, got %+v", condition)
+	}
+	if len(recorder.Events) != 1 {
+		t.Fatalf("expected exactly one event, got %d", len(recorder.Events))
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "ExpiringSoon") {
+		t.Fatalf("expected an ExpiringSoon event, got %q", event)
+	}
+}
@@ -18,7 +18,10 @@ package controller
 
 import (
 	"context"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -26,6 +29,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport"
 )
 
 // SecurityBaselineReconciler reconciles a SecurityBaseline object
@@ -33,6 +37,14 @@ type SecurityBaselineReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+	// ScanInterval is how often running Pods are re-evaluated against the
+	// baseline. Zero only scans when the baseline is reconciled.
+	ScanInterval time.Duration
+	// Reports receives the background scan results for the PolicyReports.
+	// Reporting is disabled when nil.
+	Reports *policyreport.Store
+
+	violationEvents violationEvents
 }
 
 // +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=securitybaselines,verbs=get;list;watch;create;update;patch;delete
@@ -40,18 +52,33 @@ type SecurityBaselineReconciler struct {
 // +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=securitybaselines/finalizers,verbs=update
 
 // Reconcile reconciles a SecurityBaseline object by updating its status condition
-// to Available once the resource is observed. The actual enforcement is delegated
-// to the Pod validating webhook (PodValidator).
+// to Available once the resource is observed and scanning the running Pods it
+// applies to, recording the results in status.compliance and as events on the
+// offending workloads. Admission enforcement is delegated to the Pod validating
+// webhook (PodValidator).
 func (r *SecurityBaselineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
 	var baseline corev1alpha1.SecurityBaseline
 	if err := r.Get(ctx, req.NamespacedName, &baseline); err != nil {
+		if apierrors.IsNotFound(err) {
+			ref := policyreport.PolicyRef{Kind: "SecurityBaseline", Namespace: req.Namespace, Name: req.Name}
+			r.Reports.Forget(ref)
+			r.violationEvents.forget(ref)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
 	log.Info("Reconciling SecurityBaseline", "name", baseline.Name, "namespace", baseline.Namespace)
 
+	now := time.Now()
+	compliance, err := r.scanCompliance(ctx, &baseline, now)
+	if err != nil {
+		log.Error(err, "Failed to scan Pods for SecurityBaseline compliance")
+		return ctrl.Result{}, err
+	}
+	complianceChanged := setComplianceStatus(&baseline.Status, compliance, now)
+
 	updated, err := updateAvailableStatusIfChanged(
 		ctx,
 		r.Status(),
@@ -64,11 +91,33 @@ func (r *SecurityBaselineReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		log.Error(err, "Failed to update SecurityBaseline status")
 		return ctrl.Result{}, err
 	}
-	if !updated {
-		log.V(1).Info("Skipping status update; SecurityBaseline already marked Available", "name", baseline.Name, "namespace", baseline.Namespace)
+	switch {
+	case updated:
+		// The compliance status was written together with the condition.
+	case complianceChanged:
+		if err := r.Status().Update(ctx, &baseline); err != nil {
+			log.Error(err, "Failed to update SecurityBaseline compliance status")
+			return ctrl.Result{}, err
+		}
+	default:
+		log.V(1).Info("Skipping status update; SecurityBaseline already marked Available and compliance unchanged", "name", baseline.Name, "namespace", baseline.Namespace)
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: r.ScanInterval}, nil
+}
+
+// scanCompliance evaluates the running Pods of the baseline namespace.
+func (r *SecurityBaselineReconciler) scanCompliance(ctx context.Context, baseline *corev1alpha1.SecurityBaseline,
+	now time.Time) (*corev1alpha1.ComplianceStatus, error) {
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(baseline.Namespace)); err != nil {
+		return nil, err
+	}
+	var exceptions corev1alpha1.PolicyExceptionList
+	if err := r.List(ctx, &exceptions, client.InNamespace(baseline.Namespace)); err != nil {
+		return nil, err
+	}
+	return newComplianceScanner(r.Client, r.Recorder, &r.violationEvents, r.Reports).scan(ctx, "SecurityBaseline", baseline, pods.Items, exceptions.Items, now)
 }
 
 // SetupWithManager sets up the controller with the Manager.
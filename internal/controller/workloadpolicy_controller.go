@@ -27,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -40,10 +41,10 @@ import (
 )
 
 const (
-	deploymentHPAEnabledAnnotation        = "core.platform.f3nr1r.io/hpa-enabled"
-	managedHPALabelKey                    = "core.platform.f3nr1r.io/managed-hpa"
-	managedHPALabelValue                  = "true"
-	managedHPAWorkloadPolicyAnnotationKey = "core.platform.f3nr1r.io/workload-policy"
+	deploymentHPAEnabledAnnotation     = "core.platform.f3nr1r.io/hpa-enabled"
+	managedHPALabelKey                 = "core.platform.f3nr1r.io/managed-hpa"
+	managedHPALabelValue               = "true"
+	managedWorkloadPolicyAnnotationKey = "core.platform.f3nr1r.io/workload-policy"
 )
 
 // WorkloadPolicyReconciler reconciles a WorkloadPolicy object
@@ -58,9 +59,11 @@ type WorkloadPolicyReconciler struct {
 // +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=workloadpolicies/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=limitranges;resourcequotas,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile reconciles a WorkloadPolicy object by updating its status condition
-// to Available once the resource is observed. The actual policy enforcement
+// to Available once the resource is observed, after maintaining the HPAs,
+// LimitRange and ResourceQuota generated for it. The actual policy enforcement
 // (default labels, resource requests/limits) is delegated to the Pod mutating
 // webhook (PodMutator).
 func (r *WorkloadPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -74,20 +77,19 @@ func (r *WorkloadPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	log.Info("Reconciling WorkloadPolicy", "name", policy.Name, "namespace", policy.Namespace)
 
 	if policy.Spec.HorizontalScaling != nil {
-		highestPriorityPolicy, err := r.isHighestPriorityPolicy(ctx, &policy)
-		if err != nil {
-			log.Error(err, "Failed to evaluate WorkloadPolicy priority for horizontal scaling")
+		if err := r.reconcileDeploymentHPAs(ctx, &policy); err != nil {
+			log.Error(err, "Failed to reconcile horizontal scaling for Deployments")
 			return ctrl.Result{}, err
 		}
+	}
 
-		if highestPriorityPolicy {
-			if err := r.reconcileDeploymentHPAs(ctx, &policy); err != nil {
-				log.Error(err, "Failed to reconcile horizontal scaling for Deployments")
-				return ctrl.Result{}, err
-			}
-		} else {
-			log.V(1).Info("Skipping HPA reconciliation because policy is not highest priority", "name", policy.Name, "namespace", policy.Namespace)
-		}
+	if err := r.reconcileLimitRange(ctx, &policy); err != nil {
+		log.Error(err, "Failed to reconcile the generated LimitRange")
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileResourceQuota(ctx, &policy); err != nil {
+		log.Error(err, "Failed to reconcile the generated ResourceQuota")
+		return ctrl.Result{}, err
 	}
 
 	updated, err := updateAvailableStatusIfChanged(
@@ -113,6 +115,8 @@ func (r *WorkloadPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 func (r *WorkloadPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&corev1alpha1.WorkloadPolicy{}).
+		Owns(&corev1.LimitRange{}).
+		Owns(&corev1.ResourceQuota{}).
 		Watches(
 			&appsv1.Deployment{},
 			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -137,21 +141,23 @@ func (r *WorkloadPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-func (r *WorkloadPolicyReconciler) isHighestPriorityPolicy(ctx context.Context, policy *corev1alpha1.WorkloadPolicy) (bool, error) {
-	var policies corev1alpha1.WorkloadPolicyList
-	if err := r.List(ctx, &policies, client.InNamespace(policy.Namespace)); err != nil {
-		return false, err
-	}
-
+// isHighestPriorityPolicy reports whether policy is the highest priority
+// WorkloadPolicy with horizontal scaling defaults that selects the Deployment.
+// Ties are broken by name so exactly one policy manages each Deployment's HPA.
+// Candidates with an invalid podSelector are ignored.
+func isHighestPriorityPolicy(policies []corev1alpha1.WorkloadPolicy, policy *corev1alpha1.WorkloadPolicy, deployment *appsv1.Deployment) bool {
 	var (
 		highest    corev1alpha1.WorkloadPolicy
 		highestSet bool
 	)
-	for i := range policies.Items {
-		candidate := policies.Items[i]
+	for i := range policies {
+		candidate := policies[i]
 		if candidate.Spec.HorizontalScaling == nil {
 			continue
 		}
+		if selected, err := policySelectsDeployment(&candidate, deployment); err != nil || !selected {
+			continue
+		}
 
 		if !highestSet {
 			highest = candidate
@@ -168,13 +174,34 @@ func (r *WorkloadPolicyReconciler) isHighestPriorityPolicy(ctx context.Context,
 		}
 	}
 	if !highestSet {
-		return false, nil
+		return false
 	}
 
-	return highest.Name == policy.Name, nil
+	return highest.Name == policy.Name
+}
+
+// policySelectsDeployment reports whether the policy's podSelector matches the
+// Deployment's Pod template labels. A nil selector selects every Deployment.
+func policySelectsDeployment(policy *corev1alpha1.WorkloadPolicy, deployment *appsv1.Deployment) (bool, error) {
+	if policy.Spec.PodSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(policy.Spec.PodSelector)
+	if err != nil {
+		return false, fmt.Errorf("WorkloadPolicy %s has an invalid podSelector: %w", policy.Name, err)
+	}
+	return selector.Matches(labels.Set(deployment.Spec.Template.Labels)), nil
 }
 
+// reconcileDeploymentHPAs manages the HPAs of the Deployments for which policy
+// is the highest priority horizontal scaling policy. HPAs this policy created
+// for Deployments it no longer selects are removed.
 func (r *WorkloadPolicyReconciler) reconcileDeploymentHPAs(ctx context.Context, policy *corev1alpha1.WorkloadPolicy) error {
+	var policies corev1alpha1.WorkloadPolicyList
+	if err := r.List(ctx, &policies, client.InNamespace(policy.Namespace)); err != nil {
+		return err
+	}
+
 	var deployments appsv1.DeploymentList
 	if err := r.List(ctx, &deployments, client.InNamespace(policy.Namespace)); err != nil {
 		return err
@@ -183,11 +210,6 @@ func (r *WorkloadPolicyReconciler) reconcileDeploymentHPAs(ctx context.Context,
 	for i := range deployments.Items {
 		deployment := &deployments.Items[i]
 
-		enabled, err := hpaEnabledForDeployment(deployment, policy.Spec.HorizontalScaling)
-		if err != nil {
-			return err
-		}
-
 		desiredHPAName := managedHPAName(deployment.Name)
 		key := types.NamespacedName{
 			Name:      desiredHPAName,
@@ -195,13 +217,37 @@ func (r *WorkloadPolicyReconciler) reconcileDeploymentHPAs(ctx context.Context,
 		}
 
 		existingHPA := &autoscalingv2.HorizontalPodAutoscaler{}
-		err = r.Get(ctx, key, existingHPA)
-		if err != nil && !apierrors.IsNotFound(err) {
+		getErr := r.Get(ctx, key, existingHPA)
+		if getErr != nil && !apierrors.IsNotFound(getErr) {
+			return getErr
+		}
+
+		selected, err := policySelectsDeployment(policy, deployment)
+		if err != nil {
+			return err
+		}
+		if !selected {
+			if existingHPA.Annotations[managedWorkloadPolicyAnnotationKey] == policy.Name && isManagedHPA(existingHPA) {
+				if deleteErr := r.Delete(ctx, existingHPA); deleteErr != nil && !apierrors.IsNotFound(deleteErr) {
+					return deleteErr
+				}
+			}
+			continue
+		}
+
+		if !isHighestPriorityPolicy(policies.Items, policy, deployment) {
+			logf.FromContext(ctx).V(1).Info("Skipping HPA reconciliation because policy is not highest priority for Deployment",
+				"name", policy.Name, "namespace", policy.Namespace, "deployment", deployment.Name)
+			continue
+		}
+
+		enabled, err := hpaEnabledForDeployment(deployment, policy.Spec.HorizontalScaling)
+		if err != nil {
 			return err
 		}
 
 		if !enabled {
-			if apierrors.IsNotFound(err) {
+			if apierrors.IsNotFound(getErr) {
 				continue
 			}
 			if isManagedHPA(existingHPA) {
@@ -214,7 +260,7 @@ func (r *WorkloadPolicyReconciler) reconcileDeploymentHPAs(ctx context.Context,
 
 		desiredHPA := desiredHPAForDeployment(policy, deployment)
 
-		if apierrors.IsNotFound(err) {
+		if apierrors.IsNotFound(getErr) {
 			if createErr := r.Create(ctx, desiredHPA); createErr != nil {
 				return createErr
 			}
@@ -246,9 +292,6 @@ func desiredHPAForDeployment(policy *corev1alpha1.WorkloadPolicy, deployment *ap
 	minReplicas := hpaPolicy.MinReplicas
 	targetCPU := hpaPolicy.TargetCPUUtilizationPercentage
 
-	blockOwnerDeletion := true
-	isController := true
-
 	hpa := &autoscalingv2.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      managedHPAName(deployment.Name),
@@ -257,18 +300,9 @@ func desiredHPAForDeployment(policy *corev1alpha1.WorkloadPolicy, deployment *ap
 				managedHPALabelKey: managedHPALabelValue,
 			},
 			Annotations: map[string]string{
-				managedHPAWorkloadPolicyAnnotationKey: policy.Name,
-			},
-			OwnerReferences: []metav1.OwnerReference{
-				{
-					APIVersion:         corev1alpha1.GroupVersion.String(),
-					Kind:               "WorkloadPolicy",
-					Name:               policy.Name,
-					UID:                policy.UID,
-					BlockOwnerDeletion: &blockOwnerDeletion,
-					Controller:         &isController,
-				},
+				managedWorkloadPolicyAnnotationKey: policy.Name,
 			},
+			OwnerReferences: []metav1.OwnerReference{workloadPolicyOwnerReference(policy)},
 		},
 		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
 			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
@@ -296,6 +330,21 @@ func desiredHPAForDeployment(policy *corev1alpha1.WorkloadPolicy, deployment *ap
 	return hpa
 }
 
+// workloadPolicyOwnerReference returns the controller reference of the objects
+// generated for the policy.
+func workloadPolicyOwnerReference(policy *corev1alpha1.WorkloadPolicy) metav1.OwnerReference {
+	blockOwnerDeletion := true
+	isController := true
+	return metav1.OwnerReference{
+		APIVersion:         corev1alpha1.GroupVersion.String(),
+		Kind:               "WorkloadPolicy",
+		Name:               policy.Name,
+		UID:                policy.UID,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+		Controller:         &isController,
+	}
+}
+
 func hpaEnabledForDeployment(
 	deployment *appsv1.Deployment,
 	hpaPolicy *corev1alpha1.HorizontalScalingPolicy,
@@ -350,12 +399,18 @@ func effectiveHorizontalScalingPolicy(hpaPolicy *corev1alpha1.HorizontalScalingP
 }
 
 func managedHPAName(deploymentName string) string {
-	const suffix = "-pgo-hpa"
+	return managedName(deploymentName, "-pgo-hpa")
+}
+
+// managedName appends the suffix to the name of the object an operator-managed
+// object is generated for, truncating the name to keep the result a valid
+// label value.
+func managedName(name, suffix string) string {
 	maxBaseLen := 63 - len(suffix)
-	if len(deploymentName) > maxBaseLen {
-		deploymentName = deploymentName[:maxBaseLen]
+	if len(name) > maxBaseLen {
+		name = name[:maxBaseLen]
 	}
-	return deploymentName + suffix
+	return name + suffix
 }
 
 func isManagedHPA(hpa *autoscalingv2.HorizontalPodAutoscaler) bool {
@@ -377,7 +432,7 @@ func ensureManagedHPAMetadata(hpa *autoscalingv2.HorizontalPodAutoscaler, policy
 	if hpa.Annotations == nil {
 		hpa.Annotations = map[string]string{}
 	}
-	hpa.Annotations[managedHPAWorkloadPolicyAnnotationKey] = policyName
+	hpa.Annotations[managedWorkloadPolicyAnnotationKey] = policyName
 }
 
 // hpaSpecDrifted compares only the fields the operator controls to avoid
@@ -17,12 +17,18 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"testing"
 
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
 )
@@ -214,7 +220,7 @@ func TestEnsureManagedHPAMetadata(t *testing.T) {
 	if hpa.Labels[managedHPALabelKey] != managedHPALabelValue {
 		t.Fatalf("expected managed label to be set")
 	}
-	if hpa.Annotations[managedHPAWorkloadPolicyAnnotationKey] != "my-policy" {
+	if hpa.Annotations[managedWorkloadPolicyAnnotationKey] != "my-policy" {
 		t.Fatalf("expected policy annotation to be set")
 	}
 }
@@ -373,3 +379,81 @@ func TestHPASpecDrifted(t *testing.T) {
 		t.Fatalf("expected drift for different ScaleTargetRef")
 	}
 }
+
+func TestIsHighestPriorityPolicyConsidersPodSelector(t *testing.T) {
+	t.Parallel()
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"tier": "web"}},
+			},
+		},
+	}
+	policies := []corev1alpha1.WorkloadPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "batch"},
+			Spec: corev1alpha1.WorkloadPolicySpec{
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "batch"}},
+				Priority:          100,
+				HorizontalScaling: &corev1alpha1.HorizontalScalingPolicy{},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web"},
+			Spec: corev1alpha1.WorkloadPolicySpec{
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}},
+				Priority:          1,
+				HorizontalScaling: &corev1alpha1.HorizontalScalingPolicy{},
+			},
+		},
+	}
+
+	if isHighestPriorityPolicy(policies, &policies[0], deployment) {
+		t.Fatalf("expected policy not selecting the deployment to lose despite higher priority")
+	}
+	if !isHighestPriorityPolicy(policies, &policies[1], deployment) {
+		t.Fatalf("expected selecting policy to be highest priority for the deployment")
+	}
+
+	selected, err := policySelectsDeployment(&policies[0], deployment)
+	if err != nil || selected {
+		t.Fatalf("expected batch policy not to select the web deployment, got %v (%v)", selected, err)
+	}
+}
+
+func TestReconcileCreatesHPAForDeploymentWithoutOne(t *testing.T) {
+	t.Parallel()
+
+	scheme := newStatusHelperScheme(t)
+	for _, addToScheme := range []func(*runtime.Scheme) error{corev1.AddToScheme, appsv1.AddToScheme, autoscalingv2.AddToScheme} {
+		if err := addToScheme(scheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+	policy := &corev1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+		Spec: corev1alpha1.WorkloadPolicySpec{
+			HorizontalScaling: &corev1alpha1.HorizontalScalingPolicy{EnabledByDefault: true, MinReplicas: 2, MaxReplicas: 5},
+		},
+	}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}}
+	r := &WorkloadPolicyReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(policy).WithObjects(policy, deployment).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	ctx := context.Background()
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "policy", Namespace: "default"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var hpa autoscalingv2.HorizontalPodAutoscaler
+	if err := r.Get(ctx, types.NamespacedName{Name: "api-pgo-hpa", Namespace: "default"}, &hpa); err != nil {
+		t.Fatalf("expected the HPA to be created: %v", err)
+	}
+	if !isManagedHPA(&hpa) || hpa.Spec.MaxReplicas != 5 {
+		t.Fatalf("expected a managed HPA with maxReplicas 5, got %+v", hpa)
+	}
+}
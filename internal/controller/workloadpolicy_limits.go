@@ -0,0 +1,244 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+const (
+	managedLimitRangeLabelKey    = "core.platform.f3nr1r.io/managed-limitrange"
+	managedResourceQuotaLabelKey = "core.platform.f3nr1r.io/managed-resourcequota"
+	managedLabelValue            = "true"
+)
+
+// reconcileLimitRange creates, updates or deletes the LimitRange generated for
+// the policy, so that it mirrors the policy's defaults and bounds while
+// generateLimitRange is set.
+func (r *WorkloadPolicyReconciler) reconcileLimitRange(ctx context.Context, policy *corev1alpha1.WorkloadPolicy) error {
+	desired, err := desiredLimitRange(policy)
+	if err != nil {
+		return err
+	}
+
+	existing := &corev1.LimitRange{}
+	key := types.NamespacedName{Name: managedLimitRangeName(policy.Name), Namespace: policy.Namespace}
+	if desired == nil {
+		return r.deleteManagedObject(ctx, key, existing, managedLimitRangeLabelKey)
+	}
+	return r.applyManagedObject(ctx, policy, "limitrange", key, existing, desired, managedLimitRangeLabelKey, func() bool {
+		if equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
+			return false
+		}
+		existing.Spec = desired.Spec
+		return true
+	})
+}
+
+// reconcileResourceQuota creates, updates or deletes the ResourceQuota
+// generated for the policy, so that it mirrors spec.quota.
+func (r *WorkloadPolicyReconciler) reconcileResourceQuota(ctx context.Context, policy *corev1alpha1.WorkloadPolicy) error {
+	desired, err := desiredResourceQuota(policy)
+	if err != nil {
+		return err
+	}
+
+	existing := &corev1.ResourceQuota{}
+	key := types.NamespacedName{Name: managedResourceQuotaName(policy.Name), Namespace: policy.Namespace}
+	if desired == nil {
+		return r.deleteManagedObject(ctx, key, existing, managedResourceQuotaLabelKey)
+	}
+	return r.applyManagedObject(ctx, policy, "resourcequota", key, existing, desired, managedResourceQuotaLabelKey, func() bool {
+		if equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
+			return false
+		}
+		existing.Spec = desired.Spec
+		return true
+	})
+}
+
+// applyManagedObject creates desired, or updates the object at key, read into
+// existing, when sync reports that its spec drifted after copying the desired
+// spec into it. Objects not carrying labelKey were not created by the
+// operator and are never changed.
+func (r *WorkloadPolicyReconciler) applyManagedObject(ctx context.Context, policy *corev1alpha1.WorkloadPolicy, kind string,
+	key types.NamespacedName, existing, desired client.Object, labelKey string, sync func() bool) error {
+	if err := r.Get(ctx, key, existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.Create(ctx, desired)
+		}
+		return err
+	}
+	if existing.GetLabels()[labelKey] != managedLabelValue {
+		return fmt.Errorf("%s %s exists but is not managed by platform-governance-operator", kind, key)
+	}
+	if !sync() {
+		return nil
+	}
+
+	annotations := existing.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[managedWorkloadPolicyAnnotationKey] = policy.Name
+	existing.SetAnnotations(annotations)
+	return r.Update(ctx, existing)
+}
+
+// deleteManagedObject deletes the object at key, read into existing, if the
+// operator created it.
+func (r *WorkloadPolicyReconciler) deleteManagedObject(ctx context.Context, key types.NamespacedName, existing client.Object,
+	labelKey string) error {
+	if err := r.Get(ctx, key, existing); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if existing.GetLabels()[labelKey] != managedLabelValue {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, existing))
+}
+
+// desiredLimitRange returns the LimitRange generated for the policy, or nil
+// when generateLimitRange is unset or the policy has neither container
+// defaults nor resource bounds.
+func desiredLimitRange(policy *corev1alpha1.WorkloadPolicy) (*corev1.LimitRange, error) {
+	spec := &policy.Spec
+	if !spec.GenerateLimitRange {
+		return nil, nil
+	}
+
+	item := corev1.LimitRangeItem{Type: corev1.LimitTypeContainer}
+	for _, field := range []struct {
+		name   string
+		values map[string]string
+		list   *corev1.ResourceList
+	}{
+		{name: "defaultRequests", values: spec.DefaultRequests, list: &item.DefaultRequest},
+		{name: "defaultLimits", values: spec.DefaultLimits, list: &item.Default},
+		{name: "minRequests", values: spec.MinRequests, list: &item.Min},
+		{name: "maxLimits", values: spec.MaxLimits, list: &item.Max},
+		{name: "maxLimitRequestRatio", values: spec.MaxLimitRequestRatio, list: &item.MaxLimitRequestRatio},
+	} {
+		list, err := parseResourceList(policy.Name, field.name, field.values)
+		if err != nil {
+			return nil, err
+		}
+		*field.list = list
+	}
+	if len(item.DefaultRequest) == 0 && len(item.Default) == 0 && len(item.Min) == 0 && len(item.Max) == 0 &&
+		len(item.MaxLimitRequestRatio) == 0 {
+		return nil, nil
+	}
+	completeLimitRangeItem(&item)
+
+	return &corev1.LimitRange{
+		ObjectMeta: managedObjectMeta(policy, managedLimitRangeName(policy.Name), managedLimitRangeLabelKey),
+		Spec:       corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{item}},
+	}, nil
+}
+
+// completeLimitRangeItem applies the defaults the API server sets on container
+// limit range items, so a stored LimitRange does not appear to drift: the
+// default limit falls back to the maximum, and the default request to the
+// default limit, then to the minimum.
+func completeLimitRangeItem(item *corev1.LimitRangeItem) {
+	if item.Default == nil {
+		item.Default = corev1.ResourceList{}
+	}
+	if item.DefaultRequest == nil {
+		item.DefaultRequest = corev1.ResourceList{}
+	}
+	for name, qty := range item.Max {
+		if _, ok := item.Default[name]; !ok {
+			item.Default[name] = qty.DeepCopy()
+		}
+	}
+	for name, qty := range item.Default {
+		if _, ok := item.DefaultRequest[name]; !ok {
+			item.DefaultRequest[name] = qty.DeepCopy()
+		}
+	}
+	for name, qty := range item.Min {
+		if _, ok := item.DefaultRequest[name]; !ok {
+			item.DefaultRequest[name] = qty.DeepCopy()
+		}
+	}
+}
+
+// desiredResourceQuota returns the ResourceQuota generated for the policy, or
+// nil when spec.quota is unset.
+func desiredResourceQuota(policy *corev1alpha1.WorkloadPolicy) (*corev1.ResourceQuota, error) {
+	if policy.Spec.Quota == nil {
+		return nil, nil
+	}
+	hard, err := parseResourceList(policy.Name, "quota.hard", policy.Spec.Quota.Hard)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.ResourceQuota{
+		ObjectMeta: managedObjectMeta(policy, managedResourceQuotaName(policy.Name), managedResourceQuotaLabelKey),
+		Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+	}, nil
+}
+
+// parseResourceList parses the quantities of a field of the policy spec.
+func parseResourceList(policyName, field string, values map[string]string) (corev1.ResourceList, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	list := make(corev1.ResourceList, len(values))
+	for name, value := range values {
+		qty, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("WorkloadPolicy %s has invalid %s quantity for %s: %q", policyName, field, name, value)
+		}
+		list[corev1.ResourceName(name)] = qty
+	}
+	return list, nil
+}
+
+// managedObjectMeta returns the metadata of an object generated for the
+// policy: the managed label, the policy annotation and a controller reference
+// to the policy, so the object is garbage collected with it.
+func managedObjectMeta(policy *corev1alpha1.WorkloadPolicy, name, labelKey string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            name,
+		Namespace:       policy.Namespace,
+		Labels:          map[string]string{labelKey: managedLabelValue},
+		Annotations:     map[string]string{managedWorkloadPolicyAnnotationKey: policy.Name},
+		OwnerReferences: []metav1.OwnerReference{workloadPolicyOwnerReference(policy)},
+	}
+}
+
+func managedLimitRangeName(policyName string) string {
+	return managedName(policyName, "-pgo-limits")
+}
+
+func managedResourceQuotaName(policyName string) string {
+	return managedName(policyName, "-pgo-quota")
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+func newLimitsTestReconciler(t *testing.T, objects ...client.Object) *WorkloadPolicyReconciler {
+	t.Helper()
+
+	scheme := newStatusHelperScheme(t)
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add corev1 to scheme: %v", err)
+	}
+	return &WorkloadPolicyReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		Scheme: scheme,
+	}
+}
+
+func TestDesiredLimitRangeMirrorsDefaultsAndBounds(t *testing.T) {
+	t.Parallel()
+
+	policy := &corev1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform", Namespace: "team-a", UID: "uid-1"},
+		Spec: corev1alpha1.WorkloadPolicySpec{
+			GenerateLimitRange:   true,
+			DefaultRequests:      map[string]string{"cpu": "100m"},
+			MinRequests:          map[string]string{"memory": "64Mi"},
+			MaxLimits:            map[string]string{"cpu": "2", "memory": "4Gi"},
+			MaxLimitRequestRatio: map[string]string{"cpu": "4"},
+		},
+	}
+
+	limitRange, err := desiredLimitRange(policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if limitRange.Name != "platform-pgo-limits" || limitRange.Labels[managedLimitRangeLabelKey] != managedLabelValue {
+		t.Fatalf("expected a managed LimitRange named after the policy, got %+v", limitRange.ObjectMeta)
+	}
+	if owners := limitRange.OwnerReferences; len(owners) != 1 || owners[0].UID != "uid-1" || !*owners[0].Controller {
+		t.Fatalf("expected the policy as controller owner, got %+v", owners)
+	}
+
+	item := limitRange.Spec.Limits[0]
+	wants := []struct {
+		name string
+		list corev1.ResourceList
+		key  corev1.ResourceName
+		want string
+	}{
+		{name: "default request", list: item.DefaultRequest, key: corev1.ResourceCPU, want: "100m"},
+		{name: "default request from the minimum", list: item.DefaultRequest, key: corev1.ResourceMemory, want: "4Gi"},
+		{name: "default limit from the maximum", list: item.Default, key: corev1.ResourceCPU, want: "2"},
+		{name: "minimum", list: item.Min, key: corev1.ResourceMemory, want: "64Mi"},
+		{name: "maximum", list: item.Max, key: corev1.ResourceMemory, want: "4Gi"},
+		{name: "ratio", list: item.MaxLimitRequestRatio, key: corev1.ResourceCPU, want: "4"},
+	}
+	for _, want := range wants {
+		if got := want.list[want.key]; got.Cmp(resource.MustParse(want.want)) != 0 {
+			t.Errorf("%s: expected %s of %s, got %s", want.name, want.want, want.key, got.String())
+		}
+	}
+
+	policy.Spec.GenerateLimitRange = false
+	if limitRange, err := desiredLimitRange(policy); err != nil || limitRange != nil {
+		t.Fatalf("expected no LimitRange without generateLimitRange, got %+v, %v", limitRange, err)
+	}
+}
+
+func TestReconcileLimitRangeFollowsPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	policy := &corev1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform", Namespace: "team-a"},
+		Spec: corev1alpha1.WorkloadPolicySpec{
+			GenerateLimitRange: true,
+			DefaultLimits:      map[string]string{"memory": "512Mi"},
+		},
+	}
+	r := newLimitsTestReconciler(t)
+	key := types.NamespacedName{Name: "platform-pgo-limits", Namespace: "team-a"}
+
+	if err := r.reconcileLimitRange(ctx, policy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var limitRange corev1.LimitRange
+	if err := r.Get(ctx, key, &limitRange); err != nil {
+		t.Fatalf("expected the LimitRange to be created: %v", err)
+	}
+
+	policy.Spec.DefaultLimits["memory"] = "1Gi"
+	if err := r.reconcileLimitRange(ctx, policy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Get(ctx, key, &limitRange); err != nil {
+		t.Fatalf("failed to get LimitRange: %v", err)
+	}
+	if got := limitRange.Spec.Limits[0].Default[corev1.ResourceMemory]; got.String() != "1Gi" {
+		t.Fatalf("expected the LimitRange to follow the policy, got default memory limit %s", got.String())
+	}
+
+	policy.Spec.GenerateLimitRange = false
+	if err := r.reconcileLimitRange(ctx, policy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Get(ctx, key, &limitRange); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the LimitRange to be deleted, got %v", err)
+	}
+}
+
+func TestReconcileResourceQuotaLeavesUnmanagedQuotasAlone(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	unmanaged := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform-pgo-quota", Namespace: "team-a"},
+		Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("5")}},
+	}
+	policy := &corev1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform", Namespace: "team-a"},
+		Spec: corev1alpha1.WorkloadPolicySpec{
+			Quota: &corev1alpha1.QuotaPolicy{Hard: map[string]string{"requests.cpu": "10", "pods": "50"}},
+		},
+	}
+	r := newLimitsTestReconciler(t, unmanaged)
+
+	if err := r.reconcileResourceQuota(ctx, policy); err == nil {
+		t.Fatal("expected an unmanaged ResourceQuota of the same name to be reported")
+	}
+	policy.Spec.Quota = nil
+	if err := r.reconcileResourceQuota(ctx, policy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(unmanaged), &corev1.ResourceQuota{}); err != nil {
+		t.Fatalf("expected the unmanaged ResourceQuota to be kept, got %v", err)
+	}
+}
+
+func TestReconcileResourceQuotaCreatesQuota(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	policy := &corev1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform", Namespace: "team-a"},
+		Spec: corev1alpha1.WorkloadPolicySpec{
+			Quota: &corev1alpha1.QuotaPolicy{Hard: map[string]string{"requests.cpu": "10", "pods": "50"}},
+		},
+	}
+	r := newLimitsTestReconciler(t)
+
+	if err := r.reconcileResourceQuota(ctx, policy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var quota corev1.ResourceQuota
+	if err := r.Get(ctx, types.NamespacedName{Name: "platform-pgo-quota", Namespace: "team-a"}, &quota); err != nil {
+		t.Fatalf("expected the ResourceQuota to be created: %v", err)
+	}
+	if pods := quota.Spec.Hard[corev1.ResourcePods]; pods.Value() != 50 || quota.Labels[managedResourceQuotaLabelKey] != managedLabelValue {
+		t.Fatalf("expected a managed quota of 50 pods, got %+v", quota)
+	}
+}
@@ -0,0 +1,152 @@
+// Package governance evaluates Pods against the governance policies: it selects
+// the baselines, PolicyExceptions and WorkloadPolicies that apply to a Pod,
+// checks baseline rules and merges WorkloadPolicy defaults. Both the admission
+// webhooks and the controllers build on it.
+package governance
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport/v1alpha2"
+)
+
+// BaselineFinding is a rule of a baseline that a Pod violates. It exposes the
+// admission rules to callers outside of admission, such as background scans.
+type BaselineFinding struct {
+	// Rule identifies the violated baseline rule.
+	Rule platformv1alpha1.SecurityBaselineRule
+	// Container is the offending container, or empty for Pod-level rules.
+	Container string
+	// Field is the path of the offending field in the Pod.
+	Field string
+	// Message describes what is wrong.
+	Message string
+	// Exception is the name of the PolicyException exempting the Pod from
+	// the rule, or empty when the finding is a violation.
+	Exception string
+
+	text string
+}
+
+// String renders the finding the same way admission denials do.
+func (f BaselineFinding) String() string { return f.text }
+
+// BaselineSelectsPod reports whether a baseline of the given kind applies to
+// the Pod, honoring its podSelector and excludedNamespaces. Namespace
+// selection of cluster baselines is left to the caller. Debug baselines only
+// govern ephemeral containers added at admission and select no Pod.
+func BaselineSelectsPod(baseline *platformv1alpha1.SecurityBaseline, pod *corev1.Pod) bool {
+	return baseline.Spec.Debug == nil && !slices.Contains(baseline.Spec.ExcludedNamespaces, pod.Namespace) &&
+		SelectsPod(baseline.Spec.PodSelector, pod.Labels)
+}
+
+// EvaluateBaseline returns every rule of the baseline that the Pod violates,
+// applying the PolicyExceptions of the Pod namespace that are active at now.
+// kind is SecurityBaseline or ClusterSecurityBaseline and is matched against
+// the exceptions' baseline reference. namespaceAnnotations are the
+// annotations of the Pod namespace, which may hold its allowed ID ranges.
+func EvaluateBaseline(pod *corev1.Pod, kind string, baseline *platformv1alpha1.SecurityBaseline, namespaceAnnotations map[string]string,
+	exceptions []platformv1alpha1.PolicyException, now time.Time) (violations, exempted []BaselineFinding) {
+	active := ActivePolicyExceptions(slices.Clone(exceptions), now)
+	remaining, exemptedViolations := ExemptViolations(EvaluateSecurityBaseline(pod, baseline, namespaceAnnotations), active, kind,
+		baseline.Name, pod)
+	return Findings(remaining), Findings(exemptedViolations)
+}
+
+// BaselineRules lists the rules a baseline checks, after expanding its profile.
+func BaselineRules(baseline *platformv1alpha1.SecurityBaseline) []platformv1alpha1.SecurityBaselineRule {
+	rules := resolveBaselineRules(&baseline.Spec)
+	enabled := rules.enabled()
+	if baseline.Spec.ServiceAccounts != nil {
+		enabled = append(enabled, platformv1alpha1.RuleServiceAccounts)
+	}
+	if baseline.Spec.Images != nil {
+		enabled = append(enabled, platformv1alpha1.RuleImages)
+	}
+	return enabled
+}
+
+// BaselineReportResults reports the evaluation of a Pod against a baseline of
+// the given kind as one PolicyReport result per checked rule: fail (warn for
+// Warn baselines) when the rule is violated, skip when every violation is
+// exempted by a PolicyException, and pass otherwise.
+func BaselineReportResults(subject corev1.ObjectReference, kind string, baseline *platformv1alpha1.SecurityBaseline,
+	violations, exempted []BaselineFinding) []policyreport.Result {
+	failed := v1alpha2.PolicyResultFail
+	if EffectiveEnforcementAction(baseline.Spec.EnforcementAction) == platformv1alpha1.EnforcementActionWarn {
+		failed = v1alpha2.PolicyResultWarn
+	}
+
+	rules := BaselineRules(baseline)
+	results := make([]policyreport.Result, 0, len(rules))
+	for _, rule := range rules {
+		result := policyreport.Result{
+			Policy:  policyreport.PolicyRef{Kind: kind, Namespace: baseline.Namespace, Name: baseline.Name},
+			Rule:    string(rule),
+			Result:  v1alpha2.PolicyResultPass,
+			Subject: subject,
+		}
+		violated, exemptedBy := findingsFor(rule, violations), findingsFor(rule, exempted)
+		switch {
+		case len(violated) > 0:
+			result.Result = failed
+			result.Message = joinFindings(violated)
+		case len(exemptedBy) > 0:
+			result.Result = v1alpha2.PolicyResultSkip
+			result.Message = joinFindings(exemptedBy)
+			result.Properties = map[string]string{"exception": exemptedBy[0].Exception}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func findingsFor(rule platformv1alpha1.SecurityBaselineRule, findings []BaselineFinding) []BaselineFinding {
+	var matching []BaselineFinding
+	for _, finding := range findings {
+		if finding.Rule == rule {
+			matching = append(matching, finding)
+		}
+	}
+	return matching
+}
+
+func joinFindings(findings []BaselineFinding) string {
+	messages := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		messages = append(messages, finding.String())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Findings converts violations into BaselineFindings.
+func Findings(violations []Violation) []BaselineFinding {
+	findings := make([]BaselineFinding, 0, len(violations))
+	for _, violation := range violations {
+		findings = append(findings, BaselineFinding{
+			Rule:      violation.Rule,
+			Container: violation.Container,
+			Field:     violation.Field.String(),
+			Message:   violation.Message,
+			Exception: violation.Exception,
+			text:      violation.String(),
+		})
+	}
+	return findings
+}
+
+// EffectiveEnforcementAction returns a policy's enforcement action, falling
+// back to Enforce when the field is unset (e.g. objects created before the
+// field existed and never defaulted by the API server).
+func EffectiveEnforcementAction(action platformv1alpha1.EnforcementAction) platformv1alpha1.EnforcementAction {
+	if action == "" {
+		return platformv1alpha1.EnforcementActionEnforce
+	}
+	return action
+}
@@ -0,0 +1,70 @@
+package governance
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport/v1alpha2"
+)
+
+func TestBaselineReportResults(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted", Namespace: "team-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			RunAsNonRoot:           ptr.To(true),
+			ReadOnlyRootFilesystem: ptr.To(true),
+			DisallowPrivileged:     ptr.To(true),
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a", Labels: map[string]string{"app": "web"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+	exception := platformv1alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "team-a"},
+		Spec: platformv1alpha1.PolicyExceptionSpec{
+			Baseline:  platformv1alpha1.BaselineReference{Kind: "SecurityBaseline", Name: "restricted"},
+			Rules:     []platformv1alpha1.SecurityBaselineRule{platformv1alpha1.RuleReadOnlyRootFilesystem},
+			ExpiresAt: metav1.NewTime(time.Now().Add(time.Hour)),
+		},
+	}
+
+	violations, exempted := EvaluateBaseline(pod, "SecurityBaseline", baseline, nil, []platformv1alpha1.PolicyException{exception}, time.Now())
+	subject := corev1.ObjectReference{Kind: "Pod", Namespace: "team-a", Name: "web"}
+	results := BaselineReportResults(subject, "SecurityBaseline", baseline, violations, exempted)
+
+	want := map[string]v1alpha2.PolicyResult{
+		"runAsNonRoot":           v1alpha2.PolicyResultFail,
+		"readOnlyRootFilesystem": v1alpha2.PolicyResultSkip,
+		"disallowPrivileged":     v1alpha2.PolicyResultPass,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("expected a result per checked rule, got %+v", results)
+	}
+	for _, result := range results {
+		if result.Result != want[result.Rule] {
+			t.Fatalf("expected rule %s to %s, got %s (%s)", result.Rule, want[result.Rule], result.Result, result.Message)
+		}
+		if result.Policy != (policyreport.PolicyRef{Kind: "SecurityBaseline", Namespace: "team-a", Name: "restricted"}) || result.Subject != subject {
+			t.Fatalf("unexpected policy or subject: %+v", result)
+		}
+		if result.Result == v1alpha2.PolicyResultSkip && result.Properties["exception"] != "legacy" {
+			t.Fatalf("expected the skip to name the exception, got %+v", result.Properties)
+		}
+	}
+
+	baseline.Spec.EnforcementAction = platformv1alpha1.EnforcementActionWarn
+	for _, result := range BaselineReportResults(subject, "SecurityBaseline", baseline, violations, exempted) {
+		if result.Rule == "runAsNonRoot" && result.Result != v1alpha2.PolicyResultWarn {
+			t.Fatalf("expected violations of a Warn baseline to be reported as warn, got %s", result.Result)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package governance
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// remediatedFieldsAnnotation lists the security settings the mutating webhook
+// injected into a Pod on behalf of SecurityBaselines with remediation Mutate,
+// as comma-separated field paths.
+const remediatedFieldsAnnotation = "core.platform.f3nr1r.io/remediated-fields"
+
+// BaselineRemediation records the settings injected for one baseline.
+type BaselineRemediation struct {
+	Kind   string
+	Object client.Object
+	Fields []string
+}
+
+// RemediateBaselines fills in the missing security settings required by every
+// ClusterSecurityBaseline and SecurityBaseline with remediation Mutate that
+// selects the Pod in the namespace. Only settings the Pod leaves unset are
+// injected, and rules the Pod is exempted from by an active PolicyException
+// are left alone. Baselines that injected nothing are omitted from the result.
+// debugUser selects debug baselines as for validation.
+func RemediateBaselines(ctx context.Context, c client.Reader, namespace string, pod *corev1.Pod,
+	debugUser *authenticationv1.UserInfo) ([]BaselineRemediation, error) {
+	// Baselines are selected against the labels the Pod was submitted with.
+	selected, _, err := SelectBaselines(ctx, c, namespace, pod, debugUser)
+	if err != nil {
+		return nil, err
+	}
+	selected = slices.DeleteFunc(selected, func(candidate SelectedBaseline) bool {
+		return candidate.Baseline.Spec.Remediation != platformv1alpha1.RemediationMutate
+	})
+	if len(selected) == 0 {
+		return nil, nil
+	}
+
+	var exceptions platformv1alpha1.PolicyExceptionList
+	if err := c.List(ctx, &exceptions, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	activeExceptions := ActivePolicyExceptions(exceptions.Items, time.Now())
+
+	var remediations []BaselineRemediation
+	for _, candidate := range selected {
+		baseline := &candidate.Baseline
+		// ID ranges are never remediated, so their namespace annotations are
+		// not needed.
+		violations, _ := ExemptViolations(EvaluateSecurityBaseline(pod, baseline, nil), activeExceptions, candidate.Kind, baseline.Name, pod)
+		rules := resolveBaselineRules(&baseline.Spec)
+		if fields := remediateViolations(pod, &rules, violations); len(fields) > 0 {
+			remediations = append(remediations, BaselineRemediation{Kind: candidate.Kind, Object: candidate.Object, Fields: fields})
+		}
+	}
+	return remediations, nil
+}
+
+// remediateViolations injects secure defaults for the violated settings and
+// returns the paths of the injected fields. Settings that are explicitly set,
+// even to an insecure value, are never changed.
+func remediateViolations(pod *corev1.Pod, rules *baselineRules, violations []Violation) []string {
+	violated := make(map[string]bool, len(violations))
+	seccompViolated := false
+	for _, violation := range violations {
+		violated[violation.Field.String()] = true
+		seccompViolated = seccompViolated || violation.Rule == platformv1alpha1.RuleSeccomp
+	}
+
+	var fields []string
+	podPath := field.NewPath("spec", "securityContext")
+	podSecurityContextUnset := pod.Spec.SecurityContext == nil
+	if podSecurityContextUnset {
+		pod.Spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	if violated[podPath.Child("runAsNonRoot").String()] && pod.Spec.SecurityContext.RunAsNonRoot == nil {
+		pod.Spec.SecurityContext.RunAsNonRoot = ptr.To(true)
+		fields = append(fields, podPath.Child("runAsNonRoot").String())
+	}
+	// Containers setting their own seccomp profile keep it; only the Pod-level
+	// default the others inherit is filled in.
+	if seccompViolated && pod.Spec.SecurityContext.SeccompProfile == nil && rules.allowsRuntimeDefaultSeccomp() {
+		pod.Spec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+		fields = append(fields, podPath.Child("seccompProfile").String())
+	}
+	if podSecurityContextUnset && equality.Semantic.DeepEqual(*pod.Spec.SecurityContext, corev1.PodSecurityContext{}) {
+		pod.Spec.SecurityContext = nil
+	}
+
+	ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+		securityContextUnset := c.SecurityContext == nil
+		if securityContextUnset {
+			c.SecurityContext = &corev1.SecurityContext{}
+		}
+		securityContext := c.SecurityContext
+		securityContextPath := fldPath.Child("securityContext")
+
+		if path := securityContextPath.Child("readOnlyRootFilesystem").String(); violated[path] && securityContext.ReadOnlyRootFilesystem == nil {
+			securityContext.ReadOnlyRootFilesystem = ptr.To(true)
+			fields = append(fields, path)
+		}
+		// The API server rejects allowPrivilegeEscalation: false for
+		// privileged containers and containers adding CAP_SYS_ADMIN.
+		if path := securityContextPath.Child("allowPrivilegeEscalation").String(); violated[path] &&
+			securityContext.AllowPrivilegeEscalation == nil && !escalatesPrivileges(securityContext) {
+			securityContext.AllowPrivilegeEscalation = ptr.To(false)
+			fields = append(fields, path)
+		}
+		if path := securityContextPath.Child("capabilities", "drop").String(); violated[path] &&
+			(securityContext.Capabilities == nil || len(securityContext.Capabilities.Drop) == 0) {
+			if securityContext.Capabilities == nil {
+				securityContext.Capabilities = &corev1.Capabilities{}
+			}
+			securityContext.Capabilities.Drop = []corev1.Capability{capabilityAll}
+			fields = append(fields, path)
+		}
+
+		if securityContextUnset && equality.Semantic.DeepEqual(*securityContext, corev1.SecurityContext{}) {
+			c.SecurityContext = nil
+		}
+	})
+
+	if len(fields) > 0 {
+		recordRemediatedFields(pod, fields)
+	}
+	return fields
+}
+
+// escalatesPrivileges reports whether a container is privileged or adds
+// CAP_SYS_ADMIN, which always allows privilege escalation.
+func escalatesPrivileges(securityContext *corev1.SecurityContext) bool {
+	if securityContext.Privileged != nil && *securityContext.Privileged {
+		return true
+	}
+	return securityContext.Capabilities != nil && slices.ContainsFunc(securityContext.Capabilities.Add, func(c corev1.Capability) bool {
+		return c == "SYS_ADMIN" || c == "CAP_SYS_ADMIN"
+	})
+}
+
+// recordRemediatedFields adds the injected fields to the
+// remediated-fields annotation of the Pod, keeping fields recorded earlier.
+func recordRemediatedFields(pod *corev1.Pod, fields []string) {
+	var recorded []string
+	if existing := pod.Annotations[remediatedFieldsAnnotation]; existing != "" {
+		recorded = strings.Split(existing, ",")
+	}
+	for _, f := range fields {
+		if !slices.Contains(recorded, f) {
+			recorded = append(recorded, f)
+		}
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[remediatedFieldsAnnotation] = strings.Join(recorded, ",")
+}
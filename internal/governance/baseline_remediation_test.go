@@ -0,0 +1,124 @@
+package governance
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+func remediatingBaseline() *platformv1alpha1.SecurityBaseline {
+	return &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted", Namespace: "team-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			Profile:                platformv1alpha1.PodSecurityProfileRestricted,
+			RunAsNonRoot:           ptr.To(true),
+			ReadOnlyRootFilesystem: ptr.To(true),
+			Remediation:            platformv1alpha1.RemediationMutate,
+		},
+	}
+}
+
+func TestRemediateViolationsFillsUnsetSettings(t *testing.T) {
+	t.Parallel()
+
+	baseline := remediatingBaseline()
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx"}}}}
+
+	rules := resolveBaselineRules(&baseline.Spec)
+	fields := remediateViolations(pod, &rules, EvaluateSecurityBaseline(pod, baseline, nil))
+	want := []string{
+		"spec.securityContext.runAsNonRoot",
+		"spec.securityContext.seccompProfile",
+		"spec.containers[0].securityContext.readOnlyRootFilesystem",
+		"spec.containers[0].securityContext.allowPrivilegeEscalation",
+		"spec.containers[0].securityContext.capabilities.drop",
+	}
+	if strings.Join(fields, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected injected fields %v, got %v", want, fields)
+	}
+	if got := pod.Annotations[remediatedFieldsAnnotation]; got != strings.Join(want, ",") {
+		t.Fatalf("expected the injected fields to be recorded, got %q", got)
+	}
+	if violations := EvaluateSecurityBaseline(pod, baseline, nil); len(violations) != 0 {
+		t.Fatalf("expected the remediated Pod to comply, got %v", violations)
+	}
+}
+
+func TestRemediateViolationsKeepsExplicitValues(t *testing.T) {
+	t.Parallel()
+
+	baseline := remediatingBaseline()
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		SecurityContext: &corev1.PodSecurityContext{RunAsNonRoot: ptr.To(false)},
+		Containers: []corev1.Container{{
+			Name:  "app",
+			Image: "nginx",
+			SecurityContext: &corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(false),
+				Privileged:             ptr.To(true),
+				Capabilities:           &corev1.Capabilities{Drop: []corev1.Capability{"NET_RAW"}},
+				SeccompProfile:         &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+			},
+		}},
+	}}
+
+	rules := resolveBaselineRules(&baseline.Spec)
+	fields := remediateViolations(pod, &rules, EvaluateSecurityBaseline(pod, baseline, nil))
+	if strings.Join(fields, ",") != "spec.securityContext.seccompProfile" {
+		t.Fatalf("expected only the Pod-level seccomp default to be injected, got %v", fields)
+	}
+
+	remaining := map[string]bool{}
+	for _, violation := range EvaluateSecurityBaseline(pod, baseline, nil) {
+		remaining[violation.Field.String()] = true
+	}
+	for _, path := range []string{
+		"spec.securityContext.runAsNonRoot",
+		"spec.containers[0].securityContext.readOnlyRootFilesystem",
+		"spec.containers[0].securityContext.allowPrivilegeEscalation",
+		"spec.containers[0].securityContext.capabilities.drop",
+		"spec.containers[0].securityContext.seccompProfile.type",
+	} {
+		if !remaining[path] {
+			t.Fatalf("expected the explicit insecure value at %s to remain a violation, got %v", path, remaining)
+		}
+	}
+}
+
+func TestRemediateBaselinesHonoursExceptionsAndMode(t *testing.T) {
+	t.Parallel()
+
+	enforcing := remediatingBaseline()
+	enforcing.Name = "enforcing"
+	enforcing.Spec.Remediation = platformv1alpha1.RemediationNone
+	exception := &platformv1alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "team-a"},
+		Spec: platformv1alpha1.PolicyExceptionSpec{
+			Baseline:  platformv1alpha1.BaselineReference{Kind: "SecurityBaseline", Name: "restricted"},
+			Rules:     []platformv1alpha1.SecurityBaselineRule{platformv1alpha1.RuleReadOnlyRootFilesystem},
+			ExpiresAt: metav1.NewTime(time.Now().Add(time.Hour)),
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).
+		WithObjects(remediatingBaseline(), enforcing, exception).Build()
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx"}}}}
+
+	remediations, err := RemediateBaselines(context.Background(), c, "team-a", pod, nil)
+	if err != nil {
+		t.Fatalf("remediateBaselines returned error: %v", err)
+	}
+	if len(remediations) != 1 || remediations[0].Object.GetName() != "restricted" {
+		t.Fatalf("expected only the Mutate baseline to remediate, got %+v", remediations)
+	}
+	if pod.Spec.Containers[0].SecurityContext.ReadOnlyRootFilesystem != nil {
+		t.Fatal("expected the exempted readOnlyRootFilesystem rule not to be remediated")
+	}
+}
@@ -0,0 +1,109 @@
+package governance
+
+import (
+	"context"
+	"slices"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// SelectedBaseline is a baseline that applies to a Pod, together with the
+// object it was read from: a SecurityBaseline or a ClusterSecurityBaseline.
+type SelectedBaseline struct {
+	Kind     string
+	Object   client.Object
+	Baseline platformv1alpha1.SecurityBaseline
+}
+
+// SelectBaselines returns the ClusterSecurityBaselines and SecurityBaselines
+// that apply to the Pod in the namespace, cluster baselines first, together
+// with the annotations of the namespace. debugUser is the user adding
+// ephemeral containers to the Pod, or nil for any other request; debug
+// baselines only apply to such requests, as described by selectDebugBaselines.
+func SelectBaselines(ctx context.Context, c client.Reader, namespace string, pod *corev1.Pod,
+	debugUser *authenticationv1.UserInfo) ([]SelectedBaseline, map[string]string, error) {
+	var clusterBaselines platformv1alpha1.ClusterSecurityBaselineList
+	if err := c.List(ctx, &clusterBaselines); err != nil {
+		return nil, nil, err
+	}
+	var baselines platformv1alpha1.SecurityBaselineList
+	if err := c.List(ctx, &baselines, client.InNamespace(namespace)); err != nil {
+		return nil, nil, err
+	}
+
+	// The namespace is only read when a cluster baseline needs its labels or a
+	// baseline needs its ID range annotations.
+	var ns corev1.Namespace
+	if len(clusterBaselines.Items) > 0 || slices.ContainsFunc(baselines.Items, func(baseline platformv1alpha1.SecurityBaseline) bool {
+		return readsNamespaceIDRanges(&baseline.Spec)
+	}) {
+		if err := c.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var cluster []SelectedBaseline
+	for i, clusterBaseline := range clusterBaselines.Items {
+		if !SelectsNamespace(clusterBaseline.Spec.NamespaceSelector, ns.Labels) {
+			continue
+		}
+		cluster = append(cluster, SelectedBaseline{
+			Kind:   "ClusterSecurityBaseline",
+			Object: &clusterBaselines.Items[i],
+			Baseline: platformv1alpha1.SecurityBaseline{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterBaseline.Name},
+				Spec:       clusterBaseline.Spec.SecurityBaselineSpec,
+			},
+		})
+	}
+	var namespaced []SelectedBaseline
+	for i, baseline := range baselines.Items {
+		namespaced = append(namespaced, SelectedBaseline{Kind: "SecurityBaseline", Object: &baselines.Items[i], Baseline: baseline})
+	}
+
+	// Cluster baselines are evaluated independently of namespaced ones, so a
+	// namespaced SecurityBaseline can only add restrictions on top of them.
+	var selected []SelectedBaseline
+	for _, candidates := range [][]SelectedBaseline{cluster, namespaced} {
+		candidates = slices.DeleteFunc(candidates, func(candidate SelectedBaseline) bool {
+			spec := &candidate.Baseline.Spec
+			return slices.Contains(spec.ExcludedNamespaces, namespace) || !SelectsPod(spec.PodSelector, pod.Labels)
+		})
+		selected = append(selected, selectDebugBaselines(candidates, debugUser)...)
+	}
+	return selected, ns.Annotations, nil
+}
+
+// selectDebugBaselines picks the baselines of a single kind that govern a
+// request. Debug baselines are dropped, unless debugUser is listed by one of
+// them: the request then breaks glass and only the debug baselines listing
+// the user are evaluated.
+func selectDebugBaselines(candidates []SelectedBaseline, debugUser *authenticationv1.UserInfo) []SelectedBaseline {
+	var regular, debug []SelectedBaseline
+	for _, candidate := range candidates {
+		switch policy := candidate.Baseline.Spec.Debug; {
+		case policy == nil:
+			regular = append(regular, candidate)
+		case debugUser != nil && allowsBreakGlass(policy, debugUser):
+			debug = append(debug, candidate)
+		}
+	}
+	if len(debug) > 0 {
+		return debug
+	}
+	return regular
+}
+
+// allowsBreakGlass reports whether the debug policy lists the user or one of
+// their groups.
+func allowsBreakGlass(policy *platformv1alpha1.DebugPolicy, user *authenticationv1.UserInfo) bool {
+	return slices.Contains(policy.Users, user.Username) || slices.ContainsFunc(policy.Groups, func(group string) bool {
+		return slices.Contains(user.Groups, group)
+	})
+}
@@ -0,0 +1,319 @@
+package governance
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// EffectiveGovernance computes the governance in effect for every Pod of the
+// namespace: the defaults the Pod mutating webhook applies, the HPA behavior
+// the WorkloadPolicy controller manages and the rules the Pod validating
+// webhook enforces, each attributed to the policy it comes from. Policies with
+// a podSelector only apply to some Pods and are listed as selective instead.
+// PolicyExceptions active at now are listed as exemptions from the rules.
+func EffectiveGovernance(ctx context.Context, c client.Reader, namespace string,
+	now time.Time) (platformv1alpha1.EffectiveGovernanceStatus, error) {
+	var status platformv1alpha1.EffectiveGovernanceStatus
+
+	nsLabels, err := NamespaceLabels(ctx, c, namespace)
+	if err != nil {
+		return status, err
+	}
+	var policies platformv1alpha1.WorkloadPolicyList
+	if err := c.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		return status, err
+	}
+	var clusterPolicies platformv1alpha1.ClusterWorkloadPolicyList
+	if err := c.List(ctx, &clusterPolicies); err != nil {
+		return status, err
+	}
+	var profiles platformv1alpha1.TelemetryProfileList
+	if err := c.List(ctx, &profiles, client.InNamespace(namespace)); err != nil {
+		return status, err
+	}
+	var clusterProfiles platformv1alpha1.ClusterTelemetryProfileList
+	if err := c.List(ctx, &clusterProfiles); err != nil {
+		return status, err
+	}
+	var baselines platformv1alpha1.SecurityBaselineList
+	if err := c.List(ctx, &baselines, client.InNamespace(namespace)); err != nil {
+		return status, err
+	}
+	var clusterBaselines platformv1alpha1.ClusterSecurityBaselineList
+	if err := c.List(ctx, &clusterBaselines); err != nil {
+		return status, err
+	}
+	var exceptions platformv1alpha1.PolicyExceptionList
+	if err := c.List(ctx, &exceptions, client.InNamespace(namespace)); err != nil {
+		return status, err
+	}
+
+	var namespaced, cluster []WorkloadPolicyCandidate
+	for i, policy := range policies.Items {
+		if policy.Spec.PodSelector != nil {
+			status.SelectivePolicies = append(status.SelectivePolicies, policySource("WorkloadPolicy", &policy))
+			continue
+		}
+		namespaced = append(namespaced, WorkloadPolicyCandidate{Kind: "WorkloadPolicy", Object: &policies.Items[i], Policy: policy})
+	}
+	for i, clusterPolicy := range clusterPolicies.Items {
+		if !SelectsNamespace(clusterPolicy.Spec.NamespaceSelector, nsLabels) {
+			continue
+		}
+		if clusterPolicy.Spec.PodSelector != nil {
+			status.SelectivePolicies = append(status.SelectivePolicies, policySource("ClusterWorkloadPolicy", &clusterPolicy))
+			continue
+		}
+		policy := platformv1alpha1.WorkloadPolicy{ObjectMeta: clusterPolicy.ObjectMeta, Spec: clusterPolicy.Spec.WorkloadPolicySpec}
+		cluster = append(cluster, WorkloadPolicyCandidate{Kind: "ClusterWorkloadPolicy", Object: &clusterPolicies.Items[i], Policy: policy})
+	}
+	status.HorizontalScaling = effectiveHorizontalScaling(namespaced)
+	mergeEffectiveDefaults(&status, namespaced, cluster)
+
+	var telemetry []telemetryCandidate
+	slices.SortFunc(profiles.Items, func(a, b platformv1alpha1.TelemetryProfile) int {
+		return ByPriority(a.Spec.Priority, a.Name, b.Spec.Priority, b.Name)
+	})
+	for i, profile := range profiles.Items {
+		source := policySource("TelemetryProfile", &profile)
+		if profile.Spec.PodSelector != nil {
+			status.SelectivePolicies = append(status.SelectivePolicies, source)
+			continue
+		}
+		telemetry = append(telemetry, telemetryCandidate{source: source, spec: &profiles.Items[i].Spec})
+	}
+	slices.SortFunc(clusterProfiles.Items, func(a, b platformv1alpha1.ClusterTelemetryProfile) int {
+		return ByPriority(a.Spec.Priority, a.Name, b.Spec.Priority, b.Name)
+	})
+	for i, profile := range clusterProfiles.Items {
+		if !SelectsNamespace(profile.Spec.NamespaceSelector, nsLabels) {
+			continue
+		}
+		source := policySource("ClusterTelemetryProfile", &profile)
+		if profile.Spec.PodSelector != nil {
+			status.SelectivePolicies = append(status.SelectivePolicies, source)
+			continue
+		}
+		telemetry = append(telemetry, telemetryCandidate{source: source, spec: &clusterProfiles.Items[i].Spec.TelemetryProfileSpec})
+	}
+	status.TelemetryEnv = effectiveTelemetryEnv(telemetry)
+
+	// Baselines are listed cluster first, like the Pod validating webhook
+	// evaluates them.
+	var selected []SelectedBaseline
+	slices.SortFunc(clusterBaselines.Items, func(a, b platformv1alpha1.ClusterSecurityBaseline) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	for i, clusterBaseline := range clusterBaselines.Items {
+		if SelectsNamespace(clusterBaseline.Spec.NamespaceSelector, nsLabels) {
+			selected = append(selected, SelectedBaseline{
+				Kind:   "ClusterSecurityBaseline",
+				Object: &clusterBaselines.Items[i],
+				Baseline: platformv1alpha1.SecurityBaseline{
+					ObjectMeta: clusterBaseline.ObjectMeta,
+					Spec:       clusterBaseline.Spec.SecurityBaselineSpec,
+				},
+			})
+		}
+	}
+	slices.SortFunc(baselines.Items, func(a, b platformv1alpha1.SecurityBaseline) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	for i, baseline := range baselines.Items {
+		selected = append(selected, SelectedBaseline{Kind: "SecurityBaseline", Object: &baselines.Items[i], Baseline: baseline})
+	}
+	active := ActivePolicyExceptions(exceptions.Items, now)
+	slices.SortFunc(active, func(a, b platformv1alpha1.PolicyException) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	for _, candidate := range selected {
+		spec := &candidate.Baseline.Spec
+		if spec.Debug != nil || slices.Contains(spec.ExcludedNamespaces, namespace) {
+			continue
+		}
+		source := policySource(candidate.Kind, candidate.Object)
+		if spec.PodSelector != nil {
+			status.SelectivePolicies = append(status.SelectivePolicies, source)
+			continue
+		}
+		for _, rule := range BaselineRules(&candidate.Baseline) {
+			status.SecurityRules = append(status.SecurityRules, platformv1alpha1.EffectiveSecurityRule{
+				Rule:              rule,
+				EnforcementAction: EffectiveEnforcementAction(spec.EnforcementAction),
+				Remediation:       spec.Remediation,
+				Source:            source,
+			})
+			status.Exemptions = append(status.Exemptions, effectiveExemptions(active, candidate.Kind, source, rule)...)
+		}
+	}
+
+	slices.SortFunc(status.SelectivePolicies, func(a, b platformv1alpha1.PolicySource) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name))
+	})
+	return status, nil
+}
+
+// policySource names the policy of the given kind.
+func policySource(kind string, policy client.Object) platformv1alpha1.PolicySource {
+	return platformv1alpha1.PolicySource{Kind: kind, Namespace: policy.GetNamespace(), Name: policy.GetName()}
+}
+
+// effectiveExemptions returns an exemption from the rule of the baseline for
+// every exception exempting Pods from it.
+func effectiveExemptions(exceptions []platformv1alpha1.PolicyException, kind string, baseline platformv1alpha1.PolicySource,
+	rule platformv1alpha1.SecurityBaselineRule) []platformv1alpha1.EffectiveExemption {
+	var exemptions []platformv1alpha1.EffectiveExemption
+	for i := range exceptions {
+		exception := &exceptions[i]
+		if !exceptionReferences(exception, kind, baseline.Name) || !slices.Contains(exception.Spec.Rules, rule) {
+			continue
+		}
+		exemptions = append(exemptions, platformv1alpha1.EffectiveExemption{
+			Rule:      rule,
+			Baseline:  baseline,
+			Source:    policySource("PolicyException", exception),
+			ExpiresAt: exception.Spec.ExpiresAt,
+			Selective: exception.Spec.PodSelector != nil,
+		})
+	}
+	return exemptions
+}
+
+// mergeEffectiveDefaults records the resource defaults and mandatory labels
+// of the WorkloadPolicies and then the ClusterWorkloadPolicies, each scope
+// merged by MergeWorkloadPolicies. As in the Pod mutating webhook, the first
+// policy to set a key wins.
+func mergeEffectiveDefaults(status *platformv1alpha1.EffectiveGovernanceStatus, scopes ...[]WorkloadPolicyCandidate) {
+	type resourceKey struct {
+		containers string
+		selector   platformv1alpha1.ContainerSelector
+		typ        string
+		resource   string
+	}
+	resources := map[resourceKey]bool{}
+	addSelectedResources := func(source platformv1alpha1.PolicySource, containers string,
+		selector *platformv1alpha1.ContainerSelector, typ string, defaults map[string]string) {
+		for _, name := range slices.Sorted(maps.Keys(defaults)) {
+			key := resourceKey{containers: containers, typ: typ, resource: name}
+			if selector != nil {
+				key.selector = *selector
+			}
+			if resources[key] {
+				continue
+			}
+			resources[key] = true
+			status.ResourceDefaults = append(status.ResourceDefaults, platformv1alpha1.EffectiveResourceDefault{
+				Containers: containers, Selector: selector, Type: typ, Resource: name, Value: defaults[name], Source: source,
+			})
+		}
+	}
+	addResources := func(source platformv1alpha1.PolicySource, containers, typ string, defaults map[string]string) {
+		addSelectedResources(source, containers, nil, typ, defaults)
+	}
+	labels := map[string]bool{}
+
+	for _, candidates := range scopes {
+		for _, candidate := range MergeWorkloadPolicies(candidates) {
+			if candidate.SupersededBy != "" {
+				continue
+			}
+			source := policySource(candidate.Kind, candidate.Object)
+			spec := &candidate.Policy.Spec
+			for i := range spec.ContainerResources {
+				rule := &spec.ContainerResources[i]
+				selector := rule.ContainerSelector
+				addSelectedResources(source, platformv1alpha1.ContainerKindMatched, &selector, "requests", rule.DefaultRequests)
+				addSelectedResources(source, platformv1alpha1.ContainerKindMatched, &selector, "limits", rule.DefaultLimits)
+			}
+			addResources(source, platformv1alpha1.ContainerKindContainers, "requests", spec.DefaultRequests)
+			addResources(source, platformv1alpha1.ContainerKindContainers, "limits", spec.DefaultLimits)
+			if defaults := spec.InitContainerResources; defaults != nil {
+				addResources(source, platformv1alpha1.ContainerKindInitContainers, "requests", defaults.DefaultRequests)
+				addResources(source, platformv1alpha1.ContainerKindInitContainers, "limits", defaults.DefaultLimits)
+			}
+			if defaults := spec.SidecarResources; defaults != nil {
+				addResources(source, platformv1alpha1.ContainerKindSidecars, "requests", defaults.DefaultRequests)
+				addResources(source, platformv1alpha1.ContainerKindSidecars, "limits", defaults.DefaultLimits)
+			}
+			for _, key := range slices.Sorted(maps.Keys(spec.MandatoryLabels)) {
+				if labels[key] {
+					continue
+				}
+				labels[key] = true
+				status.MandatoryLabels = append(status.MandatoryLabels, platformv1alpha1.EffectiveLabel{
+					Key: key, Value: spec.MandatoryLabels[key], Source: source,
+				})
+			}
+		}
+	}
+}
+
+// effectiveHorizontalScaling returns the HPA behavior of the highest priority
+// WorkloadPolicy with horizontal scaling defaults, with unset settings
+// defaulted.
+func effectiveHorizontalScaling(candidates []WorkloadPolicyCandidate) *platformv1alpha1.EffectiveHorizontalScaling {
+	var highest *WorkloadPolicyCandidate
+	for i := range candidates {
+		candidate := &candidates[i]
+		if candidate.Policy.Spec.HorizontalScaling == nil {
+			continue
+		}
+		if highest == nil || ByPriority(candidate.Policy.Spec.Priority, candidate.Policy.Name,
+			highest.Policy.Spec.Priority, highest.Policy.Name) < 0 {
+			highest = candidate
+		}
+	}
+	if highest == nil {
+		return nil
+	}
+
+	scaling := *highest.Policy.Spec.HorizontalScaling
+	scaling.MinReplicas = cmp.Or(scaling.MinReplicas, platformv1alpha1.DefaultHPAMinReplicas)
+	scaling.MaxReplicas = cmp.Or(scaling.MaxReplicas, platformv1alpha1.DefaultHPAMaxReplicas)
+	scaling.TargetCPUUtilizationPercentage = cmp.Or(scaling.TargetCPUUtilizationPercentage, platformv1alpha1.DefaultHPATargetCPU)
+	return &platformv1alpha1.EffectiveHorizontalScaling{
+		HorizontalScalingPolicy: scaling,
+		Source:                  policySource(highest.Kind, highest.Object),
+	}
+}
+
+// telemetryCandidate is a TelemetryProfile or ClusterTelemetryProfile
+// applying to every Pod of a namespace.
+type telemetryCandidate struct {
+	source platformv1alpha1.PolicySource
+	spec   *platformv1alpha1.TelemetryProfileSpec
+}
+
+// effectiveTelemetryEnv returns the environment variables injectTelemetry
+// sets when applying the profiles in order: each variable comes from the
+// first injecting profile that defines it.
+func effectiveTelemetryEnv(profiles []telemetryCandidate) []platformv1alpha1.EffectiveEnvVar {
+	var env []platformv1alpha1.EffectiveEnvVar
+	var endpoint, samplingRate bool
+	for _, profile := range profiles {
+		spec := profile.spec
+		if !spec.InjectEnvVars || spec.TracingEndpoint == "" {
+			continue
+		}
+		if !endpoint {
+			endpoint = true
+			env = append(env, platformv1alpha1.EffectiveEnvVar{
+				Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: spec.TracingEndpoint, Source: profile.source,
+			})
+		}
+		if !samplingRate && spec.SamplingRate != "" {
+			samplingRate = true
+			env = append(env, platformv1alpha1.EffectiveEnvVar{
+				Name: "OTEL_TRACES_SAMPLER_ARG", Value: spec.SamplingRate, Source: profile.source,
+			})
+		}
+	}
+	return env
+}
@@ -0,0 +1,263 @@
+package governance
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add corev1 to scheme: %v", err)
+	}
+	if err := platformv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add platformv1alpha1 to scheme: %v", err)
+	}
+	return scheme
+}
+
+func TestEffectiveGovernanceAttributesMergedDefaults(t *testing.T) {
+	t.Parallel()
+
+	objects := []platformv1alpha1.WorkloadPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform", Namespace: "team-a"},
+			Spec: platformv1alpha1.WorkloadPolicySpec{
+				Priority:          10,
+				DefaultRequests:   map[string]string{"cpu": "500m"},
+				HorizontalScaling: &platformv1alpha1.HorizontalScalingPolicy{MaxReplicas: 4},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "team-a"},
+			Spec: platformv1alpha1.WorkloadPolicySpec{
+				DefaultRequests:   map[string]string{"cpu": "100m", "memory": "128Mi"},
+				MandatoryLabels:   map[string]string{"team": "a"},
+				HorizontalScaling: &platformv1alpha1.HorizontalScalingPolicy{},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: "team-a"},
+			Spec: platformv1alpha1.WorkloadPolicySpec{
+				PodSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"workload": "batch"}},
+				DefaultRequests: map[string]string{"cpu": "2"},
+			},
+		},
+	}
+	builder := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"tier": "prod"}}},
+		&platformv1alpha1.ClusterWorkloadPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-defaults"},
+			Spec: platformv1alpha1.ClusterWorkloadPolicySpec{WorkloadPolicySpec: platformv1alpha1.WorkloadPolicySpec{
+				DefaultLimits:   map[string]string{"memory": "1Gi"},
+				MandatoryLabels: map[string]string{"team": "unknown", "cost-center": "shared"},
+			}},
+		},
+	)
+	for i := range objects {
+		builder = builder.WithObjects(&objects[i])
+	}
+
+	status, err := EffectiveGovernance(context.Background(), builder.Build(), "team-a", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	platform := platformv1alpha1.PolicySource{Kind: "WorkloadPolicy", Namespace: "team-a", Name: "platform"}
+	team := platformv1alpha1.PolicySource{Kind: "WorkloadPolicy", Namespace: "team-a", Name: "team"}
+	cluster := platformv1alpha1.PolicySource{Kind: "ClusterWorkloadPolicy", Name: "cluster-defaults"}
+	wantResources := []platformv1alpha1.EffectiveResourceDefault{
+		{Containers: "containers", Type: "requests", Resource: "cpu", Value: "500m", Source: platform},
+		{Containers: "containers", Type: "requests", Resource: "memory", Value: "128Mi", Source: team},
+		{Containers: "containers", Type: "limits", Resource: "memory", Value: "1Gi", Source: cluster},
+	}
+	if !slices.Equal(status.ResourceDefaults, wantResources) {
+		t.Fatalf("expected resource defaults %+v, got %+v", wantResources, status.ResourceDefaults)
+	}
+	wantLabels := []platformv1alpha1.EffectiveLabel{
+		{Key: "team", Value: "a", Source: team},
+		{Key: "cost-center", Value: "shared", Source: cluster},
+	}
+	if !slices.Equal(status.MandatoryLabels, wantLabels) {
+		t.Fatalf("expected mandatory labels %+v, got %+v", wantLabels, status.MandatoryLabels)
+	}
+	if scaling := status.HorizontalScaling; scaling == nil || scaling.Source != platform ||
+		scaling.MaxReplicas != 4 || scaling.MinReplicas != platformv1alpha1.DefaultHPAMinReplicas {
+		t.Fatalf("expected the defaulted HPA behavior of the platform policy, got %+v", scaling)
+	}
+	wantSelective := []platformv1alpha1.PolicySource{{Kind: "WorkloadPolicy", Namespace: "team-a", Name: "batch"}}
+	if !slices.Equal(status.SelectivePolicies, wantSelective) {
+		t.Fatalf("expected selective policies %+v, got %+v", wantSelective, status.SelectivePolicies)
+	}
+}
+
+func TestEffectiveGovernanceListsTelemetryAndSecurityRules(t *testing.T) {
+	t.Parallel()
+
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&platformv1alpha1.TelemetryProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "team-a"},
+			Spec:       platformv1alpha1.TelemetryProfileSpec{InjectEnvVars: true, TracingEndpoint: "http://team:4317"},
+		},
+		&platformv1alpha1.ClusterTelemetryProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Spec: platformv1alpha1.ClusterTelemetryProfileSpec{TelemetryProfileSpec: platformv1alpha1.TelemetryProfileSpec{
+				InjectEnvVars: true, TracingEndpoint: "http://cluster:4317", SamplingRate: "0.1",
+			}},
+		},
+		&platformv1alpha1.ClusterSecurityBaseline{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-privileged"},
+			Spec: platformv1alpha1.ClusterSecurityBaselineSpec{SecurityBaselineSpec: platformv1alpha1.SecurityBaselineSpec{
+				DisallowPrivileged: ptr.To(true),
+			}},
+		},
+		&platformv1alpha1.SecurityBaseline{
+			ObjectMeta: metav1.ObjectMeta{Name: "read-only", Namespace: "team-a"},
+			Spec: platformv1alpha1.SecurityBaselineSpec{
+				ReadOnlyRootFilesystem: ptr.To(true),
+				EnforcementAction:      platformv1alpha1.EnforcementActionWarn,
+			},
+		},
+		&platformv1alpha1.SecurityBaseline{
+			ObjectMeta: metav1.ObjectMeta{Name: "excluded", Namespace: "team-a"},
+			Spec:       platformv1alpha1.SecurityBaselineSpec{ReadOnlyRootFilesystem: ptr.To(true), ExcludedNamespaces: []string{"team-a"}},
+		},
+		&platformv1alpha1.SecurityBaseline{
+			ObjectMeta: metav1.ObjectMeta{Name: "break-glass", Namespace: "team-a"},
+			Spec: platformv1alpha1.SecurityBaselineSpec{
+				ReadOnlyRootFilesystem: ptr.To(true),
+				Debug:                  &platformv1alpha1.DebugPolicy{Groups: []string{"sre"}},
+			},
+		},
+	).Build()
+
+	status, err := EffectiveGovernance(context.Background(), c, "team-a", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantEnv := []platformv1alpha1.EffectiveEnvVar{
+		{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://team:4317",
+			Source: platformv1alpha1.PolicySource{Kind: "TelemetryProfile", Namespace: "team-a", Name: "team"}},
+		{Name: "OTEL_TRACES_SAMPLER_ARG", Value: "0.1",
+			Source: platformv1alpha1.PolicySource{Kind: "ClusterTelemetryProfile", Name: "cluster"}},
+	}
+	if !slices.Equal(status.TelemetryEnv, wantEnv) {
+		t.Fatalf("expected telemetry env %+v, got %+v", wantEnv, status.TelemetryEnv)
+	}
+
+	wantRules := []platformv1alpha1.EffectiveSecurityRule{
+		{
+			Rule:              platformv1alpha1.RuleDisallowPrivileged,
+			EnforcementAction: platformv1alpha1.EnforcementActionEnforce,
+			Source:            platformv1alpha1.PolicySource{Kind: "ClusterSecurityBaseline", Name: "no-privileged"},
+		},
+		{
+			Rule:              platformv1alpha1.RuleReadOnlyRootFilesystem,
+			EnforcementAction: platformv1alpha1.EnforcementActionWarn,
+			Source:            platformv1alpha1.PolicySource{Kind: "SecurityBaseline", Namespace: "team-a", Name: "read-only"},
+		},
+	}
+	if !slices.Equal(status.SecurityRules, wantRules) {
+		t.Fatalf("expected security rules %+v, got %+v", wantRules, status.SecurityRules)
+	}
+}
+
+func TestEffectiveGovernanceListsContainerResourceRules(t *testing.T) {
+	t.Parallel()
+
+	rule := func(cpu string) platformv1alpha1.ContainerResourceRule {
+		return platformv1alpha1.ContainerResourceRule{
+			ContainerSelector:         platformv1alpha1.ContainerSelector{Name: "istio-proxy"},
+			ContainerResourceDefaults: platformv1alpha1.ContainerResourceDefaults{DefaultRequests: map[string]string{"cpu": cpu}},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&platformv1alpha1.WorkloadPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: "team-a"},
+			Spec: platformv1alpha1.WorkloadPolicySpec{
+				Priority:           10,
+				ContainerResources: []platformv1alpha1.ContainerResourceRule{rule("100m")},
+			},
+		},
+		&platformv1alpha1.WorkloadPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "team-a"},
+			Spec: platformv1alpha1.WorkloadPolicySpec{
+				DefaultRequests:    map[string]string{"cpu": "500m"},
+				ContainerResources: []platformv1alpha1.ContainerResourceRule{rule("1")},
+			},
+		},
+	).Build()
+
+	status, err := EffectiveGovernance(context.Background(), c, "team-a", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(status.ResourceDefaults) != 2 {
+		t.Fatalf("expected the rule and the container default, got %+v", status.ResourceDefaults)
+	}
+	matched := status.ResourceDefaults[0]
+	if matched.Containers != platformv1alpha1.ContainerKindMatched || matched.Selector == nil ||
+		matched.Selector.Name != "istio-proxy" || matched.Value != "100m" || matched.Source.Name != "mesh" {
+		t.Fatalf("expected the rule of the highest priority policy, got %+v", matched)
+	}
+	if containers := status.ResourceDefaults[1]; containers.Selector != nil || containers.Value != "500m" {
+		t.Fatalf("expected the defaults of regular containers, got %+v", containers)
+	}
+}
+
+func TestEffectiveGovernanceListsActiveExemptions(t *testing.T) {
+	t.Parallel()
+
+	// The API server stores expiresAt with second precision.
+	now := time.Now().Truncate(time.Second)
+	legacy := newPolicyException("legacy", "read-only", now.Add(time.Hour), platformv1alpha1.RuleReadOnlyRootFilesystem)
+	legacy.Spec.PodSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "legacy"}}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&platformv1alpha1.ClusterSecurityBaseline{
+			ObjectMeta: metav1.ObjectMeta{Name: "read-only"},
+			Spec: platformv1alpha1.ClusterSecurityBaselineSpec{SecurityBaselineSpec: platformv1alpha1.SecurityBaselineSpec{
+				ReadOnlyRootFilesystem: ptr.To(true),
+			}},
+		},
+		&platformv1alpha1.SecurityBaseline{
+			ObjectMeta: metav1.ObjectMeta{Name: "read-only", Namespace: "team-a"},
+			Spec:       platformv1alpha1.SecurityBaselineSpec{ReadOnlyRootFilesystem: ptr.To(true)},
+		},
+		legacy,
+		newPolicyException("expired", "read-only", now.Add(-time.Minute), platformv1alpha1.RuleReadOnlyRootFilesystem),
+		newPolicyException("unchecked", "read-only", now.Add(time.Hour), platformv1alpha1.RuleRunAsNonRoot),
+	).Build()
+
+	status, err := EffectiveGovernance(context.Background(), c, "team-a", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []platformv1alpha1.EffectiveExemption{{
+		Rule:      platformv1alpha1.RuleReadOnlyRootFilesystem,
+		Baseline:  platformv1alpha1.PolicySource{Kind: "SecurityBaseline", Namespace: "team-a", Name: "read-only"},
+		Source:    platformv1alpha1.PolicySource{Kind: "PolicyException", Namespace: "team-a", Name: "legacy"},
+		ExpiresAt: legacy.Spec.ExpiresAt,
+		Selective: true,
+	}}
+	if !equality.Semantic.DeepEqual(status.Exemptions, want) {
+		t.Fatalf("expected only the active exemption from the namespaced baseline %+v, got %+v", want, status.Exemptions)
+	}
+}
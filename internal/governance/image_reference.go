@@ -0,0 +1,62 @@
+package governance
+
+import "strings"
+
+const (
+	DefaultImageRegistry  = "docker.io"
+	defaultImageNamespace = "library"
+)
+
+// ImageReference is a container image reference split into its parts.
+type ImageReference struct {
+	// Registry is the registry host, e.g. docker.io or ghcr.io.
+	Registry string
+	// Repository is the repository path within the registry, e.g. library/nginx.
+	Repository string
+	// Tag is the image tag, empty when the reference has none.
+	Tag string
+	// Digest is the content digest (e.g. sha256:...), empty when not pinned.
+	Digest string
+}
+
+// ParseImageReference splits an image reference such as
+// "ghcr.io/org/app:1.2@sha256:..." into its parts, applying the same
+// defaults as the container runtime for Docker Hub short names.
+func ParseImageReference(image string) ImageReference {
+	var ref ImageReference
+
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	}
+
+	registry, repository, found := strings.Cut(name, "/")
+	if !found || (!strings.ContainsAny(registry, ".:") && registry != "localhost") {
+		registry = DefaultImageRegistry
+		repository = name
+	}
+	if registry == DefaultImageRegistry && !strings.Contains(repository, "/") {
+		repository = defaultImageNamespace + "/" + repository
+	}
+	ref.Registry = registry
+	ref.Repository = repository
+	return ref
+}
+
+// Name returns the fully qualified repository name, e.g. docker.io/library/nginx.
+func (r ImageReference) Name() string {
+	return r.Registry + "/" + r.Repository
+}
+
+// HasRepositoryPrefix reports whether the image's fully qualified name is equal
+// to or nested under prefix, matching whole path segments.
+func (r ImageReference) HasRepositoryPrefix(prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	name := r.Name()
+	return name == prefix || strings.HasPrefix(name, prefix+"/")
+}
@@ -0,0 +1,46 @@
+package governance
+
+import "testing"
+
+func TestParseImageReference(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		image string
+		want  ImageReference
+	}{
+		{"nginx", ImageReference{Registry: "docker.io", Repository: "library/nginx"}},
+		{"nginx:1.27", ImageReference{Registry: "docker.io", Repository: "library/nginx", Tag: "1.27"}},
+		{"bitnami/redis:7", ImageReference{Registry: "docker.io", Repository: "bitnami/redis", Tag: "7"}},
+		{"ghcr.io/org/app:v1@sha256:abc", ImageReference{Registry: "ghcr.io", Repository: "org/app", Tag: "v1", Digest: "sha256:abc"}},
+		{"registry.local:5000/team/app", ImageReference{Registry: "registry.local:5000", Repository: "team/app"}},
+		{"localhost/app@sha256:abc", ImageReference{Registry: "localhost", Repository: "app", Digest: "sha256:abc"}},
+	}
+	for _, tc := range cases {
+		if got := ParseImageReference(tc.image); got != tc.want {
+			t.Fatalf("parseImageReference(%q) = %+v, want %+v", tc.image, got, tc.want)
+		}
+	}
+}
+
+func TestImageReferenceHasRepositoryPrefixMatchesWholeSegments(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		image   string
+		prefix  string
+		matches bool
+	}{
+		{"ghcr.io/my-org/app:v1", "ghcr.io", true},
+		{"ghcr.io/my-org/app:v1", "ghcr.io/my-org", true},
+		{"ghcr.io/my-org/app:v1", "ghcr.io/my-org/", true},
+		{"ghcr.io/my-org-evil/app:v1", "ghcr.io/my-org", false},
+		{"ghcr.io.evil.com/app:v1", "ghcr.io", false},
+		{"nginx:1.27", "docker.io/library", true},
+	}
+	for _, tc := range cases {
+		if got := ParseImageReference(tc.image).HasRepositoryPrefix(tc.prefix); got != tc.matches {
+			t.Fatalf("%q hasRepositoryPrefix(%q) = %v, want %v", tc.image, tc.prefix, got, tc.matches)
+		}
+	}
+}
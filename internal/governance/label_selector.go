@@ -0,0 +1,48 @@
+package governance
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var governancelog = logf.Log.WithName("governance")
+
+// SelectsPod reports whether a policy's podSelector matches the given Pod
+// labels. A nil selector selects every Pod. Invalid selectors, which the CRD
+// webhooks reject, select nothing.
+func SelectsPod(selector *metav1.LabelSelector, podLabels map[string]string) bool {
+	return selectorMatches(selector, podLabels, "podSelector")
+}
+
+// SelectsNamespace reports whether a cluster policy's namespaceSelector
+// matches the given namespace labels. A nil selector selects every namespace.
+func SelectsNamespace(selector *metav1.LabelSelector, namespaceLabels map[string]string) bool {
+	return selectorMatches(selector, namespaceLabels, "namespaceSelector")
+}
+
+func selectorMatches(selector *metav1.LabelSelector, set map[string]string, fieldName string) bool {
+	if selector == nil {
+		return true
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		governancelog.Error(err, "Ignoring policy with invalid "+fieldName)
+		return false
+	}
+	return s.Matches(labels.Set(set))
+}
+
+// NamespaceLabels returns the labels of the named namespace.
+func NamespaceLabels(ctx context.Context, c client.Reader, name string) (map[string]string, error) {
+	var namespace corev1.Namespace
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, &namespace); err != nil {
+		return nil, err
+	}
+	return namespace.Labels, nil
+}
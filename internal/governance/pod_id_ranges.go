@@ -0,0 +1,208 @@
+package governance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// idRangeCheck checks user or group IDs against the ranges an IDRangePolicy
+// allows in the namespace of the Pod.
+type idRangeCheck struct {
+	baselineName string
+	// idName is the kind of ID used in messages, "UID" or "GID".
+	idName string
+	ranges []platformv1alpha1.IDRange
+	// remediation tells the developer which IDs to use instead.
+	remediation string
+}
+
+// readsNamespaceIDRanges reports whether any ID range policy of the baseline
+// reads its ranges from a namespace annotation.
+func readsNamespaceIDRanges(spec *platformv1alpha1.SecurityBaselineSpec) bool {
+	for _, policy := range []*platformv1alpha1.IDRangePolicy{spec.RunAsUser, spec.RunAsGroup, spec.FSGroup, spec.SupplementalGroups} {
+		if policy != nil && policy.NamespaceAnnotation != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func newIDRangeCheck(baselineName, idName string, policy *platformv1alpha1.IDRangePolicy,
+	namespaceAnnotations map[string]string) idRangeCheck {
+	check := idRangeCheck{baselineName: baselineName, idName: idName, ranges: policy.Ranges}
+	value, ok := namespaceAnnotations[policy.NamespaceAnnotation]
+	if policy.NamespaceAnnotation != "" && ok {
+		ranges, err := parseIDRanges(value)
+		if err != nil {
+			check.ranges = nil
+			check.remediation = fmt.Sprintf("fix the %s annotation of the namespace: %v", policy.NamespaceAnnotation, err)
+			return check
+		}
+		check.ranges = ranges
+	}
+
+	switch {
+	case len(check.ranges) > 0:
+		check.remediation = fmt.Sprintf("use a %s within %s", idName, formatIDRanges(check.ranges))
+	case policy.NamespaceAnnotation != "":
+		check.remediation = fmt.Sprintf("annotate the namespace with %s to allow %ss", policy.NamespaceAnnotation, idName)
+	default:
+		check.remediation = fmt.Sprintf("no %ss are allowed", idName)
+	}
+	return check
+}
+
+func (c idRangeCheck) allows(id int64) bool {
+	for _, r := range c.ranges {
+		if id >= r.Min && id <= r.Max {
+			return true
+		}
+	}
+	return false
+}
+
+// check returns a violation when id is outside of the allowed ranges.
+func (c idRangeCheck) check(id int64, container string, fldPath *field.Path) []Violation {
+	if c.allows(id) {
+		return nil
+	}
+	return []Violation{{
+		Baseline:    c.baselineName,
+		Container:   container,
+		Field:       fldPath,
+		Message:     fmt.Sprintf("must not run with %s %d", c.idName, id),
+		Remediation: c.remediation,
+	}}
+}
+
+// evaluateContainerIDRange requires every container to run with an allowed
+// runAsUser or runAsGroup. A container's own value takes precedence over the
+// Pod's, so a non-compliant Pod-level value is fine when every container
+// overrides it, and it is reported once however many containers inherit it.
+func evaluateContainerIDRange(pod *corev1.Pod, check idRangeCheck, fieldName string,
+	podID func(*corev1.PodSecurityContext) *int64, containerID func(*corev1.SecurityContext) *int64) []Violation {
+	podPath := field.NewPath("spec", "securityContext", fieldName)
+	var inherited *int64
+	if pod.Spec.SecurityContext != nil {
+		inherited = podID(pod.Spec.SecurityContext)
+	}
+
+	var violations []Violation
+	podChecked := false
+	ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+		if c.SecurityContext != nil && containerID(c.SecurityContext) != nil {
+			violations = append(violations, check.check(*containerID(c.SecurityContext), c.Name, fldPath.Child("securityContext", fieldName))...)
+			return
+		}
+		if inherited == nil {
+			violations = append(violations, Violation{
+				Baseline:  check.baselineName,
+				Container: c.Name,
+				Field:     fldPath.Child("securityContext", fieldName),
+				Message:   "must set " + fieldName,
+				Remediation: fmt.Sprintf("set spec.securityContext.%s or securityContext.%s on the container (%s)",
+					fieldName, fieldName, check.remediation),
+			})
+			return
+		}
+		if !podChecked {
+			podChecked = true
+			violations = append(violations, check.check(*inherited, "", podPath)...)
+		}
+	})
+	return violations
+}
+
+// evaluateFSGroup checks spec.securityContext.fsGroup when the Pod sets it.
+func evaluateFSGroup(pod *corev1.Pod, check idRangeCheck) []Violation {
+	if pod.Spec.SecurityContext == nil || pod.Spec.SecurityContext.FSGroup == nil {
+		return nil
+	}
+	return check.check(*pod.Spec.SecurityContext.FSGroup, "", field.NewPath("spec", "securityContext", "fsGroup"))
+}
+
+// evaluateSupplementalGroups checks every GID in
+// spec.securityContext.supplementalGroups.
+func evaluateSupplementalGroups(pod *corev1.Pod, check idRangeCheck) []Violation {
+	if pod.Spec.SecurityContext == nil {
+		return nil
+	}
+	var violations []Violation
+	groupsPath := field.NewPath("spec", "securityContext", "supplementalGroups")
+	for i, gid := range pod.Spec.SecurityContext.SupplementalGroups {
+		violations = append(violations, check.check(gid, "", groupsPath.Index(i))...)
+	}
+	return violations
+}
+
+// parseIDRanges parses the OpenShift-style value of a namespace ID range
+// annotation: a comma-separated list of "<start>/<size>" or "<min>-<max>"
+// blocks, e.g. "1000680000/10000".
+func parseIDRanges(value string) ([]platformv1alpha1.IDRange, error) {
+	var ranges []platformv1alpha1.IDRange
+	for block := range strings.SplitSeq(value, ",") {
+		block = strings.TrimSpace(block)
+		var r platformv1alpha1.IDRange
+		switch {
+		case strings.Contains(block, "/"):
+			start, size, _ := strings.Cut(block, "/")
+			minID, err := parseID(start)
+			if err != nil {
+				return nil, err
+			}
+			n, err := parseID(size)
+			if err != nil {
+				return nil, err
+			}
+			if n == 0 {
+				return nil, fmt.Errorf("block %q has size 0", block)
+			}
+			r = platformv1alpha1.IDRange{Min: minID, Max: minID + n - 1}
+		case strings.Contains(block, "-"):
+			low, high, _ := strings.Cut(block, "-")
+			minID, err := parseID(low)
+			if err != nil {
+				return nil, err
+			}
+			maxID, err := parseID(high)
+			if err != nil {
+				return nil, err
+			}
+			if minID > maxID {
+				return nil, fmt.Errorf("block %q ends before it starts", block)
+			}
+			r = platformv1alpha1.IDRange{Min: minID, Max: maxID}
+		default:
+			return nil, fmt.Errorf("block %q is neither <start>/<size> nor <min>-<max>", block)
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+func parseID(value string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid ID %q", value)
+	}
+	return id, nil
+}
+
+// formatIDRanges renders ranges as e.g. "1000-1999, 5000".
+func formatIDRanges(ranges []platformv1alpha1.IDRange) string {
+	blocks := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		if r.Min == r.Max {
+			blocks = append(blocks, strconv.FormatInt(r.Min, 10))
+			continue
+		}
+		blocks = append(blocks, fmt.Sprintf("%d-%d", r.Min, r.Max))
+	}
+	return strings.Join(blocks, ", ")
+}
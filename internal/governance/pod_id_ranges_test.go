@@ -0,0 +1,185 @@
+package governance
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+const uidRangeAnnotation = "openshift.io/sa.scc.uid-range"
+
+func TestEvaluateRunAsUserRangeResolvesPodAndContainerPrecedence(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "uids"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			RunAsUser: &platformv1alpha1.IDRangePolicy{Ranges: []platformv1alpha1.IDRange{{Min: 1000, Max: 1999}}},
+		},
+	}
+	runAsUser := func(uid int64) *corev1.SecurityContext { return &corev1.SecurityContext{RunAsUser: ptr.To(uid)} }
+
+	tests := []struct {
+		name  string
+		pod   corev1.PodSpec
+		wants []string
+	}{
+		{
+			name: "pod-level UID is inherited",
+			pod: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{RunAsUser: ptr.To[int64](1500)},
+				Containers:      []corev1.Container{{Name: "app"}, {Name: "sidecar"}},
+			},
+		},
+		{
+			name: "containers override a pod-level UID outside of the range",
+			pod: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{RunAsUser: ptr.To[int64](0)},
+				Containers:      []corev1.Container{{Name: "app", SecurityContext: runAsUser(1000)}},
+			},
+		},
+		{
+			name: "inherited UID outside of the range is reported once at pod level",
+			pod: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{RunAsUser: ptr.To[int64](2000)},
+				Containers:      []corev1.Container{{Name: "app"}, {Name: "sidecar"}},
+			},
+			wants: []string{"spec.securityContext.runAsUser"},
+		},
+		{
+			name: "container UID outside of the range overrides a compliant pod-level UID",
+			pod: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{RunAsUser: ptr.To[int64](1500)},
+				Containers:      []corev1.Container{{Name: "app"}, {Name: "debug", SecurityContext: runAsUser(0)}},
+			},
+			wants: []string{"spec.containers[1].securityContext.runAsUser"},
+		},
+		{
+			name:  "unset UID",
+			pod:   corev1.PodSpec{InitContainers: []corev1.Container{{Name: "init"}}, Containers: []corev1.Container{{Name: "app"}}},
+			wants: []string{"spec.containers[0].securityContext.runAsUser", "spec.initContainers[0].securityContext.runAsUser"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			violations := EvaluateSecurityBaseline(&corev1.Pod{Spec: tt.pod}, baseline, nil)
+			if got := violationFields(violations); !slices.Equal(got, tt.wants) {
+				t.Fatalf("expected violations at %v, got %v", tt.wants, violations)
+			}
+			for _, violation := range violations {
+				if violation.Rule != platformv1alpha1.RuleRunAsUser {
+					t.Fatalf("expected the runAsUser rule, got %q", violation.Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateGroupRanges(t *testing.T) {
+	t.Parallel()
+
+	groups := &platformv1alpha1.IDRangePolicy{Ranges: []platformv1alpha1.IDRange{{Min: 1000, Max: 1999}, {Min: 5000, Max: 5000}}}
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "groups"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			RunAsGroup:         groups,
+			FSGroup:            groups,
+			SupplementalGroups: groups,
+		},
+	}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsGroup:         ptr.To[int64](1000),
+			FSGroup:            ptr.To[int64](0),
+			SupplementalGroups: []int64{5000, 4000},
+		},
+		Containers: []corev1.Container{{Name: "app"}},
+	}}
+
+	violations := EvaluateSecurityBaseline(pod, baseline, nil)
+	wants := []string{"spec.securityContext.fsGroup", "spec.securityContext.supplementalGroups[1]"}
+	if got := violationFields(violations); !slices.Equal(got, wants) {
+		t.Fatalf("expected violations at %v, got %v", wants, violations)
+	}
+	if !strings.Contains(violations[1].String(), "use a GID within 1000-1999, 5000") {
+		t.Fatalf("expected the allowed ranges in the remediation, got %q", violations[1])
+	}
+
+	pod.Spec.SecurityContext.FSGroup = nil
+	pod.Spec.SecurityContext.SupplementalGroups = nil
+	if violations := EvaluateSecurityBaseline(pod, baseline, nil); len(violations) != 0 {
+		t.Fatalf("expected unset fsGroup and supplementalGroups to comply, got %v", violations)
+	}
+}
+
+func TestEvaluateIDRangesFromNamespaceAnnotation(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-uids"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			RunAsUser: &platformv1alpha1.IDRangePolicy{
+				Ranges:              []platformv1alpha1.IDRange{{Min: 1000, Max: 1999}},
+				NamespaceAnnotation: uidRangeAnnotation,
+			},
+		},
+	}
+	pod := func(uid int64) *corev1.Pod {
+		return &corev1.Pod{Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{RunAsUser: ptr.To(uid)},
+			Containers:      []corev1.Container{{Name: "app"}},
+		}}
+	}
+	tenant := map[string]string{uidRangeAnnotation: "1000680000/10000"}
+
+	if violations := EvaluateSecurityBaseline(pod(1500), baseline, nil); len(violations) != 0 {
+		t.Fatalf("expected ranges to apply without the annotation, got %v", violations)
+	}
+	if violations := EvaluateSecurityBaseline(pod(1000689999), baseline, tenant); len(violations) != 0 {
+		t.Fatalf("expected the namespace range to allow its last UID, got %v", violations)
+	}
+	if violations := EvaluateSecurityBaseline(pod(1500), baseline, tenant); len(violations) != 1 {
+		t.Fatalf("expected the namespace range to replace ranges, got %v", violations)
+	}
+
+	invalid := map[string]string{uidRangeAnnotation: "1000680000"}
+	violations := EvaluateSecurityBaseline(pod(1000680000), baseline, invalid)
+	if len(violations) != 1 || !strings.Contains(violations[0].Remediation, "fix the "+uidRangeAnnotation+" annotation") {
+		t.Fatalf("expected a malformed annotation to allow no UIDs, got %v", violations)
+	}
+}
+
+func TestParseIDRanges(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		want    []platformv1alpha1.IDRange
+		wantErr bool
+	}{
+		{value: "1000680000/10000", want: []platformv1alpha1.IDRange{{Min: 1000680000, Max: 1000689999}}},
+		{value: "1000-1999, 5000/1", want: []platformv1alpha1.IDRange{{Min: 1000, Max: 1999}, {Min: 5000, Max: 5000}}},
+		{value: "", wantErr: true},
+		{value: "1000/0", wantErr: true},
+		{value: "2000-1000", wantErr: true},
+		{value: "-5/10", wantErr: true},
+		{value: "1000/abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseIDRanges(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseIDRanges(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Fatalf("parseIDRanges(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
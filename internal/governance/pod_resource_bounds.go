@@ -0,0 +1,183 @@
+package governance
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// hasResourceBounds reports whether the policy bounds the resources of
+// containers.
+func hasResourceBounds(spec *platformv1alpha1.WorkloadPolicySpec) bool {
+	return len(spec.MinRequests) > 0 || len(spec.MaxLimits) > 0 || len(spec.MaxLimitRequestRatio) > 0
+}
+
+// ResourceBoundsPolicies returns the WorkloadPolicies of the namespace and the
+// ClusterWorkloadPolicies selecting it that select the Pod and bound its
+// resources. Unlike defaults, bounds do not depend on merge strategies, so
+// every such policy is returned.
+func ResourceBoundsPolicies(ctx context.Context, c client.Reader, namespace string, pod *corev1.Pod) (
+	[]WorkloadPolicyCandidate, error) {
+	var policies platformv1alpha1.WorkloadPolicyList
+	if err := c.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	var clusterPolicies platformv1alpha1.ClusterWorkloadPolicyList
+	if err := c.List(ctx, &clusterPolicies); err != nil {
+		return nil, err
+	}
+
+	var candidates []WorkloadPolicyCandidate
+	for i, policy := range policies.Items {
+		if hasResourceBounds(&policy.Spec) && SelectsPod(policy.Spec.PodSelector, pod.Labels) {
+			candidates = append(candidates, WorkloadPolicyCandidate{Kind: "WorkloadPolicy", Object: &policies.Items[i], Policy: policy})
+		}
+	}
+
+	clusterPolicies.Items = slices.DeleteFunc(clusterPolicies.Items, func(policy platformv1alpha1.ClusterWorkloadPolicy) bool {
+		return !hasResourceBounds(&policy.Spec.WorkloadPolicySpec) || !SelectsPod(policy.Spec.PodSelector, pod.Labels)
+	})
+	if len(clusterPolicies.Items) == 0 {
+		return candidates, nil
+	}
+	nsLabels, err := NamespaceLabels(ctx, c, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for i, clusterPolicy := range clusterPolicies.Items {
+		if !SelectsNamespace(clusterPolicy.Spec.NamespaceSelector, nsLabels) {
+			continue
+		}
+		candidates = append(candidates, WorkloadPolicyCandidate{
+			Kind:   "ClusterWorkloadPolicy",
+			Object: &clusterPolicies.Items[i],
+			Policy: platformv1alpha1.WorkloadPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterPolicy.Name},
+				Spec:       clusterPolicy.Spec.WorkloadPolicySpec,
+			},
+		})
+	}
+	return candidates, nil
+}
+
+// resourceBounds are the parsed resource bounds of a policy.
+type resourceBounds struct {
+	minRequests, maxLimits, maxRatios corev1.ResourceList
+}
+
+// parseResourceBounds parses the resource bounds of the policy.
+func parseResourceBounds(policyName string, spec *platformv1alpha1.WorkloadPolicySpec) (*resourceBounds, error) {
+	bounds := &resourceBounds{}
+	for _, bound := range []struct {
+		field  string
+		values map[string]string
+		parsed *corev1.ResourceList
+	}{
+		{field: "minRequests", values: spec.MinRequests, parsed: &bounds.minRequests},
+		{field: "maxLimits", values: spec.MaxLimits, parsed: &bounds.maxLimits},
+		{field: "maxLimitRequestRatio", values: spec.MaxLimitRequestRatio, parsed: &bounds.maxRatios},
+	} {
+		*bound.parsed = make(corev1.ResourceList, len(bound.values))
+		for rName, rVal := range bound.values {
+			qty, err := resource.ParseQuantity(rVal)
+			if err != nil {
+				return nil, fmt.Errorf("WorkloadPolicy %s has invalid %s quantity for %s: %q", policyName, bound.field, rName, rVal)
+			}
+			(*bound.parsed)[corev1.ResourceName(rName)] = qty
+		}
+	}
+	return bounds, nil
+}
+
+// EvaluateResourceBounds checks the requests and limits of every container,
+// init container and sidecar of the Pod against the bounds of the policy.
+func EvaluateResourceBounds(pod *corev1.Pod, policyName string, spec *platformv1alpha1.WorkloadPolicySpec) ([]Violation, error) {
+	bounds, err := parseResourceBounds(policyName, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []Violation
+	for _, containers := range []struct {
+		path       *field.Path
+		containers []corev1.Container
+	}{
+		{path: field.NewPath("spec", "containers"), containers: pod.Spec.Containers},
+		{path: field.NewPath("spec", "initContainers"), containers: pod.Spec.InitContainers},
+	} {
+		for i := range containers.containers {
+			violations = append(violations, bounds.evaluate(policyName, &containers.containers[i], containers.path.Index(i).Child("resources"))...)
+		}
+	}
+	return violations, nil
+}
+
+// evaluate checks the resources of a single container, found at path.
+func (b *resourceBounds) evaluate(policyName string, c *corev1.Container, path *field.Path) []Violation {
+	violation := func(field *field.Path, message, remediation string) Violation {
+		return Violation{Baseline: policyName, Container: c.Name, Field: field, Message: message, Remediation: remediation}
+	}
+
+	var violations []Violation
+	for _, name := range slices.Sorted(maps.Keys(b.minRequests)) {
+		minimum := b.minRequests[name]
+		requestPath := path.Child("requests").Key(string(name))
+		request, ok := c.Resources.Requests[name]
+		switch {
+		case !ok:
+			violations = append(violations, violation(requestPath, fmt.Sprintf("must request %s", name),
+				fmt.Sprintf("set %s to at least %s", requestPath, minimum.String())))
+		case request.Cmp(minimum) < 0:
+			violations = append(violations, violation(requestPath,
+				fmt.Sprintf("requests %s of %s, below the minimum of %s", request.String(), name, minimum.String()),
+				fmt.Sprintf("set %s to at least %s", requestPath, minimum.String())))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(b.maxLimits)) {
+		maximum := b.maxLimits[name]
+		limitPath := path.Child("limits").Key(string(name))
+		limit, ok := c.Resources.Limits[name]
+		switch {
+		case !ok:
+			violations = append(violations, violation(limitPath, fmt.Sprintf("must set a %s limit", name),
+				fmt.Sprintf("set %s to at most %s", limitPath, maximum.String())))
+		case limit.Cmp(maximum) > 0:
+			violations = append(violations, violation(limitPath,
+				fmt.Sprintf("limits %s to %s, above the maximum of %s", name, limit.String(), maximum.String()),
+				fmt.Sprintf("set %s to at most %s", limitPath, maximum.String())))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(b.maxRatios)) {
+		limit, ok := c.Resources.Limits[name]
+		if !ok {
+			continue
+		}
+		ratio := b.maxRatios[name]
+		request := c.Resources.Requests[name]
+		if request.Sign() > 0 && limit.AsApproximateFloat64() <= request.AsApproximateFloat64()*ratio.AsApproximateFloat64() {
+			continue
+		}
+
+		requestPath := path.Child("requests").Key(string(name))
+		minimum := resource.NewMilliQuantity(int64(math.Ceil(float64(limit.MilliValue())/ratio.AsApproximateFloat64())), limit.Format)
+		message := fmt.Sprintf("limits %s to %s without requesting it", name, limit.String())
+		if request.Sign() > 0 {
+			message = fmt.Sprintf("limits %s to %s, more than %s times its request of %s", name, limit.String(), ratio.String(), request.String())
+		}
+		violations = append(violations, violation(path.Child("limits").Key(string(name)), message,
+			fmt.Sprintf("set %s to at least %s, or lower the limit", requestPath, minimum.String())))
+	}
+	return violations
+}
@@ -0,0 +1,85 @@
+package governance
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// cpuResources returns the requirements of a container requesting and
+// limiting CPU, leaving out empty quantities.
+func cpuResources(request, limit string) corev1.ResourceRequirements {
+	var resources corev1.ResourceRequirements
+	if request != "" {
+		resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(request)}
+	}
+	if limit != "" {
+		resources.Limits = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(limit)}
+	}
+	return resources
+}
+
+func TestEvaluateResourceBounds(t *testing.T) {
+	t.Parallel()
+
+	spec := &platformv1alpha1.WorkloadPolicySpec{
+		MinRequests:          map[string]string{"cpu": "100m"},
+		MaxLimits:            map[string]string{"cpu": "2"},
+		MaxLimitRequestRatio: map[string]string{"cpu": "4"},
+	}
+
+	tests := []struct {
+		name      string
+		resources corev1.ResourceRequirements
+		wants     []string
+	}{
+		{name: "within bounds", resources: cpuResources("250m", "1")},
+		{name: "request below the minimum", resources: cpuResources("50m", "200m"), wants: []string{"spec.containers[0].resources.requests[cpu]"}},
+		{name: "limit above the maximum", resources: cpuResources("1", "3"), wants: []string{"spec.containers[0].resources.limits[cpu]"}},
+		{name: "limit more than 4 times the request", resources: cpuResources("100m", "500m"), wants: []string{"spec.containers[0].resources.limits[cpu]"}},
+		{
+			name:  "unset request and limit",
+			wants: []string{"spec.containers[0].resources.requests[cpu]", "spec.containers[0].resources.limits[cpu]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Resources: tt.resources}}}}
+			violations, err := EvaluateResourceBounds(pod, "bounds", spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := violationFields(violations); !slices.Equal(got, tt.wants) {
+				t.Fatalf("expected violations at %v, got %v", tt.wants, violations)
+			}
+		})
+	}
+}
+
+func TestEvaluateResourceBoundsChecksInitContainers(t *testing.T) {
+	t.Parallel()
+
+	spec := &platformv1alpha1.WorkloadPolicySpec{MaxLimitRequestRatio: map[string]string{"cpu": "2"}}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "migrate", Resources: cpuResources("", "1")}},
+		Containers:     []corev1.Container{{Name: "app"}},
+	}}
+
+	violations, err := EvaluateResourceBounds(pod, "ratio", spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Field.String() != "spec.initContainers[0].resources.limits[cpu]" {
+		t.Fatalf("expected the init container limit without a request to be reported, got %v", violations)
+	}
+	if !strings.Contains(violations[0].Remediation, "set spec.initContainers[0].resources.requests[cpu] to at least 500m") {
+		t.Fatalf("expected the smallest compliant request in the remediation, got %q", violations[0].Remediation)
+	}
+}
@@ -0,0 +1,203 @@
+package governance
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// defaultAllowedProfileTypes are the seccomp and AppArmor profile types
+// allowed when a policy lists none.
+var defaultAllowedProfileTypes = []string{"RuntimeDefault", "Localhost"}
+
+// securityProfile is the seccomp or AppArmor profile a container runs with,
+// together with the field it was read from.
+type securityProfile struct {
+	profileType string
+	localhost   string
+	// typePath and localhostPath locate the type and the localhost profile
+	// name of the profile in the Pod.
+	typePath      *field.Path
+	localhostPath *field.Path
+	// container is the container setting the profile, or empty when it is
+	// inherited from the Pod.
+	container string
+}
+
+// profileRequirement checks the effective profiles of a Pod against the
+// allowed types and localhost profiles of a policy.
+type profileRequirement struct {
+	// name is the profile kind used in messages, e.g. "seccomp".
+	name string
+	// securityContextField is the securityContext field holding the profile.
+	securityContextField string
+	allowedTypes         []string
+	allowedLocalhost     []string
+	// unsetRemediation tells the developer how to set a profile.
+	unsetRemediation string
+}
+
+// evaluateSeccompPolicy requires every container to run with an allowed
+// seccomp profile. A container's own profile takes precedence over the Pod's,
+// so a non-compliant Pod-level profile is fine when every container
+// overrides it.
+func evaluateSeccompPolicy(pod *corev1.Pod, baselineName string, policy *platformv1alpha1.SeccompPolicy) []Violation {
+	requirement := profileRequirement{
+		name:                 "seccomp",
+		securityContextField: "seccompProfile",
+		allowedLocalhost:     policy.AllowedLocalhostProfiles,
+		unsetRemediation:     "set spec.securityContext.seccompProfile or securityContext.seccompProfile on the container",
+	}
+	for _, t := range policy.AllowedTypes {
+		requirement.allowedTypes = append(requirement.allowedTypes, string(t))
+	}
+
+	var podProfile *securityProfile
+	if sc := pod.Spec.SecurityContext; sc != nil && sc.SeccompProfile != nil {
+		podProfile = seccompProfileAt(sc.SeccompProfile, field.NewPath("spec", "securityContext", "seccompProfile"), "")
+	}
+	return requirement.evaluate(pod, baselineName, podProfile, func(c *corev1.Container, fldPath *field.Path) *securityProfile {
+		if c.SecurityContext == nil || c.SecurityContext.SeccompProfile == nil {
+			return nil
+		}
+		return seccompProfileAt(c.SecurityContext.SeccompProfile, fldPath.Child("securityContext", "seccompProfile"), c.Name)
+	})
+}
+
+func seccompProfileAt(profile *corev1.SeccompProfile, fldPath *field.Path, container string) *securityProfile {
+	return &securityProfile{
+		profileType:   string(profile.Type),
+		localhost:     ptr.Deref(profile.LocalhostProfile, ""),
+		typePath:      fldPath.Child("type"),
+		localhostPath: fldPath.Child("localhostProfile"),
+		container:     container,
+	}
+}
+
+// evaluateAppArmorPolicy requires every container to run with an allowed
+// AppArmor profile. A container's appArmorProfile takes precedence over its
+// legacy annotation, which takes precedence over the Pod's appArmorProfile.
+func evaluateAppArmorPolicy(pod *corev1.Pod, baselineName string, policy *platformv1alpha1.AppArmorPolicy) []Violation {
+	requirement := profileRequirement{
+		name:                 "AppArmor",
+		securityContextField: "appArmorProfile",
+		allowedLocalhost:     policy.AllowedLocalhostProfiles,
+		unsetRemediation:     "set spec.securityContext.appArmorProfile or securityContext.appArmorProfile on the container",
+	}
+	for _, t := range policy.AllowedTypes {
+		requirement.allowedTypes = append(requirement.allowedTypes, string(t))
+	}
+
+	var podProfile *securityProfile
+	if sc := pod.Spec.SecurityContext; sc != nil && sc.AppArmorProfile != nil {
+		podProfile = appArmorProfileAt(sc.AppArmorProfile, field.NewPath("spec", "securityContext", "appArmorProfile"), "")
+	}
+	annotationsPath := field.NewPath("metadata", "annotations")
+	return requirement.evaluate(pod, baselineName, podProfile, func(c *corev1.Container, fldPath *field.Path) *securityProfile {
+		if c.SecurityContext != nil && c.SecurityContext.AppArmorProfile != nil {
+			return appArmorProfileAt(c.SecurityContext.AppArmorProfile, fldPath.Child("securityContext", "appArmorProfile"), c.Name)
+		}
+		key := appArmorAnnotationPrefix + c.Name
+		value, ok := pod.Annotations[key]
+		if !ok {
+			return nil
+		}
+		profile := &securityProfile{
+			profileType:   value,
+			typePath:      annotationsPath.Key(key),
+			localhostPath: annotationsPath.Key(key),
+			container:     c.Name,
+		}
+		switch {
+		case value == corev1.DeprecatedAppArmorBetaProfileRuntimeDefault:
+			profile.profileType = string(corev1.AppArmorProfileTypeRuntimeDefault)
+		case value == corev1.DeprecatedAppArmorBetaProfileNameUnconfined:
+			profile.profileType = string(corev1.AppArmorProfileTypeUnconfined)
+		case strings.HasPrefix(value, corev1.DeprecatedAppArmorBetaProfileNamePrefix):
+			profile.profileType = string(corev1.AppArmorProfileTypeLocalhost)
+			profile.localhost = strings.TrimPrefix(value, corev1.DeprecatedAppArmorBetaProfileNamePrefix)
+		}
+		return profile
+	})
+}
+
+func appArmorProfileAt(profile *corev1.AppArmorProfile, fldPath *field.Path, container string) *securityProfile {
+	return &securityProfile{
+		profileType:   string(profile.Type),
+		localhost:     ptr.Deref(profile.LocalhostProfile, ""),
+		typePath:      fldPath.Child("type"),
+		localhostPath: fldPath.Child("localhostProfile"),
+		container:     container,
+	}
+}
+
+// evaluate resolves the effective profile of every container, its own or the
+// Pod's, and checks it. A non-compliant Pod-level profile is reported once,
+// however many containers inherit it.
+func (r profileRequirement) evaluate(pod *corev1.Pod, baselineName string, podProfile *securityProfile,
+	containerProfile func(c *corev1.Container, fldPath *field.Path) *securityProfile) []Violation {
+	allowedTypes := r.allowedTypes
+	if len(allowedTypes) == 0 {
+		allowedTypes = defaultAllowedProfileTypes
+	}
+
+	var violations []Violation
+	podChecked := false
+	ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+		profile := containerProfile(c, fldPath)
+		if profile == nil {
+			if podProfile == nil {
+				violations = append(violations, Violation{
+					Baseline:    baselineName,
+					Container:   c.Name,
+					Field:       fldPath.Child("securityContext", r.securityContextField),
+					Message:     fmt.Sprintf("must set a %s profile", r.name),
+					Remediation: r.unsetRemediation,
+				})
+				return
+			}
+			if podChecked {
+				return
+			}
+			podChecked = true
+			profile = podProfile
+		}
+		violations = append(violations, r.check(baselineName, profile, allowedTypes)...)
+	})
+	return violations
+}
+
+func (r profileRequirement) check(baselineName string, profile *securityProfile, allowedTypes []string) []Violation {
+	if !slices.Contains(allowedTypes, profile.profileType) {
+		return []Violation{{
+			Baseline:    baselineName,
+			Container:   profile.container,
+			Field:       profile.typePath,
+			Message:     fmt.Sprintf("must not use %s profile %q", r.name, profile.profileType),
+			Remediation: fmt.Sprintf("use one of the %s profile types %v", r.name, allowedTypes),
+		}}
+	}
+	if profile.profileType != "Localhost" || len(r.allowedLocalhost) == 0 {
+		return nil
+	}
+	if slices.ContainsFunc(r.allowedLocalhost, func(pattern string) bool {
+		matched, err := path.Match(pattern, profile.localhost)
+		return err == nil && matched
+	}) {
+		return nil
+	}
+	return []Violation{{
+		Baseline:    baselineName,
+		Container:   profile.container,
+		Field:       profile.localhostPath,
+		Message:     fmt.Sprintf("must not use localhost %s profile %q", r.name, profile.localhost),
+		Remediation: fmt.Sprintf("use one of the localhost %s profiles %q", r.name, r.allowedLocalhost),
+	}}
+}
@@ -0,0 +1,177 @@
+package governance
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+func violationFields(violations []Violation) []string {
+	fields := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		fields = append(fields, violation.Field.String())
+	}
+	return fields
+}
+
+func TestEvaluateSeccompPolicyResolvesPodAndContainerPrecedence(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "seccomp"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			Seccomp: &platformv1alpha1.SeccompPolicy{},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		pod   corev1.PodSpec
+		wants []string
+	}{
+		{
+			name: "pod-level profile is inherited",
+			pod: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}},
+				Containers:      []corev1.Container{{Name: "app"}, {Name: "sidecar"}},
+			},
+		},
+		{
+			name: "containers override an unconfined pod-level profile",
+			pod: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined}},
+				Containers: []corev1.Container{{
+					Name:            "app",
+					SecurityContext: &corev1.SecurityContext{SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}},
+				}},
+			},
+		},
+		{
+			name: "inherited unconfined profile is reported once at pod level",
+			pod: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined}},
+				Containers:      []corev1.Container{{Name: "app"}, {Name: "sidecar"}},
+			},
+			wants: []string{"spec.securityContext.seccompProfile.type"},
+		},
+		{
+			name: "container unconfined overrides a compliant pod-level profile",
+			pod: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}},
+				Containers: []corev1.Container{{Name: "app"}, {
+					Name:            "debug",
+					SecurityContext: &corev1.SecurityContext{SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined}},
+				}},
+			},
+			wants: []string{"spec.containers[1].securityContext.seccompProfile.type"},
+		},
+		{
+			name:  "unset profile",
+			pod:   corev1.PodSpec{InitContainers: []corev1.Container{{Name: "init"}}, Containers: []corev1.Container{{Name: "app"}}},
+			wants: []string{"spec.containers[0].securityContext.seccompProfile", "spec.initContainers[0].securityContext.seccompProfile"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			violations := EvaluateSecurityBaseline(&corev1.Pod{Spec: tt.pod}, baseline, nil)
+			if got := violationFields(violations); !slices.Equal(got, tt.wants) {
+				t.Fatalf("expected violations at %v, got %v", tt.wants, violations)
+			}
+			for _, violation := range violations {
+				if violation.Rule != platformv1alpha1.RuleSeccomp {
+					t.Fatalf("expected the seccomp rule, got %q", violation.Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateSeccompPolicyLocalhostAllowlist(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "seccomp"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			Seccomp: &platformv1alpha1.SeccompPolicy{
+				AllowedTypes:             []corev1.SeccompProfileType{corev1.SeccompProfileTypeLocalhost},
+				AllowedLocalhostProfiles: []string{"profiles/*.json"},
+			},
+		},
+	}
+	localhost := func(name string) *corev1.SecurityContext {
+		return &corev1.SecurityContext{SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeLocalhost, LocalhostProfile: ptr.To(name),
+		}}
+	}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+		{Name: "allowed", SecurityContext: localhost("profiles/audit.json")},
+		{Name: "other", SecurityContext: localhost("custom/audit.json")},
+		{Name: "runtime", SecurityContext: &corev1.SecurityContext{SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}}},
+	}}}
+
+	wants := []string{
+		"spec.containers[1].securityContext.seccompProfile.localhostProfile",
+		"spec.containers[2].securityContext.seccompProfile.type",
+	}
+	if got := violationFields(EvaluateSecurityBaseline(pod, baseline, nil)); !slices.Equal(got, wants) {
+		t.Fatalf("expected violations at %v, got %v", wants, got)
+	}
+}
+
+func TestEvaluateAppArmorPolicyPrecedence(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "apparmor"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			AppArmor: &platformv1alpha1.AppArmorPolicy{AllowedLocalhostProfiles: []string{"k8s-*"}},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			appArmorAnnotationPrefix + "legacy":   "localhost/custom",
+			appArmorAnnotationPrefix + "override": "unconfined",
+		}},
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{AppArmorProfile: &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeUnconfined}},
+			Containers: []corev1.Container{
+				{Name: "inherits"},
+				{Name: "legacy"},
+				{Name: "override", SecurityContext: &corev1.SecurityContext{AppArmorProfile: &corev1.AppArmorProfile{
+					Type: corev1.AppArmorProfileTypeLocalhost, LocalhostProfile: ptr.To("k8s-nginx"),
+				}}},
+			},
+		},
+	}
+
+	wants := []string{
+		"spec.securityContext.appArmorProfile.type",
+		"metadata.annotations[container.apparmor.security.beta.kubernetes.io/legacy]",
+	}
+	if got := violationFields(EvaluateSecurityBaseline(pod, baseline, nil)); !slices.Equal(got, wants) {
+		t.Fatalf("expected violations at %v, got %v", wants, got)
+	}
+}
+
+func TestResolveBaselineRulesProfilePoliciesReplaceProfile(t *testing.T) {
+	t.Parallel()
+
+	rules := resolveBaselineRules(&platformv1alpha1.SecurityBaselineSpec{
+		Profile:  platformv1alpha1.PodSecurityProfileRestricted,
+		Seccomp:  &platformv1alpha1.SeccompPolicy{AllowedTypes: []corev1.SeccompProfileType{corev1.SeccompProfileTypeLocalhost}},
+		AppArmor: &platformv1alpha1.AppArmorPolicy{},
+	})
+	if rules.Seccomp != seccompUnchecked || rules.RestrictAppArmor {
+		t.Fatalf("expected the policies to replace the profile checks, got %+v", rules)
+	}
+	if rules.allowsRuntimeDefaultSeccomp() {
+		t.Fatal("expected RuntimeDefault not to satisfy a Localhost-only seccomp policy")
+	}
+}
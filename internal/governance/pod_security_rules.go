@@ -0,0 +1,435 @@
+package governance
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// capabilityAll is the special capability name that matches every capability.
+const capabilityAll corev1.Capability = "ALL"
+
+// Violation describes a single SecurityBaseline rule that a Pod fails.
+type Violation struct {
+	// Baseline is the name of the policy (a SecurityBaseline or an
+	// ImageVerificationPolicy) that defines the rule.
+	Baseline string
+	// Rule identifies the SecurityBaseline rule, or is empty for other policies.
+	Rule platformv1alpha1.SecurityBaselineRule
+	// Container is the offending container, or empty for Pod-level rules.
+	Container string
+	// Field is the path of the offending field in the Pod.
+	Field *field.Path
+	// Message describes what is wrong.
+	Message string
+	// Remediation tells the developer how to fix it.
+	Remediation string
+	// Exception is the name of the PolicyException exempting the Pod from the
+	// rule, or empty when the violation applies.
+	Exception string
+}
+
+// String renders the violation as a single human readable line.
+func (v Violation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s]", v.Baseline)
+	if v.Container != "" {
+		fmt.Fprintf(&b, " container %q:", v.Container)
+	}
+	fmt.Fprintf(&b, " %s: %s", v.Field.String(), v.Message)
+	if v.Remediation != "" {
+		fmt.Fprintf(&b, " (%s)", v.Remediation)
+	}
+	if v.Exception != "" {
+		fmt.Fprintf(&b, " [exempted by PolicyException %s]", v.Exception)
+	}
+	return b.String()
+}
+
+// ContainerVisitor is invoked for every container of a Pod with the path of
+// the container within the Pod spec.
+type ContainerVisitor func(c *corev1.Container, fldPath *field.Path)
+
+// ForEachContainer visits the regular, init and ephemeral containers of the
+// Pod. Ephemeral containers are visited as Containers, whose fields they share.
+func ForEachContainer(pod *corev1.Pod, visit ContainerVisitor) {
+	specPath := field.NewPath("spec")
+	for i := range pod.Spec.Containers {
+		visit(&pod.Spec.Containers[i], specPath.Child("containers").Index(i))
+	}
+	for i := range pod.Spec.InitContainers {
+		visit(&pod.Spec.InitContainers[i], specPath.Child("initContainers").Index(i))
+	}
+	for i := range pod.Spec.EphemeralContainers {
+		visit((*corev1.Container)(&pod.Spec.EphemeralContainers[i].EphemeralContainerCommon),
+			specPath.Child("ephemeralContainers").Index(i))
+	}
+}
+
+// EvaluateSecurityBaseline returns every rule of the baseline that the Pod
+// violates. An empty result means the Pod is compliant. namespaceAnnotations
+// are the annotations of the Pod's namespace, which may hold the ID ranges
+// allowed in it.
+func EvaluateSecurityBaseline(pod *corev1.Pod, baseline *platformv1alpha1.SecurityBaseline,
+	namespaceAnnotations map[string]string) []Violation {
+	rules := resolveBaselineRules(&baseline.Spec)
+	name := baseline.Name
+
+	var violations []Violation
+
+	if rules.RunAsNonRoot {
+		violations = append(violations, withRule(platformv1alpha1.RuleRunAsNonRoot, evaluateRunAsNonRoot(pod, name))...)
+	}
+
+	if rules.ReadOnlyRootFilesystem {
+		ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+			if c.SecurityContext == nil || c.SecurityContext.ReadOnlyRootFilesystem == nil || !*c.SecurityContext.ReadOnlyRootFilesystem {
+				violations = append(violations, Violation{
+					Baseline:    name,
+					Rule:        platformv1alpha1.RuleReadOnlyRootFilesystem,
+					Container:   c.Name,
+					Field:       fldPath.Child("securityContext", "readOnlyRootFilesystem"),
+					Message:     "must have a read-only root filesystem",
+					Remediation: "set securityContext.readOnlyRootFilesystem: true",
+				})
+			}
+		})
+	}
+
+	if rules.DisallowPrivilegeEscalation {
+		ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+			if c.SecurityContext == nil || c.SecurityContext.AllowPrivilegeEscalation == nil || *c.SecurityContext.AllowPrivilegeEscalation {
+				violations = append(violations, Violation{
+					Baseline:    name,
+					Rule:        platformv1alpha1.RuleDisallowPrivilegeEscalation,
+					Container:   c.Name,
+					Field:       fldPath.Child("securityContext", "allowPrivilegeEscalation"),
+					Message:     "must not allow privilege escalation",
+					Remediation: "set securityContext.allowPrivilegeEscalation: false",
+				})
+			}
+		})
+	}
+
+	if rules.DisallowPrivileged {
+		ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+			if c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
+				violations = append(violations, Violation{
+					Baseline:    name,
+					Rule:        platformv1alpha1.RuleDisallowPrivileged,
+					Container:   c.Name,
+					Field:       fldPath.Child("securityContext", "privileged"),
+					Message:     "must not run as privileged",
+					Remediation: "remove securityContext.privileged or set it to false",
+				})
+			}
+		})
+	}
+
+	if rules.Capabilities != nil {
+		violations = append(violations, withRule(platformv1alpha1.RuleCapabilities, evaluateCapabilities(pod, name, rules.Capabilities))...)
+	}
+
+	if rules.DisallowHostNamespaces {
+		violations = append(violations, withRule(platformv1alpha1.RuleDisallowHostNamespaces, evaluateHostNamespaces(pod, name))...)
+	}
+
+	if rules.DisallowHostPorts {
+		ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+			for i, port := range c.Ports {
+				if port.HostPort == 0 {
+					continue
+				}
+				violations = append(violations, Violation{
+					Baseline:    name,
+					Rule:        platformv1alpha1.RuleDisallowHostPorts,
+					Container:   c.Name,
+					Field:       fldPath.Child("ports").Index(i).Child("hostPort"),
+					Message:     fmt.Sprintf("must not use host port %d", port.HostPort),
+					Remediation: "remove hostPort and expose the container through a Service",
+				})
+			}
+		})
+	}
+
+	if rules.HostPath != nil {
+		violations = append(violations, withRule(platformv1alpha1.RuleHostPath, evaluateHostPath(pod, name, rules.HostPath))...)
+	}
+
+	if rules.SeccompPolicy != nil {
+		violations = append(violations, withRule(platformv1alpha1.RuleSeccomp, evaluateSeccompPolicy(pod, name, rules.SeccompPolicy))...)
+	}
+
+	if rules.AppArmorPolicy != nil {
+		violations = append(violations, withRule(platformv1alpha1.RuleAppArmor, evaluateAppArmorPolicy(pod, name, rules.AppArmorPolicy))...)
+	}
+
+	if rules.RunAsUserRanges != nil {
+		check := newIDRangeCheck(name, "UID", rules.RunAsUserRanges, namespaceAnnotations)
+		violations = append(violations, withRule(platformv1alpha1.RuleRunAsUser, evaluateContainerIDRange(pod, check, "runAsUser",
+			func(sc *corev1.PodSecurityContext) *int64 { return sc.RunAsUser },
+			func(sc *corev1.SecurityContext) *int64 { return sc.RunAsUser }))...)
+	}
+
+	if rules.RunAsGroupRanges != nil {
+		check := newIDRangeCheck(name, "GID", rules.RunAsGroupRanges, namespaceAnnotations)
+		violations = append(violations, withRule(platformv1alpha1.RuleRunAsGroup, evaluateContainerIDRange(pod, check, "runAsGroup",
+			func(sc *corev1.PodSecurityContext) *int64 { return sc.RunAsGroup },
+			func(sc *corev1.SecurityContext) *int64 { return sc.RunAsGroup }))...)
+	}
+
+	if rules.FSGroupRanges != nil {
+		check := newIDRangeCheck(name, "GID", rules.FSGroupRanges, namespaceAnnotations)
+		violations = append(violations, withRule(platformv1alpha1.RuleFSGroup, evaluateFSGroup(pod, check))...)
+	}
+
+	if rules.SupplementalGroupsRanges != nil {
+		check := newIDRangeCheck(name, "GID", rules.SupplementalGroupsRanges, namespaceAnnotations)
+		violations = append(violations, withRule(platformv1alpha1.RuleSupplementalGroups, evaluateSupplementalGroups(pod, check))...)
+	}
+
+	violations = append(violations, evaluatePodSecurityStandards(pod, name, &rules)...)
+
+	if baseline.Spec.ServiceAccounts != nil {
+		violations = append(violations, withRule(platformv1alpha1.RuleServiceAccounts,
+			evaluateServiceAccounts(pod, name, baseline.Spec.ServiceAccounts))...)
+	}
+
+	if baseline.Spec.Images != nil {
+		violations = append(violations, withRule(platformv1alpha1.RuleImages, evaluateImages(pod, name, baseline.Spec.Images))...)
+	}
+
+	return violations
+}
+
+// withRule tags violations with the baseline rule that produced them.
+func withRule(rule platformv1alpha1.SecurityBaselineRule, violations []Violation) []Violation {
+	for i := range violations {
+		violations[i].Rule = rule
+	}
+	return violations
+}
+
+// evaluateRunAsNonRoot requires runAsNonRoot: true either at Pod level or on
+// every container, and forbids containers from explicitly setting it to false.
+func evaluateRunAsNonRoot(pod *corev1.Pod, baselineName string) []Violation {
+	var violations []Violation
+	podPath := field.NewPath("spec", "securityContext", "runAsNonRoot")
+
+	podLevel := pod.Spec.SecurityContext != nil && pod.Spec.SecurityContext.RunAsNonRoot != nil && *pod.Spec.SecurityContext.RunAsNonRoot
+	podExplicitlyFalse := pod.Spec.SecurityContext != nil && pod.Spec.SecurityContext.RunAsNonRoot != nil && !*pod.Spec.SecurityContext.RunAsNonRoot
+
+	var implicit []string
+	containers := 0
+	ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+		containers++
+		if c.SecurityContext == nil || c.SecurityContext.RunAsNonRoot == nil {
+			if !podLevel {
+				implicit = append(implicit, c.Name)
+			}
+			return
+		}
+		if !*c.SecurityContext.RunAsNonRoot {
+			violations = append(violations, Violation{
+				Baseline:    baselineName,
+				Container:   c.Name,
+				Field:       fldPath.Child("securityContext", "runAsNonRoot"),
+				Message:     "must not set runAsNonRoot to false",
+				Remediation: "set securityContext.runAsNonRoot: true or remove it",
+			})
+		}
+	})
+
+	switch {
+	case podExplicitlyFalse:
+		violations = append([]Violation{{
+			Baseline:    baselineName,
+			Field:       podPath,
+			Message:     "must run as non-root",
+			Remediation: "set spec.securityContext.runAsNonRoot: true",
+		}}, violations...)
+	case len(implicit) > 0 || (containers == 0 && !podLevel):
+		message := "must run as non-root"
+		if len(implicit) > 0 && len(implicit) < containers {
+			message = fmt.Sprintf("must run as non-root: containers %q do not set runAsNonRoot", implicit)
+		}
+		violations = append([]Violation{{
+			Baseline:    baselineName,
+			Field:       podPath,
+			Message:     message,
+			Remediation: "set spec.securityContext.runAsNonRoot: true or set securityContext.runAsNonRoot: true on every container",
+		}}, violations...)
+	}
+
+	return violations
+}
+
+func evaluateImages(pod *corev1.Pod, baselineName string, policy *platformv1alpha1.ImagePolicy) []Violation {
+	var violations []Violation
+	ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+		ref := ParseImageReference(c.Image)
+		imagePath := fldPath.Child("image")
+
+		if len(policy.AllowedRegistries) > 0 && !slices.ContainsFunc(policy.AllowedRegistries, ref.HasRepositoryPrefix) {
+			violations = append(violations, Violation{
+				Baseline:    baselineName,
+				Container:   c.Name,
+				Field:       imagePath,
+				Message:     fmt.Sprintf("image %q is not from an allowed registry", c.Image),
+				Remediation: fmt.Sprintf("use an image from one of %v", policy.AllowedRegistries),
+			})
+		}
+
+		if policy.DisallowLatestTag && ref.Digest == "" && (ref.Tag == "" || ref.Tag == "latest") {
+			violations = append(violations, Violation{
+				Baseline:    baselineName,
+				Container:   c.Name,
+				Field:       imagePath,
+				Message:     fmt.Sprintf("image %q must not use the latest tag or be untagged", c.Image),
+				Remediation: "pin the image to an explicit version tag or digest",
+			})
+		}
+
+		if policy.RequireDigest && !strings.HasPrefix(ref.Digest, "sha256:") {
+			violations = append(violations, Violation{
+				Baseline:    baselineName,
+				Container:   c.Name,
+				Field:       imagePath,
+				Message:     fmt.Sprintf("image %q must be pinned by digest", c.Image),
+				Remediation: "reference the image as <name>@sha256:<digest>",
+			})
+		}
+	})
+	return violations
+}
+
+func evaluateHostNamespaces(pod *corev1.Pod, baselineName string) []Violation {
+	var violations []Violation
+	specPath := field.NewPath("spec")
+	hostNamespaces := []struct {
+		name    string
+		enabled bool
+	}{
+		{name: "hostNetwork", enabled: pod.Spec.HostNetwork},
+		{name: "hostPID", enabled: pod.Spec.HostPID},
+		{name: "hostIPC", enabled: pod.Spec.HostIPC},
+	}
+	for _, ns := range hostNamespaces {
+		if !ns.enabled {
+			continue
+		}
+		violations = append(violations, Violation{
+			Baseline:    baselineName,
+			Field:       specPath.Child(ns.name),
+			Message:     "must not share the host namespace",
+			Remediation: fmt.Sprintf("remove spec.%s or set it to false", ns.name),
+		})
+	}
+	return violations
+}
+
+func evaluateHostPath(pod *corev1.Pod, baselineName string, policy *platformv1alpha1.HostPathPolicy) []Violation {
+	var violations []Violation
+	allowedReadOnly := make(map[string]bool)
+	volumesPath := field.NewPath("spec", "volumes")
+	for i, volume := range pod.Spec.Volumes {
+		if volume.HostPath == nil {
+			continue
+		}
+		if hostPathAllowed(volume.HostPath.Path, policy.AllowedReadOnlyPathPrefixes) {
+			allowedReadOnly[volume.Name] = true
+			continue
+		}
+		remediation := "use a non-hostPath volume type such as emptyDir or a PersistentVolumeClaim"
+		if len(policy.AllowedReadOnlyPathPrefixes) > 0 {
+			remediation = fmt.Sprintf("%s, or mount a path under %v read-only", remediation, policy.AllowedReadOnlyPathPrefixes)
+		}
+		violations = append(violations, Violation{
+			Baseline:    baselineName,
+			Field:       volumesPath.Index(i).Child("hostPath", "path"),
+			Message:     fmt.Sprintf("must not use hostPath volume %q (%s)", volume.Name, volume.HostPath.Path),
+			Remediation: remediation,
+		})
+	}
+
+	if len(allowedReadOnly) == 0 {
+		return violations
+	}
+	ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+		for i, mount := range c.VolumeMounts {
+			if !allowedReadOnly[mount.Name] || mount.ReadOnly {
+				continue
+			}
+			violations = append(violations, Violation{
+				Baseline:    baselineName,
+				Container:   c.Name,
+				Field:       fldPath.Child("volumeMounts").Index(i).Child("readOnly"),
+				Message:     fmt.Sprintf("must mount hostPath volume %q read-only", mount.Name),
+				Remediation: "set readOnly: true on the volume mount",
+			})
+		}
+	})
+	return violations
+}
+
+// hostPathAllowed reports whether hostPath is equal to or nested under one of
+// the allowed prefixes. Matching is done on whole path segments, so /var/log
+// allows /var/log/pods but not /var/logs.
+func hostPathAllowed(hostPath string, prefixes []string) bool {
+	cleaned := path.Clean(hostPath)
+	for _, prefix := range prefixes {
+		prefix = path.Clean(prefix)
+		if cleaned == prefix || prefix == "/" || strings.HasPrefix(cleaned, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func evaluateCapabilities(pod *corev1.Pod, baselineName string, policy *platformv1alpha1.CapabilitiesPolicy) []Violation {
+	var violations []Violation
+	ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+		var caps *corev1.Capabilities
+		if c.SecurityContext != nil {
+			caps = c.SecurityContext.Capabilities
+		}
+		capsPath := fldPath.Child("securityContext", "capabilities")
+
+		if policy.RequireDropAll && (caps == nil || !slices.Contains(caps.Drop, capabilityAll)) {
+			violations = append(violations, Violation{
+				Baseline:    baselineName,
+				Container:   c.Name,
+				Field:       capsPath.Child("drop"),
+				Message:     "must drop ALL capabilities",
+				Remediation: "add ALL to securityContext.capabilities.drop",
+			})
+		}
+
+		if caps == nil {
+			return
+		}
+		for i, capability := range caps.Add {
+			if slices.Contains(policy.AllowedAdd, capability) {
+				continue
+			}
+			remediation := "remove the capability from securityContext.capabilities.add"
+			if len(policy.AllowedAdd) > 0 {
+				remediation = fmt.Sprintf("%s; allowed capabilities are %v", remediation, policy.AllowedAdd)
+			}
+			violations = append(violations, Violation{
+				Baseline:    baselineName,
+				Container:   c.Name,
+				Field:       capsPath.Child("add").Index(i),
+				Message:     fmt.Sprintf("must not add capability %s", capability),
+				Remediation: remediation,
+			})
+		}
+	})
+	return violations
+}
@@ -0,0 +1,569 @@
+package governance
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+func TestEvaluateSecurityBaselineCompliantPodHasNoViolations(t *testing.T) {
+	t.Parallel()
+
+	runAsNonRoot := true
+	readOnly := true
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			RunAsNonRoot:           ptr.To(true),
+			ReadOnlyRootFilesystem: ptr.To(true),
+		},
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{RunAsNonRoot: &runAsNonRoot},
+			Containers: []corev1.Container{
+				{Name: "app", SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: &readOnly}},
+			},
+		},
+	}
+
+	if violations := EvaluateSecurityBaseline(pod, baseline, nil); len(violations) != 0 {
+		t.Fatalf("expected no violations, got %v", violations)
+	}
+}
+
+func TestEvaluateSecurityBaselineExplicitFalseReadOnlyIsViolation(t *testing.T) {
+	t.Parallel()
+
+	readOnly := false
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{ReadOnlyRootFilesystem: ptr.To(true)},
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: &readOnly}},
+			},
+		},
+	}
+
+	violations := EvaluateSecurityBaseline(pod, baseline, nil)
+	if len(violations) != 1 {
+		t.Fatalf("expected exactly one violation, got %v", violations)
+	}
+	if violations[0].Container != "app" {
+		t.Fatalf("expected violation for container app, got %q", violations[0].Container)
+	}
+}
+
+func TestViolationStringIncludesAllDetails(t *testing.T) {
+	t.Parallel()
+
+	violation := Violation{
+		Baseline:    "baseline",
+		Container:   "app",
+		Field:       field.NewPath("spec", "containers").Index(0).Child("securityContext", "readOnlyRootFilesystem"),
+		Message:     "must have a read-only root filesystem",
+		Remediation: "set securityContext.readOnlyRootFilesystem: true",
+	}
+
+	rendered := violation.String()
+	for _, fragment := range []string{
+		"[baseline]",
+		`container "app"`,
+		"spec.containers[0].securityContext.readOnlyRootFilesystem",
+		"must have a read-only root filesystem",
+		"set securityContext.readOnlyRootFilesystem: true",
+	} {
+		if !strings.Contains(rendered, fragment) {
+			t.Fatalf("expected %q to contain %q", rendered, fragment)
+		}
+	}
+}
+
+func TestEvaluateSecurityBaselinePrivilegeEscalation(t *testing.T) {
+	t.Parallel()
+
+	disallow := false
+	allow := true
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{DisallowPrivilegeEscalation: ptr.To(true)},
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "compliant", SecurityContext: &corev1.SecurityContext{AllowPrivilegeEscalation: &disallow}},
+				{Name: "explicit", SecurityContext: &corev1.SecurityContext{AllowPrivilegeEscalation: &allow}},
+				{Name: "unset"},
+			},
+		},
+	}
+
+	violations := EvaluateSecurityBaseline(pod, baseline, nil)
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations, got %v", violations)
+	}
+	if violations[0].Container != "explicit" || violations[1].Container != "unset" {
+		t.Fatalf("unexpected offending containers: %v", violations)
+	}
+}
+
+func TestEvaluateSecurityBaselinePrivileged(t *testing.T) {
+	t.Parallel()
+
+	privileged := true
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{DisallowPrivileged: ptr.To(true)},
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}},
+			InitContainers: []corev1.Container{
+				{Name: "init", SecurityContext: &corev1.SecurityContext{Privileged: &privileged}},
+			},
+		},
+	}
+
+	violations := EvaluateSecurityBaseline(pod, baseline, nil)
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %v", violations)
+	}
+	if got := violations[0].Field.String(); got != "spec.initContainers[0].securityContext.privileged" {
+		t.Fatalf("unexpected field path %q", got)
+	}
+}
+
+func TestEvaluateSecurityBaselineCapabilities(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			Capabilities: &platformv1alpha1.CapabilitiesPolicy{
+				RequireDropAll: true,
+				AllowedAdd:     []corev1.Capability{"NET_BIND_SERVICE"},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "compliant",
+					SecurityContext: &corev1.SecurityContext{Capabilities: &corev1.Capabilities{
+						Drop: []corev1.Capability{"ALL"},
+						Add:  []corev1.Capability{"NET_BIND_SERVICE"},
+					}},
+				},
+				{
+					Name: "violator",
+					SecurityContext: &corev1.SecurityContext{Capabilities: &corev1.Capabilities{
+						Add: []corev1.Capability{"NET_BIND_SERVICE", "SYS_ADMIN"},
+					}},
+				},
+			},
+		},
+	}
+
+	violations := EvaluateSecurityBaseline(pod, baseline, nil)
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations, got %v", violations)
+	}
+	if got := violations[0].Field.String(); got != "spec.containers[1].securityContext.capabilities.drop" {
+		t.Fatalf("unexpected field path %q", got)
+	}
+	if got := violations[1].Field.String(); got != "spec.containers[1].securityContext.capabilities.add[1]" {
+		t.Fatalf("unexpected field path %q", got)
+	}
+	if !strings.Contains(violations[1].Message, "SYS_ADMIN") {
+		t.Fatalf("expected violation to name SYS_ADMIN, got %q", violations[1].Message)
+	}
+}
+
+func TestEvaluateSecurityBaselineEmptyAllowedAddForbidsAllAdds(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			Capabilities: &platformv1alpha1.CapabilitiesPolicy{},
+		},
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					SecurityContext: &corev1.SecurityContext{Capabilities: &corev1.Capabilities{
+						Add: []corev1.Capability{"NET_BIND_SERVICE"},
+					}},
+				},
+			},
+		},
+	}
+
+	if violations := EvaluateSecurityBaseline(pod, baseline, nil); len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %v", violations)
+	}
+}
+
+func TestEvaluateSecurityBaselineHostNamespaces(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{DisallowHostNamespaces: ptr.To(true)},
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			HostNetwork: true,
+			HostPID:     true,
+		},
+	}
+
+	violations := EvaluateSecurityBaseline(pod, baseline, nil)
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations, got %v", violations)
+	}
+	if violations[0].Field.String() != "spec.hostNetwork" || violations[1].Field.String() != "spec.hostPID" {
+		t.Fatalf("unexpected field paths: %v", violations)
+	}
+}
+
+func TestEvaluateSecurityBaselineHostPorts(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{DisallowHostPorts: ptr.To(true)},
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					Ports: []corev1.ContainerPort{
+						{ContainerPort: 8080},
+						{ContainerPort: 9090, HostPort: 9090},
+					},
+				},
+			},
+		},
+	}
+
+	violations := EvaluateSecurityBaseline(pod, baseline, nil)
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %v", violations)
+	}
+	if got := violations[0].Field.String(); got != "spec.containers[0].ports[1].hostPort" {
+		t.Fatalf("unexpected field path %q", got)
+	}
+}
+
+func TestEvaluateSecurityBaselineHostPath(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			HostPath: &platformv1alpha1.HostPathPolicy{
+				AllowedReadOnlyPathPrefixes: []string{"/var/log"},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{Name: "logs", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/log/pods"}}},
+				{Name: "docker", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/run/docker.sock"}}},
+				{Name: "logs-lookalike", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/logs"}}},
+				{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			},
+			Containers: []corev1.Container{
+				{
+					Name: "reader",
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "logs", MountPath: "/logs", ReadOnly: true},
+						{Name: "scratch", MountPath: "/tmp"},
+					},
+				},
+				{
+					Name:         "writer",
+					VolumeMounts: []corev1.VolumeMount{{Name: "logs", MountPath: "/logs"}},
+				},
+			},
+		},
+	}
+
+	violations := EvaluateSecurityBaseline(pod, baseline, nil)
+	if len(violations) != 3 {
+		t.Fatalf("expected 3 violations, got %v", violations)
+	}
+	expectedFields := []string{
+		"spec.volumes[1].hostPath.path",
+		"spec.volumes[2].hostPath.path",
+		"spec.containers[1].volumeMounts[0].readOnly",
+	}
+	for i, violation := range violations {
+		if got := violation.Field.String(); got != expectedFields[i] {
+			t.Fatalf("expected violation %d at %q, got %q", i, expectedFields[i], got)
+		}
+	}
+}
+
+func TestHostPathAllowedMatchesWholeSegments(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		path     string
+		prefixes []string
+		allowed  bool
+	}{
+		{path: "/var/log", prefixes: []string{"/var/log"}, allowed: true},
+		{path: "/var/log/pods", prefixes: []string{"/var/log/"}, allowed: true},
+		{path: "/var/logs", prefixes: []string{"/var/log"}, allowed: false},
+		{path: "/var/log/../run", prefixes: []string{"/var/log"}, allowed: false},
+		{path: "/etc", prefixes: nil, allowed: false},
+	}
+	for _, tc := range cases {
+		if got := hostPathAllowed(tc.path, tc.prefixes); got != tc.allowed {
+			t.Fatalf("hostPathAllowed(%q, %v) = %v, want %v", tc.path, tc.prefixes, got, tc.allowed)
+		}
+	}
+}
+
+func TestEvaluateSecurityBaselineImages(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			Images: &platformv1alpha1.ImagePolicy{
+				AllowedRegistries: []string{"registry.example.com", "ghcr.io/my-org"},
+				DisallowLatestTag: true,
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "ok", Image: "ghcr.io/my-org/app:1.0"},
+				{Name: "hub", Image: "nginx:1.27"},
+			},
+			InitContainers: []corev1.Container{
+				{Name: "init", Image: "registry.example.com/tools/init"},
+			},
+			EphemeralContainers: []corev1.EphemeralContainer{
+				{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Image: "registry.example.com/debug:latest"}},
+			},
+		},
+	}
+
+	violations := EvaluateSecurityBaseline(pod, baseline, nil)
+	want := []string{
+		"spec.containers[1].image",
+		"spec.initContainers[0].image",
+		"spec.ephemeralContainers[0].image",
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("expected %d violations, got %v", len(want), violations)
+	}
+	for i, v := range violations {
+		if v.Field.String() != want[i] {
+			t.Fatalf("violation %d: expected field %s, got %s", i, want[i], v.Field)
+		}
+	}
+	if !strings.Contains(violations[0].Message, `"nginx:1.27"`) {
+		t.Fatalf("expected violation to name the image, got %q", violations[0].Message)
+	}
+}
+
+func TestEvaluateSecurityBaselineRequireDigest(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			Images: &platformv1alpha1.ImagePolicy{RequireDigest: true, DisallowLatestTag: true},
+		},
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "pinned", Image: "ghcr.io/org/app@sha256:0123456789abcdef"},
+				{Name: "tagged", Image: "ghcr.io/org/app:1.0"},
+			},
+		},
+	}
+
+	violations := EvaluateSecurityBaseline(pod, baseline, nil)
+	if len(violations) != 1 || violations[0].Container != "tagged" {
+		t.Fatalf("expected a single digest violation for container tagged, got %v", violations)
+	}
+}
+
+func TestResolveBaselineRulesRestrictedProfile(t *testing.T) {
+	t.Parallel()
+
+	rules := resolveBaselineRules(&platformv1alpha1.SecurityBaselineSpec{
+		Profile: platformv1alpha1.PodSecurityProfileRestricted,
+	})
+	if !rules.RunAsNonRoot || !rules.DisallowPrivilegeEscalation || !rules.DisallowPrivileged ||
+		!rules.DisallowHostNamespaces || !rules.DisallowHostPorts || !rules.RestrictVolumeTypes ||
+		!rules.DisallowRootUser || rules.Seccomp != seccompRequired {
+		t.Fatalf("expected restricted profile to enable all restricted checks, got %+v", rules)
+	}
+	if rules.Capabilities == nil || !rules.Capabilities.RequireDropAll {
+		t.Fatalf("expected restricted profile to require dropping ALL capabilities")
+	}
+	if rules.ReadOnlyRootFilesystem {
+		t.Fatalf("expected readOnlyRootFilesystem not to be part of the restricted profile")
+	}
+}
+
+func TestResolveBaselineRulesFieldsRelaxAndTightenProfile(t *testing.T) {
+	t.Parallel()
+
+	rules := resolveBaselineRules(&platformv1alpha1.SecurityBaselineSpec{
+		Profile:                     platformv1alpha1.PodSecurityProfileBaseline,
+		DisallowHostPorts:           ptr.To(false),
+		DisallowPrivilegeEscalation: ptr.To(true),
+		HostPath: &platformv1alpha1.HostPathPolicy{
+			AllowedReadOnlyPathPrefixes: []string{"/var/log"},
+		},
+	})
+	if rules.DisallowHostPorts {
+		t.Fatalf("expected disallowHostPorts: false to relax the profile")
+	}
+	if !rules.DisallowPrivilegeEscalation {
+		t.Fatalf("expected disallowPrivilegeEscalation: true to tighten the profile")
+	}
+	if !rules.DisallowPrivileged {
+		t.Fatalf("expected unset disallowPrivileged to inherit the profile")
+	}
+	if len(rules.HostPath.AllowedReadOnlyPathPrefixes) != 1 {
+		t.Fatalf("expected explicit hostPath policy to replace the profile's")
+	}
+}
+
+func TestEvaluateSecurityBaselineRestrictedProfile(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			Profile: platformv1alpha1.PodSecurityProfileRestricted,
+		},
+	}
+	compliant := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot:   ptr.To(true),
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					SecurityContext: &corev1.SecurityContext{
+						AllowPrivilegeEscalation: ptr.To(false),
+						Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			},
+		},
+	}
+	if violations := EvaluateSecurityBaseline(compliant, baseline, nil); len(violations) != 0 {
+		t.Fatalf("expected restricted-compliant pod to pass, got %v", violations)
+	}
+
+	violating := compliant.DeepCopy()
+	violating.Spec.SecurityContext.RunAsUser = ptr.To[int64](0)
+	violating.Spec.SecurityContext.Sysctls = []corev1.Sysctl{{Name: "kernel.msgmax", Value: "65536"}}
+	violating.Spec.Volumes = append(violating.Spec.Volumes, corev1.Volume{
+		Name:         "nfs",
+		VolumeSource: corev1.VolumeSource{NFS: &corev1.NFSVolumeSource{Server: "nfs", Path: "/"}},
+	})
+	violating.Spec.Containers[0].SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined}
+
+	expectedFields := []string{
+		"spec.securityContext.sysctls[0].name",
+		"spec.containers[0].securityContext.seccompProfile.type",
+		"spec.volumes[1].nfs",
+		"spec.securityContext.runAsUser",
+	}
+	violations := EvaluateSecurityBaseline(violating, baseline, nil)
+	if len(violations) != len(expectedFields) {
+		t.Fatalf("expected %d violations, got %v", len(expectedFields), violations)
+	}
+	for i, violation := range violations {
+		if got := violation.Field.String(); got != expectedFields[i] {
+			t.Fatalf("expected violation %d at %q, got %q", i, expectedFields[i], got)
+		}
+	}
+}
+
+func TestEvaluateSecurityBaselineProfileVersionGatesChecks(t *testing.T) {
+	t.Parallel()
+
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{
+				Sysctls: []corev1.Sysctl{{Name: "net.ipv4.tcp_keepalive_time", Value: "600"}},
+			},
+		},
+	}
+	newer := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			Profile:        platformv1alpha1.PodSecurityProfileBaseline,
+			ProfileVersion: "v1.30",
+		},
+	}
+	if violations := EvaluateSecurityBaseline(pod, newer, nil); len(violations) != 0 {
+		t.Fatalf("expected tcp_keepalive_time to be allowed at v1.30, got %v", violations)
+	}
+
+	older := newer.DeepCopy()
+	older.Spec.ProfileVersion = "v1.28"
+	if violations := EvaluateSecurityBaseline(pod, older, nil); len(violations) != 1 {
+		t.Fatalf("expected tcp_keepalive_time to be rejected at v1.28, got %v", violations)
+	}
+}
+
+func TestEvaluateRunAsNonRootAcceptsContainerLevelSetting(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{RunAsNonRoot: ptr.To(true)},
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", SecurityContext: &corev1.SecurityContext{RunAsNonRoot: ptr.To(true)}},
+			},
+		},
+	}
+	if violations := EvaluateSecurityBaseline(pod, baseline, nil); len(violations) != 0 {
+		t.Fatalf("expected container-level runAsNonRoot to satisfy the rule, got %v", violations)
+	}
+
+	pod.Spec.SecurityContext = &corev1.PodSecurityContext{RunAsNonRoot: ptr.To(true)}
+	pod.Spec.Containers[0].SecurityContext.RunAsNonRoot = ptr.To(false)
+	violations := EvaluateSecurityBaseline(pod, baseline, nil)
+	if len(violations) != 1 || violations[0].Container != "app" {
+		t.Fatalf("expected container overriding runAsNonRoot to false to be rejected, got %v", violations)
+	}
+}
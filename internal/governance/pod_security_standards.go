@@ -0,0 +1,487 @@
+package governance
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// appArmorAnnotationPrefix is the legacy per-container AppArmor annotation
+// that Pod Security Standards still evaluate alongside the GA fields.
+const appArmorAnnotationPrefix = "container.apparmor.security.beta.kubernetes.io/"
+
+// seccompLevel is the strictness of the seccomp profile check.
+type seccompLevel int
+
+const (
+	seccompUnchecked seccompLevel = iota
+	// seccompNotUnconfined forbids the Unconfined profile (PSS baseline).
+	seccompNotUnconfined
+	// seccompRequired requires RuntimeDefault or Localhost (PSS restricted).
+	seccompRequired
+)
+
+// baselineRules is the effective set of checks of a SecurityBaseline after
+// expanding its Pod Security Standards profile and applying the individual
+// rule fields on top of it.
+type baselineRules struct {
+	RunAsNonRoot                bool
+	ReadOnlyRootFilesystem      bool
+	DisallowPrivilegeEscalation bool
+	DisallowPrivileged          bool
+	Capabilities                *platformv1alpha1.CapabilitiesPolicy
+	DisallowHostNamespaces      bool
+	DisallowHostPorts           bool
+	HostPath                    *platformv1alpha1.HostPathPolicy
+	// SeccompPolicy and AppArmorPolicy replace the profile's Seccomp and
+	// RestrictAppArmor checks when set.
+	SeccompPolicy  *platformv1alpha1.SeccompPolicy
+	AppArmorPolicy *platformv1alpha1.AppArmorPolicy
+	// RunAsUserRanges replaces the profile's DisallowRootUser check when set.
+	RunAsUserRanges          *platformv1alpha1.IDRangePolicy
+	RunAsGroupRanges         *platformv1alpha1.IDRangePolicy
+	FSGroupRanges            *platformv1alpha1.IDRangePolicy
+	SupplementalGroupsRanges *platformv1alpha1.IDRangePolicy
+
+	// The following checks have no dedicated spec field and are only enabled
+	// through a profile.
+	DisallowHostProcess bool
+	RestrictAppArmor    bool
+	RestrictSELinux     bool
+	RestrictProcMount   bool
+	RestrictSysctls     bool
+	Seccomp             seccompLevel
+	RestrictVolumeTypes bool
+	DisallowRootUser    bool
+
+	// ProfileMinor is the Kubernetes minor version of the Pod Security
+	// Standards definition in use.
+	ProfileMinor int
+}
+
+var (
+	// pssBaselineCapabilities are the capabilities the PSS baseline level allows to add.
+	pssBaselineCapabilities = []corev1.Capability{
+		"AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL", "MKNOD",
+		"NET_BIND_SERVICE", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT",
+	}
+
+	// pssRestrictedVolumeTypes are the volume types the PSS restricted level
+	// allows. hostPath is governed by the hostPath rule instead.
+	pssRestrictedVolumeTypes = []string{
+		"configMap", "csi", "downwardAPI", "emptyDir", "ephemeral",
+		"persistentVolumeClaim", "projected", "secret",
+	}
+)
+
+// resolveBaselineRules expands the Pod Security Standards profile of the spec
+// and applies the individual rule fields on top of it.
+func resolveBaselineRules(spec *platformv1alpha1.SecurityBaselineSpec) baselineRules {
+	rules := baselineRules{ProfileMinor: profileMinorVersion(spec.ProfileVersion)}
+
+	switch spec.Profile {
+	case platformv1alpha1.PodSecurityProfileRestricted:
+		applyPSSBaseline(&rules)
+		rules.RunAsNonRoot = true
+		rules.DisallowPrivilegeEscalation = true
+		rules.Capabilities = &platformv1alpha1.CapabilitiesPolicy{
+			RequireDropAll: true,
+			AllowedAdd:     []corev1.Capability{"NET_BIND_SERVICE"},
+		}
+		rules.Seccomp = seccompRequired
+		rules.RestrictVolumeTypes = true
+		rules.DisallowRootUser = rules.ProfileMinor >= 23
+	case platformv1alpha1.PodSecurityProfileBaseline:
+		applyPSSBaseline(&rules)
+	}
+
+	overrideBool(&rules.RunAsNonRoot, spec.RunAsNonRoot)
+	overrideBool(&rules.ReadOnlyRootFilesystem, spec.ReadOnlyRootFilesystem)
+	overrideBool(&rules.DisallowPrivilegeEscalation, spec.DisallowPrivilegeEscalation)
+	overrideBool(&rules.DisallowPrivileged, spec.DisallowPrivileged)
+	overrideBool(&rules.DisallowHostNamespaces, spec.DisallowHostNamespaces)
+	overrideBool(&rules.DisallowHostPorts, spec.DisallowHostPorts)
+	if spec.Capabilities != nil {
+		rules.Capabilities = spec.Capabilities
+	}
+	if spec.HostPath != nil {
+		rules.HostPath = spec.HostPath
+	}
+	if spec.Seccomp != nil {
+		rules.SeccompPolicy = spec.Seccomp
+		rules.Seccomp = seccompUnchecked
+	}
+	if spec.AppArmor != nil {
+		rules.AppArmorPolicy = spec.AppArmor
+		rules.RestrictAppArmor = false
+	}
+	if spec.RunAsUser != nil {
+		rules.RunAsUserRanges = spec.RunAsUser
+		rules.DisallowRootUser = false
+	}
+	rules.RunAsGroupRanges = spec.RunAsGroup
+	rules.FSGroupRanges = spec.FSGroup
+	rules.SupplementalGroupsRanges = spec.SupplementalGroups
+
+	return rules
+}
+
+// enabled lists the rules that are checked.
+func (r *baselineRules) enabled() []platformv1alpha1.SecurityBaselineRule {
+	checks := []struct {
+		rule    platformv1alpha1.SecurityBaselineRule
+		enabled bool
+	}{
+		{platformv1alpha1.RuleRunAsNonRoot, r.RunAsNonRoot},
+		{platformv1alpha1.RuleReadOnlyRootFilesystem, r.ReadOnlyRootFilesystem},
+		{platformv1alpha1.RuleDisallowPrivilegeEscalation, r.DisallowPrivilegeEscalation},
+		{platformv1alpha1.RuleDisallowPrivileged, r.DisallowPrivileged},
+		{platformv1alpha1.RuleCapabilities, r.Capabilities != nil},
+		{platformv1alpha1.RuleDisallowHostNamespaces, r.DisallowHostNamespaces},
+		{platformv1alpha1.RuleDisallowHostPorts, r.DisallowHostPorts},
+		{platformv1alpha1.RuleHostPath, r.HostPath != nil},
+		{platformv1alpha1.RuleHostProcess, r.DisallowHostProcess},
+		{platformv1alpha1.RuleAppArmor, r.RestrictAppArmor || r.AppArmorPolicy != nil},
+		{platformv1alpha1.RuleSELinux, r.RestrictSELinux},
+		{platformv1alpha1.RuleProcMount, r.RestrictProcMount},
+		{platformv1alpha1.RuleSysctls, r.RestrictSysctls},
+		{platformv1alpha1.RuleSeccomp, r.Seccomp != seccompUnchecked || r.SeccompPolicy != nil},
+		{platformv1alpha1.RuleVolumeTypes, r.RestrictVolumeTypes},
+		{platformv1alpha1.RuleRunAsUser, r.DisallowRootUser || r.RunAsUserRanges != nil},
+		{platformv1alpha1.RuleRunAsGroup, r.RunAsGroupRanges != nil},
+		{platformv1alpha1.RuleFSGroup, r.FSGroupRanges != nil},
+		{platformv1alpha1.RuleSupplementalGroups, r.SupplementalGroupsRanges != nil},
+	}
+	var rules []platformv1alpha1.SecurityBaselineRule
+	for _, check := range checks {
+		if check.enabled {
+			rules = append(rules, check.rule)
+		}
+	}
+	return rules
+}
+
+// allowsRuntimeDefaultSeccomp reports whether the RuntimeDefault seccomp
+// profile satisfies the seccomp checks.
+func (r *baselineRules) allowsRuntimeDefaultSeccomp() bool {
+	return r.SeccompPolicy == nil || len(r.SeccompPolicy.AllowedTypes) == 0 ||
+		slices.Contains(r.SeccompPolicy.AllowedTypes, corev1.SeccompProfileTypeRuntimeDefault)
+}
+
+func applyPSSBaseline(rules *baselineRules) {
+	rules.DisallowPrivileged = true
+	rules.DisallowHostNamespaces = true
+	rules.DisallowHostPorts = true
+	rules.HostPath = &platformv1alpha1.HostPathPolicy{}
+	rules.Capabilities = &platformv1alpha1.CapabilitiesPolicy{AllowedAdd: pssBaselineCapabilities}
+	rules.DisallowHostProcess = true
+	rules.RestrictAppArmor = true
+	rules.RestrictSELinux = true
+	rules.RestrictProcMount = true
+	rules.RestrictSysctls = true
+	rules.Seccomp = seccompNotUnconfined
+}
+
+func overrideBool(target *bool, override *bool) {
+	if override != nil {
+		*target = *override
+	}
+}
+
+// profileMinorVersion parses a profile version such as "v1.30" into its minor
+// version. Empty and "latest" map to the newest definition.
+func profileMinorVersion(version string) int {
+	if version == "" || version == platformv1alpha1.PodSecurityProfileVersionLatest {
+		return math.MaxInt
+	}
+	minor, err := strconv.Atoi(strings.TrimPrefix(version, "v1."))
+	if err != nil {
+		return math.MaxInt
+	}
+	return minor
+}
+
+// pssSafeSysctls returns the sysctls allowed by the PSS baseline level at the
+// given minor version.
+func pssSafeSysctls(minor int) []string {
+	sysctls := []string{
+		"kernel.shm_rmid_forced",
+		"net.ipv4.ip_local_port_range",
+		"net.ipv4.tcp_syncookies",
+		"net.ipv4.ping_group_range",
+	}
+	if minor >= 22 {
+		sysctls = append(sysctls, "net.ipv4.ip_unprivileged_port_start")
+	}
+	if minor >= 27 {
+		sysctls = append(sysctls, "net.ipv4.ip_local_reserved_ports")
+	}
+	if minor >= 29 {
+		sysctls = append(sysctls,
+			"net.ipv4.tcp_keepalive_time",
+			"net.ipv4.tcp_fin_timeout",
+			"net.ipv4.tcp_keepalive_intvl",
+			"net.ipv4.tcp_keepalive_probes",
+		)
+	}
+	if minor >= 32 {
+		sysctls = append(sysctls, "net.ipv4.tcp_rmem", "net.ipv4.tcp_wmem")
+	}
+	return sysctls
+}
+
+// pssSELinuxTypes returns the SELinux types allowed by the PSS baseline level
+// at the given minor version.
+func pssSELinuxTypes(minor int) []string {
+	types := []string{"", "container_t", "container_init_t", "container_kvm_t"}
+	if minor >= 31 {
+		types = append(types, "container_engine_t")
+	}
+	return types
+}
+
+// evaluatePodSecurityStandards runs the profile-only checks enabled in rules.
+func evaluatePodSecurityStandards(pod *corev1.Pod, baselineName string, rules *baselineRules) []Violation {
+	var violations []Violation
+	add := func(rule platformv1alpha1.SecurityBaselineRule, container string, fldPath *field.Path, message, remediation string) {
+		violations = append(violations, Violation{
+			Baseline:    baselineName,
+			Rule:        rule,
+			Container:   container,
+			Field:       fldPath,
+			Message:     message,
+			Remediation: remediation,
+		})
+	}
+	podSC := pod.Spec.SecurityContext
+	podSCPath := field.NewPath("spec", "securityContext")
+
+	if rules.DisallowHostProcess {
+		if podSC != nil && podSC.WindowsOptions != nil && podSC.WindowsOptions.HostProcess != nil && *podSC.WindowsOptions.HostProcess {
+			add(platformv1alpha1.RuleHostProcess, "", podSCPath.Child("windowsOptions", "hostProcess"), "must not run as a Windows HostProcess",
+				"remove windowsOptions.hostProcess or set it to false")
+		}
+		ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+			sc := c.SecurityContext
+			if sc != nil && sc.WindowsOptions != nil && sc.WindowsOptions.HostProcess != nil && *sc.WindowsOptions.HostProcess {
+				add(platformv1alpha1.RuleHostProcess, c.Name, fldPath.Child("securityContext", "windowsOptions", "hostProcess"), "must not run as a Windows HostProcess",
+					"remove windowsOptions.hostProcess or set it to false")
+			}
+		})
+	}
+
+	if rules.RestrictAppArmor {
+		violations = append(violations, withRule(platformv1alpha1.RuleAppArmor, evaluateAppArmorNotUnconfined(pod, baselineName))...)
+	}
+
+	if rules.RestrictSELinux {
+		allowedTypes := pssSELinuxTypes(rules.ProfileMinor)
+		checkSELinux := func(container string, opts *corev1.SELinuxOptions, fldPath *field.Path) {
+			if opts == nil {
+				return
+			}
+			if !slices.Contains(allowedTypes, opts.Type) {
+				add(platformv1alpha1.RuleSELinux, container, fldPath.Child("type"), fmt.Sprintf("must not use SELinux type %q", opts.Type),
+					fmt.Sprintf("use one of the SELinux types %q", allowedTypes[1:]))
+			}
+			if opts.User != "" {
+				add(platformv1alpha1.RuleSELinux, container, fldPath.Child("user"), "must not set a custom SELinux user", "remove seLinuxOptions.user")
+			}
+			if opts.Role != "" {
+				add(platformv1alpha1.RuleSELinux, container, fldPath.Child("role"), "must not set a custom SELinux role", "remove seLinuxOptions.role")
+			}
+		}
+		if podSC != nil {
+			checkSELinux("", podSC.SELinuxOptions, podSCPath.Child("seLinuxOptions"))
+		}
+		ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+			if c.SecurityContext != nil {
+				checkSELinux(c.Name, c.SecurityContext.SELinuxOptions, fldPath.Child("securityContext", "seLinuxOptions"))
+			}
+		})
+	}
+
+	if rules.RestrictProcMount {
+		ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+			sc := c.SecurityContext
+			if sc != nil && sc.ProcMount != nil && *sc.ProcMount != corev1.DefaultProcMount {
+				add(platformv1alpha1.RuleProcMount, c.Name, fldPath.Child("securityContext", "procMount"), fmt.Sprintf("must not use procMount %q", *sc.ProcMount),
+					"remove securityContext.procMount or set it to Default")
+			}
+		})
+	}
+
+	if rules.RestrictSysctls && podSC != nil {
+		safe := pssSafeSysctls(rules.ProfileMinor)
+		for i, sysctl := range podSC.Sysctls {
+			if !slices.Contains(safe, sysctl.Name) {
+				add(platformv1alpha1.RuleSysctls, "", podSCPath.Child("sysctls").Index(i).Child("name"), fmt.Sprintf("must not set unsafe sysctl %q", sysctl.Name),
+					"remove the sysctl or use one of the safe sysctls")
+			}
+		}
+	}
+
+	switch rules.Seccomp {
+	case seccompNotUnconfined:
+		violations = append(violations, withRule(platformv1alpha1.RuleSeccomp, evaluateSeccompNotUnconfined(pod, baselineName))...)
+	case seccompRequired:
+		violations = append(violations, withRule(platformv1alpha1.RuleSeccomp, evaluateSeccompRequired(pod, baselineName))...)
+	}
+
+	if rules.RestrictVolumeTypes {
+		for i, volume := range pod.Spec.Volumes {
+			volumeType := volumeSourceType(&volume.VolumeSource)
+			if volumeType == "hostPath" || slices.Contains(pssRestrictedVolumeTypes, volumeType) {
+				continue
+			}
+			add(platformv1alpha1.RuleVolumeTypes, "", field.NewPath("spec", "volumes").Index(i).Child(volumeType),
+				fmt.Sprintf("must not use volume %q of type %s", volume.Name, volumeType),
+				fmt.Sprintf("use one of the volume types %v", pssRestrictedVolumeTypes))
+		}
+	}
+
+	if rules.DisallowRootUser {
+		if podSC != nil && podSC.RunAsUser != nil && *podSC.RunAsUser == 0 {
+			add(platformv1alpha1.RuleRunAsUser, "", podSCPath.Child("runAsUser"), "must not run as UID 0", "set spec.securityContext.runAsUser to a non-zero UID")
+		}
+		ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+			if c.SecurityContext != nil && c.SecurityContext.RunAsUser != nil && *c.SecurityContext.RunAsUser == 0 {
+				add(platformv1alpha1.RuleRunAsUser, c.Name, fldPath.Child("securityContext", "runAsUser"), "must not run as UID 0",
+					"set securityContext.runAsUser to a non-zero UID")
+			}
+		})
+	}
+
+	return violations
+}
+
+func evaluateAppArmorNotUnconfined(pod *corev1.Pod, baselineName string) []Violation {
+	var violations []Violation
+	unconfined := func(profile *corev1.AppArmorProfile) bool {
+		return profile != nil && profile.Type == corev1.AppArmorProfileTypeUnconfined
+	}
+	remediation := "use the RuntimeDefault or a Localhost AppArmor profile"
+
+	if pod.Spec.SecurityContext != nil && unconfined(pod.Spec.SecurityContext.AppArmorProfile) {
+		violations = append(violations, Violation{
+			Baseline:    baselineName,
+			Field:       field.NewPath("spec", "securityContext", "appArmorProfile", "type"),
+			Message:     "must not use the Unconfined AppArmor profile",
+			Remediation: remediation,
+		})
+	}
+	ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+		if c.SecurityContext != nil && unconfined(c.SecurityContext.AppArmorProfile) {
+			violations = append(violations, Violation{
+				Baseline:    baselineName,
+				Container:   c.Name,
+				Field:       fldPath.Child("securityContext", "appArmorProfile", "type"),
+				Message:     "must not use the Unconfined AppArmor profile",
+				Remediation: remediation,
+			})
+		}
+	})
+
+	annotationsPath := field.NewPath("metadata", "annotations")
+	for key, value := range pod.Annotations {
+		if !strings.HasPrefix(key, appArmorAnnotationPrefix) {
+			continue
+		}
+		if value == "" || value == corev1.DeprecatedAppArmorBetaProfileRuntimeDefault || strings.HasPrefix(value, corev1.DeprecatedAppArmorBetaProfileNamePrefix) {
+			continue
+		}
+		violations = append(violations, Violation{
+			Baseline:    baselineName,
+			Container:   strings.TrimPrefix(key, appArmorAnnotationPrefix),
+			Field:       annotationsPath.Key(key),
+			Message:     fmt.Sprintf("must not use AppArmor profile %q", value),
+			Remediation: remediation,
+		})
+	}
+	slices.SortStableFunc(violations, func(a, b Violation) int {
+		return strings.Compare(a.Field.String(), b.Field.String())
+	})
+	return violations
+}
+
+func evaluateSeccompNotUnconfined(pod *corev1.Pod, baselineName string) []Violation {
+	var violations []Violation
+	remediation := "use the RuntimeDefault or a Localhost seccomp profile"
+	if pod.Spec.SecurityContext != nil && seccompUnconfined(pod.Spec.SecurityContext.SeccompProfile) {
+		violations = append(violations, Violation{
+			Baseline:    baselineName,
+			Field:       field.NewPath("spec", "securityContext", "seccompProfile", "type"),
+			Message:     "must not use the Unconfined seccomp profile",
+			Remediation: remediation,
+		})
+	}
+	ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+		if c.SecurityContext != nil && seccompUnconfined(c.SecurityContext.SeccompProfile) {
+			violations = append(violations, Violation{
+				Baseline:    baselineName,
+				Container:   c.Name,
+				Field:       fldPath.Child("securityContext", "seccompProfile", "type"),
+				Message:     "must not use the Unconfined seccomp profile",
+				Remediation: remediation,
+			})
+		}
+	})
+	return violations
+}
+
+// evaluateSeccompRequired requires a RuntimeDefault or Localhost seccomp
+// profile, either at Pod level or on every container.
+func evaluateSeccompRequired(pod *corev1.Pod, baselineName string) []Violation {
+	violations := evaluateSeccompNotUnconfined(pod, baselineName)
+
+	podConfined := pod.Spec.SecurityContext != nil && seccompConfined(pod.Spec.SecurityContext.SeccompProfile)
+	if podConfined {
+		return violations
+	}
+	ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+		if c.SecurityContext != nil && c.SecurityContext.SeccompProfile != nil {
+			return
+		}
+		violations = append(violations, Violation{
+			Baseline:    baselineName,
+			Container:   c.Name,
+			Field:       fldPath.Child("securityContext", "seccompProfile", "type"),
+			Message:     "must set a seccomp profile",
+			Remediation: "set spec.securityContext.seccompProfile.type to RuntimeDefault or Localhost",
+		})
+	})
+	return violations
+}
+
+func seccompUnconfined(profile *corev1.SeccompProfile) bool {
+	return profile != nil && profile.Type == corev1.SeccompProfileTypeUnconfined
+}
+
+func seccompConfined(profile *corev1.SeccompProfile) bool {
+	return profile != nil && (profile.Type == corev1.SeccompProfileTypeRuntimeDefault || profile.Type == corev1.SeccompProfileTypeLocalhost)
+}
+
+// volumeSourceType returns the JSON name of the volume source that is set,
+// e.g. "emptyDir" or "nfs".
+func volumeSourceType(source *corev1.VolumeSource) string {
+	raw, err := json.Marshal(source)
+	if err != nil {
+		return "unknown"
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return "unknown"
+	}
+	for name := range fields {
+		return name
+	}
+	return "unknown"
+}
@@ -0,0 +1,96 @@
+package governance
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// automountTokenAnnotation opts a Pod out of the DisallowTokenAutomount rule
+// of a ServiceAccountPolicy when set to "true".
+const automountTokenAnnotation = "core.platform.f3nr1r.io/automount-service-account-token"
+
+// defaultServiceAccount is the ServiceAccount Pods run as when they name none.
+const defaultServiceAccount = "default"
+
+// evaluateServiceAccounts checks the ServiceAccount the Pod runs as and
+// whether its token is mounted.
+func evaluateServiceAccounts(pod *corev1.Pod, baselineName string, policy *platformv1alpha1.ServiceAccountPolicy) []Violation {
+	var violations []Violation
+
+	if policy.DisallowTokenAutomount {
+		violations = append(violations, evaluateTokenAutomount(pod, baselineName)...)
+	}
+
+	serviceAccount := podServiceAccount(pod)
+	serviceAccountPath := field.NewPath("spec", "serviceAccountName")
+	if policy.DisallowDefault && serviceAccount == defaultServiceAccount {
+		violations = append(violations, Violation{
+			Baseline:    baselineName,
+			Field:       serviceAccountPath,
+			Message:     "must not run as the default ServiceAccount",
+			Remediation: "create a dedicated ServiceAccount and set spec.serviceAccountName",
+		})
+	}
+	if len(policy.Allowed) > 0 && !slices.ContainsFunc(policy.Allowed, func(pattern string) bool {
+		matched, err := path.Match(pattern, serviceAccount)
+		return err == nil && matched
+	}) {
+		violations = append(violations, Violation{
+			Baseline:    baselineName,
+			Field:       serviceAccountPath,
+			Message:     fmt.Sprintf("must not run as ServiceAccount %q", serviceAccount),
+			Remediation: fmt.Sprintf("use one of the ServiceAccounts %q", policy.Allowed),
+		})
+	}
+	return violations
+}
+
+// evaluateTokenAutomount requires spec.automountServiceAccountToken: false
+// unless the Pod opts in to token mounting with automountTokenAnnotation.
+func evaluateTokenAutomount(pod *corev1.Pod, baselineName string) []Violation {
+	if raw, ok := pod.Annotations[automountTokenAnnotation]; ok {
+		optIn, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return []Violation{{
+				Baseline:    baselineName,
+				Field:       field.NewPath("metadata", "annotations").Key(automountTokenAnnotation),
+				Message:     fmt.Sprintf("invalid value %q", raw),
+				Remediation: `set the annotation to "true" or "false"`,
+			}}
+		}
+		if optIn {
+			return nil
+		}
+	}
+
+	if pod.Spec.AutomountServiceAccountToken != nil && !*pod.Spec.AutomountServiceAccountToken {
+		return nil
+	}
+	return []Violation{{
+		Baseline: baselineName,
+		Field:    field.NewPath("spec", "automountServiceAccountToken"),
+		Message:  "must not mount the ServiceAccount token",
+		Remediation: fmt.Sprintf(`set spec.automountServiceAccountToken: false, or annotate the Pod with %s: "true" if it needs API access`,
+			automountTokenAnnotation),
+	}}
+}
+
+// podServiceAccount returns the name of the ServiceAccount the Pod runs as.
+func podServiceAccount(pod *corev1.Pod) string {
+	switch {
+	case pod.Spec.ServiceAccountName != "":
+		return pod.Spec.ServiceAccountName
+	case pod.Spec.DeprecatedServiceAccount != "":
+		return pod.Spec.DeprecatedServiceAccount
+	default:
+		return defaultServiceAccount
+	}
+}
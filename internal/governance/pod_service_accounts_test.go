@@ -0,0 +1,103 @@
+package governance
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+func TestEvaluateServiceAccountsTokenAutomount(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "tokens"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			ServiceAccounts: &platformv1alpha1.ServiceAccountPolicy{DisallowTokenAutomount: true},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		automount   *bool
+		annotations map[string]string
+		wants       []string
+	}{
+		{name: "token mounting disabled", automount: ptr.To(false)},
+		{name: "unset inherits the ServiceAccount", wants: []string{"spec.automountServiceAccountToken"}},
+		{name: "token mounting enabled", automount: ptr.To(true), wants: []string{"spec.automountServiceAccountToken"}},
+		{name: "opted in", automount: ptr.To(true), annotations: map[string]string{automountTokenAnnotation: "true"}},
+		{
+			name:        "opted out explicitly",
+			annotations: map[string]string{automountTokenAnnotation: "false"},
+			wants:       []string{"spec.automountServiceAccountToken"},
+		},
+		{
+			name:        "invalid opt-in",
+			annotations: map[string]string{automountTokenAnnotation: "yes please"},
+			wants:       []string{"metadata.annotations[core.platform.f3nr1r.io/automount-service-account-token]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Spec: corev1.PodSpec{
+					AutomountServiceAccountToken: tt.automount,
+					Containers:                   []corev1.Container{{Name: "app"}},
+				},
+			}
+			violations := EvaluateSecurityBaseline(pod, baseline, nil)
+			if got := violationFields(violations); !slices.Equal(got, tt.wants) {
+				t.Fatalf("expected violations at %v, got %v", tt.wants, violations)
+			}
+			for _, violation := range violations {
+				if violation.Rule != platformv1alpha1.RuleServiceAccounts {
+					t.Fatalf("expected the serviceAccounts rule, got %q", violation.Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateServiceAccountsRestrictsServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "service-accounts"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			ServiceAccounts: &platformv1alpha1.ServiceAccountPolicy{DisallowDefault: true, Allowed: []string{"web", "ci-*"}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		serviceAccount string
+		wants          int
+	}{
+		{name: "allowed", serviceAccount: "web"},
+		{name: "allowed by wildcard", serviceAccount: "ci-runner"},
+		{name: "not allowed", serviceAccount: "admin", wants: 1},
+		{name: "explicit default", serviceAccount: "default", wants: 2},
+		{name: "unset defaults to default", wants: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pod := &corev1.Pod{Spec: corev1.PodSpec{
+				ServiceAccountName: tt.serviceAccount,
+				Containers:         []corev1.Container{{Name: "app"}},
+			}}
+			if violations := EvaluateSecurityBaseline(pod, baseline, nil); len(violations) != tt.wants {
+				t.Fatalf("expected %d violation(s), got %v", tt.wants, violations)
+			}
+		})
+	}
+}
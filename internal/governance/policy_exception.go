@@ -0,0 +1,57 @@
+package governance
+
+import (
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// ActivePolicyExceptions returns the exceptions that have not expired at now.
+// Expiry is checked here rather than trusting the status written by the
+// controller, so an exception stops being honored the moment it expires.
+func ActivePolicyExceptions(exceptions []platformv1alpha1.PolicyException, now time.Time) []platformv1alpha1.PolicyException {
+	return slices.DeleteFunc(exceptions, func(exception platformv1alpha1.PolicyException) bool {
+		return !now.Before(exception.Spec.ExpiresAt.Time)
+	})
+}
+
+// ExemptViolations splits the violations of a baseline into those that still
+// apply and those exempted by one of the exceptions. Exempted violations carry
+// the name of the exception that exempted them.
+func ExemptViolations(violations []Violation, exceptions []platformv1alpha1.PolicyException, kind, baselineName string,
+	pod *corev1.Pod) (remaining, exempted []Violation) {
+	var applicable []*platformv1alpha1.PolicyException
+	for i := range exceptions {
+		if exceptionReferences(&exceptions[i], kind, baselineName) && SelectsPod(exceptions[i].Spec.PodSelector, pod.Labels) {
+			applicable = append(applicable, &exceptions[i])
+		}
+	}
+
+	for _, violation := range violations {
+		index := slices.IndexFunc(applicable, func(exception *platformv1alpha1.PolicyException) bool {
+			return violation.Rule != "" && slices.Contains(exception.Spec.Rules, violation.Rule)
+		})
+		if index < 0 {
+			remaining = append(remaining, violation)
+			continue
+		}
+		violation.Exception = applicable[index].Name
+		exempted = append(exempted, violation)
+	}
+	return remaining, exempted
+}
+
+// exceptionReferences reports whether the exception targets the named baseline.
+// Exceptions only ever target SecurityBaselines: a namespaced exception must
+// not relax a ClusterSecurityBaseline, even one created before the validating
+// webhook rejected such references.
+func exceptionReferences(exception *platformv1alpha1.PolicyException, kind, baselineName string) bool {
+	exceptionKind := exception.Spec.Baseline.Kind
+	if exceptionKind == "" {
+		exceptionKind = "SecurityBaseline"
+	}
+	return kind == "SecurityBaseline" && exceptionKind == kind && exception.Spec.Baseline.Name == baselineName
+}
@@ -0,0 +1,82 @@
+package governance
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+func newPolicyException(name, baseline string, expiresAt time.Time, rules ...platformv1alpha1.SecurityBaselineRule) *platformv1alpha1.PolicyException {
+	return &platformv1alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+		Spec: platformv1alpha1.PolicyExceptionSpec{
+			Baseline:      platformv1alpha1.BaselineReference{Name: baseline},
+			Rules:         rules,
+			Justification: "legacy image writes to its root filesystem",
+			Owner:         "team-a",
+			ExpiresAt:     metav1.NewTime(expiresAt),
+		},
+	}
+}
+
+func TestExemptViolationsMatchesBaselineRuleAndPod(t *testing.T) {
+	t.Parallel()
+
+	violations := []Violation{
+		{Baseline: "baseline", Rule: platformv1alpha1.RuleRunAsNonRoot},
+		{Baseline: "baseline", Rule: platformv1alpha1.RuleReadOnlyRootFilesystem},
+	}
+	exception := newPolicyException("legacy", "baseline", time.Now().Add(time.Hour), platformv1alpha1.RuleReadOnlyRootFilesystem)
+	exception.Spec.PodSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "legacy"}}
+	exceptions := []platformv1alpha1.PolicyException{*exception}
+
+	legacyPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "legacy"}}}
+	remaining, exempted := ExemptViolations(violations, exceptions, "SecurityBaseline", "baseline", legacyPod)
+	if len(remaining) != 1 || remaining[0].Rule != platformv1alpha1.RuleRunAsNonRoot {
+		t.Fatalf("expected only the runAsNonRoot violation to remain, got %+v", remaining)
+	}
+	if len(exempted) != 1 || exempted[0].Exception != "legacy" {
+		t.Fatalf("expected the readOnlyRootFilesystem violation to be exempted by legacy, got %+v", exempted)
+	}
+
+	otherPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}}}
+	if remaining, _ := ExemptViolations(violations, exceptions, "SecurityBaseline", "baseline", otherPod); len(remaining) != 2 {
+		t.Fatalf("expected no exemption for a Pod outside the podSelector, got %d remaining", len(remaining))
+	}
+	if remaining, _ := ExemptViolations(violations, exceptions, "ClusterSecurityBaseline", "baseline", legacyPod); len(remaining) != 2 {
+		t.Fatalf("expected no exemption for a baseline of another kind, got %d remaining", len(remaining))
+	}
+}
+
+func TestExemptViolationsNeverExemptsClusterSecurityBaselines(t *testing.T) {
+	t.Parallel()
+
+	violations := []Violation{{Baseline: "restricted", Rule: platformv1alpha1.RuleReadOnlyRootFilesystem}}
+	exception := newPolicyException("legacy", "restricted", time.Now().Add(time.Hour), platformv1alpha1.RuleReadOnlyRootFilesystem)
+	exception.Spec.Baseline.Kind = "ClusterSecurityBaseline"
+
+	remaining, exempted := ExemptViolations(violations, []platformv1alpha1.PolicyException{*exception}, "ClusterSecurityBaseline",
+		"restricted", &corev1.Pod{})
+	if len(remaining) != 1 || len(exempted) != 0 {
+		t.Fatalf("expected a namespaced exception not to relax a ClusterSecurityBaseline, got remaining %+v, exempted %+v",
+			remaining, exempted)
+	}
+}
+
+func TestActivePolicyExceptionsDropsExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	exceptions := []platformv1alpha1.PolicyException{
+		*newPolicyException("active", "baseline", now.Add(time.Minute), platformv1alpha1.RuleRunAsNonRoot),
+		*newPolicyException("expired", "baseline", now, platformv1alpha1.RuleRunAsNonRoot),
+	}
+	active := ActivePolicyExceptions(exceptions, now)
+	if len(active) != 1 || active[0].Name != "active" {
+		t.Fatalf("expected only the unexpired exception, got %+v", active)
+	}
+}
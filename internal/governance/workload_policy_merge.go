@@ -0,0 +1,97 @@
+package governance
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// WorkloadPolicyCandidate is a WorkloadPolicy or ClusterWorkloadPolicy
+// selecting a Pod, together with the object it was read from.
+type WorkloadPolicyCandidate struct {
+	Kind   string
+	Object client.Object
+	Policy platformv1alpha1.WorkloadPolicy
+	// SupersededBy names the HighestPriorityOnly policy that excludes this
+	// policy, if any.
+	SupersededBy string
+}
+
+// ByPriority orders policies by descending priority, breaking ties by name so
+// that the order never depends on how the API server listed them.
+func ByPriority(priorityA int32, nameA string, priorityB int32, nameB string) int {
+	return cmp.Or(cmp.Compare(priorityB, priorityA), cmp.Compare(nameA, nameB))
+}
+
+// MergeWorkloadPolicies orders the policies of a single scope by priority and
+// trims their specs to what they contribute under the merge strategies of the
+// policies before them: fields set by an Override policy are dropped from
+// later policies, and every policy after a HighestPriorityOnly one is
+// superseded. Defaults of the remaining policies only fill in what earlier
+// policies leave unset when they are applied in order.
+func MergeWorkloadPolicies(candidates []WorkloadPolicyCandidate) []WorkloadPolicyCandidate {
+	slices.SortFunc(candidates, func(a, b WorkloadPolicyCandidate) int {
+		return ByPriority(a.Policy.Spec.Priority, a.Policy.Name, b.Policy.Spec.Priority, b.Policy.Name)
+	})
+
+	var overridden overriddenDefaults
+	supersededBy := ""
+	for i := range candidates {
+		candidate := &candidates[i]
+		if supersededBy != "" {
+			candidate.SupersededBy = supersededBy
+			continue
+		}
+
+		spec := &candidate.Policy.Spec
+		overridden.drop(spec)
+		switch spec.MergeStrategy {
+		case platformv1alpha1.MergeStrategyOverride:
+			overridden.claim(spec)
+		case platformv1alpha1.MergeStrategyHighestPriorityOnly:
+			supersededBy = fmt.Sprintf("%s %s", candidate.Kind, candidate.Policy.Name)
+		}
+	}
+	return candidates
+}
+
+// overriddenDefaults records the defaults claimed by Override policies.
+type overriddenDefaults struct {
+	requests, limits, labels, initContainers, sidecars, containerRules bool
+}
+
+// claim records the defaults the spec sets.
+func (o *overriddenDefaults) claim(spec *platformv1alpha1.WorkloadPolicySpec) {
+	o.requests = o.requests || len(spec.DefaultRequests) > 0
+	o.limits = o.limits || len(spec.DefaultLimits) > 0
+	o.labels = o.labels || len(spec.MandatoryLabels) > 0
+	o.initContainers = o.initContainers || spec.InitContainerResources != nil
+	o.sidecars = o.sidecars || spec.SidecarResources != nil
+	o.containerRules = o.containerRules || len(spec.ContainerResources) > 0
+}
+
+// drop clears the claimed defaults from the spec.
+func (o *overriddenDefaults) drop(spec *platformv1alpha1.WorkloadPolicySpec) {
+	if o.requests {
+		spec.DefaultRequests = nil
+	}
+	if o.limits {
+		spec.DefaultLimits = nil
+	}
+	if o.labels {
+		spec.MandatoryLabels = nil
+	}
+	if o.initContainers {
+		spec.InitContainerResources = nil
+	}
+	if o.sidecars {
+		spec.SidecarResources = nil
+	}
+	if o.containerRules {
+		spec.ContainerResources = nil
+	}
+}
@@ -0,0 +1,105 @@
+package governance
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+func workloadPolicyCandidates(policies ...platformv1alpha1.WorkloadPolicy) []WorkloadPolicyCandidate {
+	candidates := make([]WorkloadPolicyCandidate, 0, len(policies))
+	for i := range policies {
+		candidates = append(candidates, WorkloadPolicyCandidate{Kind: "WorkloadPolicy", Object: &policies[i], Policy: policies[i]})
+	}
+	return candidates
+}
+
+func TestMergeWorkloadPoliciesOrdersByPriorityAndName(t *testing.T) {
+	t.Parallel()
+
+	policy := func(name string, priority int32) platformv1alpha1.WorkloadPolicy {
+		return platformv1alpha1.WorkloadPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       platformv1alpha1.WorkloadPolicySpec{Priority: priority},
+		}
+	}
+
+	for _, listed := range [][]platformv1alpha1.WorkloadPolicy{
+		{policy("low", 1), policy("team-b", 5), policy("high", 10), policy("team-a", 5)},
+		{policy("team-a", 5), policy("high", 10), policy("low", 1), policy("team-b", 5)},
+	} {
+		var names []string
+		for _, candidate := range MergeWorkloadPolicies(workloadPolicyCandidates(listed...)) {
+			names = append(names, candidate.Policy.Name)
+		}
+		if want := []string{"high", "team-a", "team-b", "low"}; !slices.Equal(names, want) {
+			t.Fatalf("expected order %v, got %v", want, names)
+		}
+	}
+}
+
+func TestMergeWorkloadPoliciesStrategies(t *testing.T) {
+	t.Parallel()
+
+	defaults := func(strategy platformv1alpha1.MergeStrategy) []WorkloadPolicyCandidate {
+		return workloadPolicyCandidates(
+			platformv1alpha1.WorkloadPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+				Spec: platformv1alpha1.WorkloadPolicySpec{
+					Priority:        10,
+					MergeStrategy:   strategy,
+					DefaultRequests: map[string]string{"cpu": "500m"},
+				},
+			},
+			platformv1alpha1.WorkloadPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "team"},
+				Spec: platformv1alpha1.WorkloadPolicySpec{
+					DefaultRequests: map[string]string{"cpu": "100m", "memory": "128Mi"},
+					MandatoryLabels: map[string]string{"team": "a"},
+				},
+			},
+		)
+	}
+
+	tests := []struct {
+		strategy     platformv1alpha1.MergeStrategy
+		wantRequests map[string]string
+		wantLabels   map[string]string
+		superseded   bool
+	}{
+		{
+			strategy:     platformv1alpha1.MergeStrategyMerge,
+			wantRequests: map[string]string{"cpu": "100m", "memory": "128Mi"},
+			wantLabels:   map[string]string{"team": "a"},
+		},
+		{strategy: platformv1alpha1.MergeStrategyOverride, wantLabels: map[string]string{"team": "a"}},
+		{strategy: platformv1alpha1.MergeStrategyHighestPriorityOnly, superseded: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			t.Parallel()
+
+			merged := MergeWorkloadPolicies(defaults(tt.strategy))
+			team := merged[1]
+			if (team.SupersededBy != "") != tt.superseded {
+				t.Fatalf("expected superseded %t, got %q", tt.superseded, team.SupersededBy)
+			}
+			if tt.superseded {
+				if team.SupersededBy != "WorkloadPolicy platform" {
+					t.Fatalf("expected the superseding policy to be named, got %q", team.SupersededBy)
+				}
+				return
+			}
+			if !maps.Equal(team.Policy.Spec.DefaultRequests, tt.wantRequests) {
+				t.Fatalf("expected the team requests %v, got %v", tt.wantRequests, team.Policy.Spec.DefaultRequests)
+			}
+			if !maps.Equal(team.Policy.Spec.MandatoryLabels, tt.wantLabels) {
+				t.Fatalf("expected the team labels %v, got %v", tt.wantLabels, team.Policy.Spec.MandatoryLabels)
+			}
+		})
+	}
+}
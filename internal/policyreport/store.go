@@ -0,0 +1,322 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policyreport publishes the results of admission decisions and
+// background scans as Kubernetes Policy Working Group PolicyReports, one per
+// namespace.
+package policyreport
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport/v1alpha2"
+)
+
+// Trigger is what produced a result.
+type Trigger string
+
+const (
+	// TriggerAdmission marks results of admission decisions.
+	TriggerAdmission Trigger = "admission"
+	// TriggerBackground marks results of background compliance scans.
+	TriggerBackground Trigger = "background"
+)
+
+// PolicyRef identifies the policy that produced a result. Namespace is empty
+// for cluster-scoped policies.
+type PolicyRef struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// Result is the outcome of a single policy rule for a resource.
+type Result struct {
+	Policy     PolicyRef
+	Rule       string
+	Result     v1alpha2.PolicyResult
+	Subject    corev1.ObjectReference
+	Message    string
+	Properties map[string]string
+}
+
+// SubjectForPod returns the resource a Pod's results are reported against:
+// its controller, such as a ReplicaSet or Job, or the Pod itself. Pods created
+// with generateName have no name yet at admission, so their generateName is
+// used instead.
+func SubjectForPod(pod *corev1.Pod, namespace string) corev1.ObjectReference {
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		return corev1.ObjectReference{
+			APIVersion: owner.APIVersion,
+			Kind:       owner.Kind,
+			Namespace:  namespace,
+			Name:       owner.Name,
+			UID:        owner.UID,
+		}
+	}
+	return corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  namespace,
+		Name:       cmp.Or(pod.Name, pod.GenerateName),
+		UID:        pod.UID,
+	}
+}
+
+type resultKey struct {
+	trigger     Trigger
+	policy      PolicyRef
+	rule        string
+	subjectKind string
+	subjectName string
+}
+
+type entry struct {
+	result Result
+	// changed is when the outcome or message last changed; lastSeen is when
+	// the result was last recorded and drives the expiry of admission results.
+	changed  time.Time
+	lastSeen time.Time
+}
+
+// Store accumulates the latest result per policy rule and resource until the
+// Writer publishes them. It is safe for concurrent use, and a nil Store
+// discards everything recorded to it.
+type Store struct {
+	mu      sync.Mutex
+	entries map[string]map[resultKey]*entry
+	dirty   sets.Set[string]
+	// elected is closed once this replica is the leader whose Writer drains
+	// the Store.
+	elected <-chan struct{}
+}
+
+// NewStore returns an empty Store. Admission results are discarded until
+// elected is closed, typically by the manager once this replica becomes the
+// leader, since only the leader's Writer publishes and expires them. A nil
+// channel keeps them from the start.
+func NewStore(elected <-chan struct{}) *Store {
+	return &Store{entries: map[string]map[resultKey]*entry{}, dirty: sets.New[string](), elected: elected}
+}
+
+// Record stores the results of a single admission decision, replacing earlier
+// results for the same policy rule and resource. Results are discarded while
+// this replica is not the leader.
+func (s *Store) Record(trigger Trigger, now time.Time, results []Result) {
+	if s == nil || !s.isElected() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, result := range worstByKey(trigger, results) {
+		s.upsert(key, result, now)
+	}
+}
+
+// Replace stores the results of a complete evaluation of a policy, such as a
+// background scan, dropping every earlier result of the policy for trigger
+// that is not part of results.
+func (s *Store) Replace(trigger Trigger, policy PolicyRef, now time.Time, results []Result) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	latest := worstByKey(trigger, results)
+	for namespace, entries := range s.entries {
+		for key := range entries {
+			if _, ok := latest[key]; !ok && key.trigger == trigger && key.policy == policy {
+				delete(entries, key)
+				s.dirty.Insert(namespace)
+			}
+		}
+	}
+	for key, result := range latest {
+		s.upsert(key, result, now)
+	}
+}
+
+// Forget drops every result of a policy, e.g. after it was deleted.
+func (s *Store) Forget(policy PolicyRef) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for namespace, entries := range s.entries {
+		for key := range entries {
+			if key.policy == policy {
+				delete(entries, key)
+				s.dirty.Insert(namespace)
+			}
+		}
+	}
+}
+
+// isElected reports whether this replica publishes the results of the Store.
+func (s *Store) isElected() bool {
+	if s.elected == nil {
+		return true
+	}
+	select {
+	case <-s.elected:
+		return true
+	default:
+		return false
+	}
+}
+
+// upsert must be called with s.mu held.
+func (s *Store) upsert(key resultKey, result Result, now time.Time) {
+	namespace := result.Subject.Namespace
+	entries, ok := s.entries[namespace]
+	if !ok {
+		entries = map[resultKey]*entry{}
+		s.entries[namespace] = entries
+	}
+	existing, ok := entries[key]
+	if ok && existing.result.Result == result.Result && existing.result.Message == result.Message &&
+		maps.Equal(existing.result.Properties, result.Properties) {
+		existing.lastSeen = now
+		return
+	}
+	entries[key] = &entry{result: result, changed: now, lastSeen: now}
+	s.dirty.Insert(namespace)
+}
+
+// pending drops admission results not seen within retention and returns the
+// report results of every namespace that changed since the last call.
+func (s *Store) pending(now time.Time, retention time.Duration) map[string][]v1alpha2.PolicyReportResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if retention > 0 {
+		for namespace, entries := range s.entries {
+			for key, e := range entries {
+				if key.trigger == TriggerAdmission && now.Sub(e.lastSeen) > retention {
+					delete(entries, key)
+					s.dirty.Insert(namespace)
+				}
+			}
+		}
+	}
+
+	reports := make(map[string][]v1alpha2.PolicyReportResult, s.dirty.Len())
+	for namespace := range s.dirty {
+		results := make([]v1alpha2.PolicyReportResult, 0, len(s.entries[namespace]))
+		for key, e := range s.entries[namespace] {
+			results = append(results, reportResult(key, e))
+		}
+		sortResults(results)
+		reports[namespace] = results
+		if len(s.entries[namespace]) == 0 {
+			delete(s.entries, namespace)
+		}
+	}
+	s.dirty = sets.New[string]()
+	return reports
+}
+
+// markDirty schedules the report of a namespace to be written again, e.g.
+// after a failed write.
+func (s *Store) markDirty(namespace string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dirty.Insert(namespace)
+}
+
+// worstByKey indexes results by key. When several results share a key, such
+// as the Pods of one workload in a scan, the most severe one is kept.
+func worstByKey(trigger Trigger, results []Result) map[resultKey]Result {
+	byKey := make(map[resultKey]Result, len(results))
+	for _, result := range results {
+		key := resultKey{
+			trigger:     trigger,
+			policy:      result.Policy,
+			rule:        result.Rule,
+			subjectKind: result.Subject.Kind,
+			subjectName: result.Subject.Name,
+		}
+		if existing, ok := byKey[key]; ok && severity(existing.Result) >= severity(result.Result) {
+			continue
+		}
+		byKey[key] = result
+	}
+	return byKey
+}
+
+func reportResult(key resultKey, e *entry) v1alpha2.PolicyReportResult {
+	properties := maps.Clone(e.result.Properties)
+	if properties == nil {
+		properties = map[string]string{}
+	}
+	properties["trigger"] = string(key.trigger)
+	return v1alpha2.PolicyReportResult{
+		Source:      Source,
+		Policy:      key.policy.Name,
+		Rule:        key.rule,
+		Category:    key.policy.Kind,
+		Timestamp:   metav1.Timestamp{Seconds: e.changed.Unix()},
+		Result:      e.result.Result,
+		Scored:      true,
+		Subjects:    []corev1.ObjectReference{e.result.Subject},
+		Description: e.result.Message,
+		Properties:  properties,
+	}
+}
+
+// sortResults orders results by severity first, so failures survive when a
+// report has to be truncated, then by policy, rule and resource.
+func sortResults(results []v1alpha2.PolicyReportResult) {
+	slices.SortFunc(results, func(a, b v1alpha2.PolicyReportResult) int {
+		return cmp.Or(
+			cmp.Compare(severity(b.Result), severity(a.Result)),
+			strings.Compare(a.Category, b.Category),
+			strings.Compare(a.Policy, b.Policy),
+			strings.Compare(a.Rule, b.Rule),
+			strings.Compare(a.Subjects[0].Kind, b.Subjects[0].Kind),
+			strings.Compare(a.Subjects[0].Name, b.Subjects[0].Name),
+			strings.Compare(a.Properties["trigger"], b.Properties["trigger"]),
+		)
+	})
+}
+
+func severity(result v1alpha2.PolicyResult) int {
+	switch result {
+	case v1alpha2.PolicyResultFail:
+		return 4
+	case v1alpha2.PolicyResultError:
+		return 3
+	case v1alpha2.PolicyResultWarn:
+		return 2
+	case v1alpha2.PolicyResultSkip:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyreport
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport/v1alpha2"
+)
+
+var restricted = PolicyRef{Kind: "SecurityBaseline", Namespace: "team-a", Name: "restricted"}
+
+func workload(name string) corev1.ObjectReference {
+	return corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "team-a", Name: name}
+}
+
+func TestSubjectForPod(t *testing.T) {
+	t.Parallel()
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{GenerateName: "debug-"}}
+	if subject := SubjectForPod(pod, "team-a"); subject.Kind != "Pod" || subject.Name != "debug-" || subject.Namespace != "team-a" {
+		t.Fatalf("expected the Pod itself, got %+v", subject)
+	}
+
+	pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-5d8f", Controller: ptr.To(true)}}
+	if subject := SubjectForPod(pod, "team-a"); subject.Kind != "ReplicaSet" || subject.Name != "web-5d8f" {
+		t.Fatalf("expected the controlling ReplicaSet, got %+v", subject)
+	}
+}
+
+func TestStoreKeepsMostSevereResultPerResource(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore(nil)
+	now := time.Now()
+	store.Record(TriggerBackground, now, []Result{
+		{Policy: restricted, Rule: "runAsNonRoot", Result: v1alpha2.PolicyResultPass, Subject: workload("web")},
+		{Policy: restricted, Rule: "runAsNonRoot", Result: v1alpha2.PolicyResultFail, Subject: workload("web"), Message: "runs as root"},
+		{Policy: restricted, Rule: "runAsNonRoot", Result: v1alpha2.PolicyResultPass, Subject: workload("web")},
+	})
+
+	results := store.pending(now, 0)["team-a"]
+	if len(results) != 1 || results[0].Result != v1alpha2.PolicyResultFail || results[0].Description != "runs as root" {
+		t.Fatalf("expected a single fail result, got %+v", results)
+	}
+	if results[0].Source != Source || results[0].Category != "SecurityBaseline" || results[0].Properties["trigger"] != "background" {
+		t.Fatalf("unexpected result metadata: %+v", results[0])
+	}
+	if pending := store.pending(now, 0); len(pending) != 0 {
+		t.Fatalf("expected nothing pending after the report was taken, got %+v", pending)
+	}
+}
+
+func TestStoreReplaceDropsResultsMissingFromScan(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore(nil)
+	now := time.Now()
+	store.Replace(TriggerBackground, restricted, now, []Result{
+		{Policy: restricted, Rule: "runAsNonRoot", Result: v1alpha2.PolicyResultFail, Subject: workload("web")},
+		{Policy: restricted, Rule: "runAsNonRoot", Result: v1alpha2.PolicyResultPass, Subject: workload("api")},
+	})
+	store.Record(TriggerAdmission, now, []Result{
+		{Policy: restricted, Rule: "runAsNonRoot", Result: v1alpha2.PolicyResultFail, Subject: workload("web")},
+	})
+	store.pending(now, 0)
+
+	store.Replace(TriggerBackground, restricted, now, []Result{
+		{Policy: restricted, Rule: "runAsNonRoot", Result: v1alpha2.PolicyResultPass, Subject: workload("api")},
+	})
+	results := store.pending(now, 0)["team-a"]
+	if len(results) != 2 {
+		t.Fatalf("expected the admission result and the remaining scan result, got %+v", results)
+	}
+	if results[0].Properties["trigger"] != "admission" || results[1].Subjects[0].Name != "api" {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	store.Forget(restricted)
+	if results, ok := store.pending(now, 0)["team-a"]; !ok || len(results) != 0 {
+		t.Fatalf("expected an empty report after the policy was forgotten, got %+v", results)
+	}
+}
+
+func TestStoreOnlyRewritesChangedResults(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore(nil)
+	first := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := Result{Policy: restricted, Rule: "runAsNonRoot", Result: v1alpha2.PolicyResultPass, Subject: workload("web")}
+	store.Record(TriggerAdmission, first, []Result{result})
+	store.pending(first, 0)
+
+	store.Record(TriggerAdmission, first.Add(time.Minute), []Result{result})
+	if pending := store.pending(first.Add(time.Minute), 0); len(pending) != 0 {
+		t.Fatalf("expected an unchanged result not to rewrite the report, got %+v", pending)
+	}
+
+	result.Result = v1alpha2.PolicyResultFail
+	store.Record(TriggerAdmission, first.Add(2*time.Minute), []Result{result})
+	results := store.pending(first.Add(2*time.Minute), 0)["team-a"]
+	if len(results) != 1 || results[0].Timestamp.Seconds != first.Add(2*time.Minute).Unix() {
+		t.Fatalf("expected the changed result with a new timestamp, got %+v", results)
+	}
+}
+
+func TestStoreExpiresAdmissionResults(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore(nil)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.Record(TriggerAdmission, now, []Result{
+		{Policy: restricted, Rule: "runAsNonRoot", Result: v1alpha2.PolicyResultPass, Subject: workload("web")},
+	})
+	store.Replace(TriggerBackground, restricted, now, []Result{
+		{Policy: restricted, Rule: "runAsNonRoot", Result: v1alpha2.PolicyResultPass, Subject: workload("web")},
+	})
+	store.pending(now, time.Hour)
+
+	results := store.pending(now.Add(2*time.Hour), time.Hour)["team-a"]
+	if len(results) != 1 || results[0].Properties["trigger"] != "background" {
+		t.Fatalf("expected only the background result to survive, got %+v", results)
+	}
+}
+
+func TestStoreDiscardsAdmissionResultsUntilElected(t *testing.T) {
+	t.Parallel()
+
+	elected := make(chan struct{})
+	store := NewStore(elected)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := Result{Policy: restricted, Rule: "runAsNonRoot", Result: v1alpha2.PolicyResultFail, Subject: workload("web")}
+
+	store.Record(TriggerAdmission, now, []Result{result})
+	if reports := store.pending(now, 0); len(reports) != 0 {
+		t.Fatalf("expected admission results to be discarded before the replica is elected, got %+v", reports)
+	}
+
+	close(elected)
+	store.Record(TriggerAdmission, now, []Result{result})
+	if results := store.pending(now, 0)["team-a"]; len(results) != 1 {
+		t.Fatalf("expected the admission result to be kept once elected, got %+v", results)
+	}
+}
+
+func TestNilStoreDiscardsResults(t *testing.T) {
+	t.Parallel()
+
+	var store *Store
+	store.Record(TriggerAdmission, time.Now(), []Result{{Policy: restricted, Subject: workload("web")}})
+	store.Replace(TriggerBackground, restricted, time.Now(), nil)
+	store.Forget(restricted)
+}
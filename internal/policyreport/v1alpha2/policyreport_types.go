@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PolicyResult is the outcome of evaluating a policy rule against a resource.
+type PolicyResult string
+
+const (
+	// PolicyResultPass means the resource satisfies the rule.
+	PolicyResultPass PolicyResult = "pass"
+	// PolicyResultFail means the resource violates the rule.
+	PolicyResultFail PolicyResult = "fail"
+	// PolicyResultWarn means the resource violates a rule that is not enforced.
+	PolicyResultWarn PolicyResult = "warn"
+	// PolicyResultError means the rule could not be evaluated.
+	PolicyResultError PolicyResult = "error"
+	// PolicyResultSkip means the rule was not applied to the resource.
+	PolicyResultSkip PolicyResult = "skip"
+)
+
+// PolicyReportSummary counts the results of a report by outcome.
+type PolicyReportSummary struct {
+	Pass  int `json:"pass"`
+	Fail  int `json:"fail"`
+	Warn  int `json:"warn"`
+	Error int `json:"error"`
+	Skip  int `json:"skip"`
+}
+
+// PolicyReportResult is the result of a single policy rule for a set of resources.
+type PolicyReportResult struct {
+	// Source is the policy engine that produced the result.
+	Source string `json:"source,omitempty"`
+
+	// Policy is the name of the policy.
+	Policy string `json:"policy"`
+
+	// Rule is the name of the policy rule.
+	Rule string `json:"rule,omitempty"`
+
+	// Category groups related policies, e.g. by their kind.
+	Category string `json:"category,omitempty"`
+
+	// Timestamp is when the result was produced.
+	Timestamp metav1.Timestamp `json:"timestamp,omitempty"`
+
+	// Result is the outcome of the rule.
+	Result PolicyResult `json:"result,omitempty"`
+
+	// Scored indicates the result counts towards the compliance score.
+	Scored bool `json:"scored,omitempty"`
+
+	// Subjects are the resources the result applies to.
+	Subjects []corev1.ObjectReference `json:"resources,omitempty"`
+
+	// Description is a human readable explanation of the result.
+	Description string `json:"message,omitempty"`
+
+	// Properties carry additional engine specific information.
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PolicyReport holds the policy results of the resources in a namespace.
+type PolicyReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Summary counts the results by outcome.
+	Summary PolicyReportSummary `json:"summary,omitempty"`
+
+	// Results are the individual policy rule results.
+	Results []PolicyReportResult `json:"results,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PolicyReportList contains a list of PolicyReport.
+type PolicyReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PolicyReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PolicyReport{}, &PolicyReportList{})
+}
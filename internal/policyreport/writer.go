@@ -0,0 +1,162 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyreport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport/v1alpha2"
+)
+
+const (
+	// ReportName is the name of the PolicyReport written to each namespace.
+	ReportName = "platform-governance"
+	// Source identifies the operator as the producer of report results.
+	Source = "platform-governance-operator"
+
+	managedByLabelKey   = "app.kubernetes.io/managed-by"
+	managedByLabelValue = "platform-governance-operator"
+
+	// maxReportResults bounds the results of a single report, keeping the
+	// object well below the etcd size limit. The most severe results are kept.
+	maxReportResults = 1000
+)
+
+var writerlog = logf.Log.WithName("policyreport-writer")
+
+// +kubebuilder:rbac:groups=wgpolicyk8s.io,resources=policyreports,verbs=get;list;watch;create;update;patch
+
+// Writer periodically publishes the results of a Store as one PolicyReport per
+// namespace. It only runs on the elected leader, so a single replica writes
+// the reports.
+type Writer struct {
+	// Client writes the reports.
+	Client client.Client
+	// Reader reads existing reports directly from the API server, so the
+	// manager does not cache reports written by other tools.
+	Reader client.Reader
+	Store  *Store
+	// Interval is how often changed reports are written.
+	Interval time.Duration
+	// Retention is how long admission results are kept after they were last
+	// seen. Zero keeps them until the policy is deleted.
+	Retention time.Duration
+
+	crdMissing bool
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (w *Writer) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable and writes reports until ctx is done.
+func (w *Writer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			w.Flush(ctx, time.Now())
+		}
+	}
+}
+
+// Flush writes the report of every namespace whose results changed. Reports
+// that fail to be written are retried on the next flush.
+func (w *Writer) Flush(ctx context.Context, now time.Time) {
+	for namespace, results := range w.Store.pending(now, w.Retention) {
+		err := w.write(ctx, namespace, results)
+		switch {
+		case err == nil:
+			w.crdMissing = false
+			continue
+		case meta.IsNoMatchError(err):
+			if !w.crdMissing {
+				writerlog.Info("PolicyReport CRD (wgpolicyk8s.io/v1alpha2) is not installed; reports are kept until it is")
+				w.crdMissing = true
+			}
+		default:
+			writerlog.Error(err, "Failed to write PolicyReport", "namespace", namespace)
+		}
+		w.Store.markDirty(namespace)
+	}
+}
+
+func (w *Writer) write(ctx context.Context, namespace string, results []v1alpha2.PolicyReportResult) error {
+	if len(results) > maxReportResults {
+		results = results[:maxReportResults]
+	}
+
+	report := &v1alpha2.PolicyReport{}
+	err := w.Reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ReportName}, report)
+	if apierrors.IsNotFound(err) {
+		if len(results) == 0 {
+			return nil
+		}
+		report = &v1alpha2.PolicyReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ReportName,
+				Namespace: namespace,
+				Labels:    map[string]string{managedByLabelKey: managedByLabelValue},
+			},
+			Summary: summarize(results),
+			Results: results,
+		}
+		return w.Client.Create(ctx, report)
+	}
+	if err != nil {
+		return err
+	}
+	if report.Labels[managedByLabelKey] != managedByLabelValue {
+		return fmt.Errorf("PolicyReport %s/%s exists but is not managed by the operator", namespace, ReportName)
+	}
+
+	report.Summary = summarize(results)
+	report.Results = results
+	return w.Client.Update(ctx, report)
+}
+
+func summarize(results []v1alpha2.PolicyReportResult) v1alpha2.PolicyReportSummary {
+	var summary v1alpha2.PolicyReportSummary
+	for _, result := range results {
+		switch result.Result {
+		case v1alpha2.PolicyResultPass:
+			summary.Pass++
+		case v1alpha2.PolicyResultFail:
+			summary.Fail++
+		case v1alpha2.PolicyResultWarn:
+			summary.Warn++
+		case v1alpha2.PolicyResultError:
+			summary.Error++
+		case v1alpha2.PolicyResultSkip:
+			summary.Skip++
+		}
+	}
+	return summary
+}
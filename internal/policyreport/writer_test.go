@@ -0,0 +1,98 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyreport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport/v1alpha2"
+)
+
+func newWriter(t *testing.T, objects ...client.Object) *Writer {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := v1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add wgpolicyk8s.io/v1alpha2 to scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	return &Writer{Client: c, Reader: c, Store: NewStore(nil), Interval: time.Second}
+}
+
+func TestWriterFlushCreatesAndUpdatesReports(t *testing.T) {
+	t.Parallel()
+
+	w := newWriter(t)
+	ctx := context.Background()
+	now := time.Now()
+	w.Store.Record(TriggerAdmission, now, []Result{
+		{Policy: restricted, Rule: "runAsNonRoot", Result: v1alpha2.PolicyResultFail, Subject: workload("web")},
+		{Policy: restricted, Rule: "runAsNonRoot", Result: v1alpha2.PolicyResultPass, Subject: workload("api")},
+	})
+	w.Flush(ctx, now)
+
+	report := &v1alpha2.PolicyReport{}
+	if err := w.Client.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: ReportName}, report); err != nil {
+		t.Fatalf("expected the report to be created: %v", err)
+	}
+	if report.Summary != (v1alpha2.PolicyReportSummary{Pass: 1, Fail: 1}) || len(report.Results) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if report.Results[0].Result != v1alpha2.PolicyResultFail {
+		t.Fatalf("expected failures first, got %+v", report.Results)
+	}
+
+	w.Store.Forget(restricted)
+	w.Flush(ctx, now)
+	if err := w.Client.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: ReportName}, report); err != nil {
+		t.Fatalf("failed to get report: %v", err)
+	}
+	if len(report.Results) != 0 || report.Summary != (v1alpha2.PolicyReportSummary{}) {
+		t.Fatalf("expected an empty report, got %+v", report)
+	}
+}
+
+func TestWriterDoesNotOverwriteForeignReports(t *testing.T) {
+	t.Parallel()
+
+	foreign := &v1alpha2.PolicyReport{ObjectMeta: metav1.ObjectMeta{Name: ReportName, Namespace: "team-a"}}
+	w := newWriter(t, foreign)
+	ctx := context.Background()
+	now := time.Now()
+	w.Store.Record(TriggerAdmission, now, []Result{
+		{Policy: restricted, Rule: "runAsNonRoot", Result: v1alpha2.PolicyResultFail, Subject: workload("web")},
+	})
+	w.Flush(ctx, now)
+
+	report := &v1alpha2.PolicyReport{}
+	if err := w.Client.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: ReportName}, report); err != nil {
+		t.Fatalf("failed to get report: %v", err)
+	}
+	if len(report.Results) != 0 {
+		t.Fatalf("expected the foreign report to be left alone, got %+v", report.Results)
+	}
+	if pending := w.Store.pending(now, 0); len(pending["team-a"]) != 1 {
+		t.Fatalf("expected the namespace to be retried, got %+v", pending)
+	}
+}
@@ -0,0 +1,214 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/f3nr1r/platform-governance-operator/internal/governance"
+)
+
+const (
+	mediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+
+	// dockerHubRegistryHost is the API endpoint serving the docker.io registry.
+	dockerHubRegistryHost = "registry-1.docker.io"
+
+	// maxRegistryResponseBytes bounds manifests, blobs and token responses read
+	// from a registry. Signature manifests and payloads are a few KiB at most.
+	maxRegistryResponseBytes = 4 << 20
+)
+
+var (
+	// errRegistryNotFound is returned when the registry has no manifest or blob for a reference.
+	errRegistryNotFound = errors.New("not found in registry")
+
+	// registryTokenRealmHosts lists the hosts, besides the registry host itself,
+	// that may serve the bearer tokens of a registry.
+	registryTokenRealmHosts = map[string][]string{
+		dockerHubRegistryHost: {"auth.docker.io"},
+		"registry.gitlab.com": {"gitlab.com"},
+	}
+
+	authChallengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// registryRepository addresses a repository on an OCI distribution registry.
+type registryRepository struct {
+	host    string
+	baseURL string
+	name    string
+}
+
+// newRegistryRepository returns the registry repository an image is pulled
+// from. Insecure registries are contacted over plain HTTP.
+func newRegistryRepository(ref governance.ImageReference, insecure bool) registryRepository {
+	host := ref.Registry
+	if host == governance.DefaultImageRegistry {
+		host = dockerHubRegistryHost
+	}
+	scheme := "https"
+	if insecure {
+		scheme = "http"
+	}
+	return registryRepository{host: host, baseURL: scheme + "://" + host, name: ref.Repository}
+}
+
+// registryClient is a minimal, read-only OCI distribution API client. It
+// supports anonymous access and the bearer token flow public registries use
+// for anonymous pulls.
+type registryClient struct {
+	httpClient *http.Client
+}
+
+// manifest returns the raw image manifest for a tag or digest.
+func (c *registryClient) manifest(ctx context.Context, repo registryRepository, reference string) ([]byte, error) {
+	return c.fetch(ctx, repo, "/manifests/"+reference, []string{mediaTypeOCIManifest, mediaTypeDockerManifest})
+}
+
+// blob returns the content of a blob after checking it matches its digest.
+func (c *registryClient) blob(ctx context.Context, repo registryRepository, digest string) ([]byte, error) {
+	body, err := c.fetch(ctx, repo, "/blobs/"+digest, nil)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(body)
+	if got := "sha256:" + hex.EncodeToString(sum[:]); got != digest {
+		return nil, fmt.Errorf("blob %s has unexpected digest %s", digest, got)
+	}
+	return body, nil
+}
+
+func (c *registryClient) fetch(ctx context.Context, repo registryRepository, path string, accept []string) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, repo, path, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if err := checkRegistryResponse(resp); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxRegistryResponseBytes))
+}
+
+// do sends a request to the repository, retrying once with a bearer token
+// when the registry answers with an authentication challenge.
+func (c *registryClient) do(ctx context.Context, method string, repo registryRepository, path string, accept []string) (*http.Response, error) {
+	target := repo.baseURL + "/v2/" + repo.name + path
+	resp, err := c.send(ctx, method, target, accept, "")
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	_ = resp.Body.Close()
+	token, err := c.token(ctx, challenge, repo)
+	if err != nil {
+		return nil, err
+	}
+	return c.send(ctx, method, target, accept, "Bearer "+token)
+}
+
+func (c *registryClient) send(ctx context.Context, method, target string, accept []string, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(accept) > 0 {
+		req.Header.Set("Accept", strings.Join(accept, ", "))
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	return c.httpClient.Do(req)
+}
+
+// token requests an anonymous pull token from the realm named in a Bearer
+// authentication challenge. The realm is chosen by the registry, so it is only
+// contacted over HTTPS on the registry host or a known token service of the
+// registry, which keeps a registry from directing requests of the webhook to
+// arbitrary endpoints.
+func (c *registryClient) token(ctx context.Context, challenge string, repo registryRepository) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("registry requires unsupported authentication %q", scheme)
+	}
+	values := map[string]string{}
+	for _, match := range authChallengeParam.FindAllStringSubmatch(params, -1) {
+		values[match[1]] = match[2]
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Scheme == "" {
+		return "", fmt.Errorf("registry returned an invalid token realm %q", values["realm"])
+	}
+	if realm.Scheme != "https" || !isTrustedTokenRealmHost(repo.host, realm.Host) {
+		return "", fmt.Errorf("registry returned an untrusted token realm %q", values["realm"])
+	}
+
+	query := realm.Query()
+	if service := values["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := values["scope"]
+	if scope == "" {
+		scope = "repository:" + repo.name + ":pull"
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	resp, err := c.send(ctx, http.MethodGet, realm.String(), nil, "")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if err := checkRegistryResponse(resp); err != nil {
+		return "", fmt.Errorf("requesting registry token: %w", err)
+	}
+
+	var tokenResponse struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxRegistryResponseBytes)).Decode(&tokenResponse); err != nil {
+		return "", fmt.Errorf("decoding registry token: %w", err)
+	}
+	if tokenResponse.Token != "" {
+		return tokenResponse.Token, nil
+	}
+	if tokenResponse.AccessToken != "" {
+		return tokenResponse.AccessToken, nil
+	}
+	return "", fmt.Errorf("registry token response contained no token")
+}
+
+// isTrustedTokenRealmHost reports whether realmHost may serve the bearer
+// tokens of the registry at registryHost.
+func isTrustedTokenRealmHost(registryHost, realmHost string) bool {
+	if strings.EqualFold(realmHost, registryHost) {
+		return true
+	}
+	return slices.ContainsFunc(registryTokenRealmHosts[registryHost], func(host string) bool {
+		return strings.EqualFold(realmHost, host)
+	})
+}
+
+func checkRegistryResponse(resp *http.Response) error {
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case resp.StatusCode == http.StatusNotFound:
+		return errRegistryNotFound
+	default:
+		return fmt.Errorf("registry request %s %s returned %s", resp.Request.Method, resp.Request.URL.Redacted(), resp.Status)
+	}
+}
@@ -0,0 +1,226 @@
+package core
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+
+	"github.com/f3nr1r/platform-governance-operator/internal/governance"
+)
+
+const (
+	// cosignSignatureAnnotation holds the base64 encoded signature of a cosign
+	// signature layer.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// imageVerificationCacheSize bounds the number of cached verification results.
+	imageVerificationCacheSize = 4096
+	// verifiedImageTTL is how long a successful verification is reused.
+	verifiedImageTTL = time.Hour
+	// unverifiedImageTTL is how long a failed verification is reused, kept
+	// short so newly signed images are admitted quickly.
+	unverifiedImageTTL = time.Minute
+)
+
+// imageVerifier verifies container image signatures.
+type imageVerifier interface {
+	// Verify returns nil when image, which must be referenced by digest,
+	// carries a valid signature from any of keys.
+	Verify(ctx context.Context, image string, keys []crypto.PublicKey, insecure bool) error
+}
+
+// signatureError reports that an image has no valid signature, as opposed to
+// a failure to reach the registry. Only signature errors are cached.
+type signatureError struct {
+	reason string
+}
+
+func (e *signatureError) Error() string {
+	return e.reason
+}
+
+// cosignVerifier verifies cosign key-based signatures stored in the registry
+// next to the image (the <digest>.sig tag). Verification is fully offline with
+// respect to Sigstore: no transparency log (Rekor) or certificate authority
+// (Fulcio) is contacted, only the image registry.
+type cosignVerifier struct {
+	registry *registryClient
+	results  *cache.LRUExpireCache
+}
+
+func newCosignVerifier(httpClient *http.Client) *cosignVerifier {
+	return &cosignVerifier{
+		registry: &registryClient{httpClient: httpClient},
+		results:  cache.NewLRUExpireCache(imageVerificationCacheSize),
+	}
+}
+
+// Verify checks the cosign signatures of an image referenced by digest.
+// Results are cached per repository digest and key set, so repeated
+// admissions of the same image do not reach the registry again.
+func (v *cosignVerifier) Verify(ctx context.Context, image string, keys []crypto.PublicKey, insecure bool) error {
+	ref := governance.ParseImageReference(image)
+	repo := newRegistryRepository(ref, insecure)
+
+	digest := ref.Digest
+	if digest == "" {
+		return &signatureError{reason: fmt.Sprintf("image %s is not referenced by digest", image)}
+	}
+
+	cacheKey := ref.Name() + "@" + digest + "/" + publicKeysFingerprint(keys)
+	if cached, ok := v.results.Get(cacheKey); ok {
+		if cached == nil {
+			return nil
+		}
+		return cached.(error)
+	}
+
+	err := v.verifyDigest(ctx, repo, digest, keys)
+	var sigErr *signatureError
+	switch {
+	case err == nil:
+		v.results.Add(cacheKey, nil, verifiedImageTTL)
+	case errors.As(err, &sigErr):
+		v.results.Add(cacheKey, err, unverifiedImageTTL)
+	}
+	return err
+}
+
+func (v *cosignVerifier) verifyDigest(ctx context.Context, repo registryRepository, digest string, keys []crypto.PublicKey) error {
+	rawManifest, err := v.registry.manifest(ctx, repo, cosignSignatureTag(digest))
+	if errors.Is(err, errRegistryNotFound) {
+		return &signatureError{reason: fmt.Sprintf("no cosign signatures found for %s", digest)}
+	}
+	if err != nil {
+		return fmt.Errorf("fetching signatures: %w", err)
+	}
+
+	var manifest struct {
+		Layers []struct {
+			Digest      string            `json:"digest"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"layers"`
+	}
+	if err := json.Unmarshal(rawManifest, &manifest); err != nil {
+		return fmt.Errorf("decoding signature manifest: %w", err)
+	}
+
+	for _, layer := range manifest.Layers {
+		encoded, ok := layer.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		payload, err := v.registry.blob(ctx, repo, layer.Digest)
+		if err != nil {
+			return fmt.Errorf("fetching signature payload: %w", err)
+		}
+		if !slices.ContainsFunc(keys, func(key crypto.PublicKey) bool {
+			return verifySignature(key, payload, signature)
+		}) {
+			continue
+		}
+		if signedDigest(payload) == digest {
+			return nil
+		}
+	}
+	return &signatureError{reason: fmt.Sprintf("no signature for %s matches the trusted public keys", digest)}
+}
+
+// cosignSignatureTag returns the tag cosign stores an image's signatures under,
+// e.g. sha256:abc becomes sha256-abc.sig.
+func cosignSignatureTag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1) + ".sig"
+}
+
+// signedDigest returns the image digest a cosign simple signing payload
+// attests to, or "" when the payload cannot be decoded.
+func signedDigest(payload []byte) string {
+	var simpleSigning struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+	if err := json.Unmarshal(payload, &simpleSigning); err != nil {
+		return ""
+	}
+	return simpleSigning.Critical.Image.DockerManifestDigest
+}
+
+// verifySignature checks signature over payload the way cosign signs it:
+// ECDSA and RSA keys sign the SHA-256 digest of the payload, Ed25519 keys
+// sign the payload itself.
+func verifySignature(key crypto.PublicKey, payload, signature []byte) bool {
+	digest := sha256.Sum256(payload)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, payload, signature)
+	default:
+		return false
+	}
+}
+
+// parsePublicKeys decodes every PEM encoded PKIX public key in data.
+func parsePublicKeys(data []byte) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing public key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no PEM encoded public key found")
+	}
+	return keys, nil
+}
+
+// publicKeysFingerprint identifies a set of keys independently of their order.
+func publicKeysFingerprint(keys []crypto.PublicKey) string {
+	fingerprints := make([]string, 0, len(keys))
+	for _, key := range keys {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			continue
+		}
+		sum := sha256.Sum256(der)
+		fingerprints = append(fingerprints, hex.EncodeToString(sum[:]))
+	}
+	slices.Sort(fingerprints)
+	sum := sha256.Sum256([]byte(strings.Join(fingerprints, ",")))
+	return hex.EncodeToString(sum[:])
+}
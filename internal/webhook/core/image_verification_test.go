@@ -0,0 +1,268 @@
+package core
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/f3nr1r/platform-governance-operator/internal/governance"
+)
+
+// testRegistry is a local stand-in for an OCI registry serving a single
+// repository with cosign signatures.
+type testRegistry struct {
+	server    *httptest.Server
+	manifests map[string][]byte
+	blobs     map[string][]byte
+	requests  atomic.Int32
+	// requireToken makes the registry demand an anonymous bearer token.
+	requireToken bool
+}
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	t.Helper()
+
+	r := &testRegistry{manifests: map[string][]byte{}, blobs: map[string][]byte{}}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+// newTLSTestRegistry returns a test registry served over HTTPS, to be reached
+// with the client of its server.
+func newTLSTestRegistry(t *testing.T) *testRegistry {
+	t.Helper()
+
+	r := &testRegistry{manifests: map[string][]byte{}, blobs: map[string][]byte{}}
+	r.server = httptest.NewTLSServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *testRegistry) host() string {
+	return strings.TrimPrefix(strings.TrimPrefix(r.server.URL, "http://"), "https://")
+}
+
+func (r *testRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	r.requests.Add(1)
+	if req.URL.Path == "/token" {
+		_, _ = fmt.Fprint(w, `{"token":"anonymous"}`)
+		return
+	}
+	if r.requireToken && req.Header.Get("Authorization") != "Bearer anonymous" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test"`, r.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	const prefix = "/v2/team/app/"
+	path := strings.TrimPrefix(req.URL.Path, prefix)
+	switch {
+	case strings.HasPrefix(path, "manifests/"):
+		body, ok := r.manifests[strings.TrimPrefix(path, "manifests/")]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		sum := sha256.Sum256(body)
+		w.Header().Set("Docker-Content-Digest", "sha256:"+hex.EncodeToString(sum[:]))
+		_, _ = w.Write(body)
+	case strings.HasPrefix(path, "blobs/"):
+		body, ok := r.blobs[strings.TrimPrefix(path, "blobs/")]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		_, _ = w.Write(body)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+// pushImage stores an image manifest under tag and returns its digest.
+func (r *testRegistry) pushImage(tag string) string {
+	manifest := []byte(fmt.Sprintf(`{"schemaVersion":2,"mediaType":%q,"layers":[],"annotations":{"tag":%q}}`, mediaTypeOCIManifest, tag))
+	sum := sha256.Sum256(manifest)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+	r.manifests[tag] = manifest
+	r.manifests[digest] = manifest
+	return digest
+}
+
+// sign stores a cosign signature of digest made with key.
+func (r *testRegistry) sign(t *testing.T, digest string, key *ecdsa.PrivateKey) {
+	t.Helper()
+
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"%s/team/app"},`+
+		`"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, r.host(), digest))
+	payloadSum := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, payloadSum[:])
+	if err != nil {
+		t.Fatalf("signing payload: %v", err)
+	}
+	payloadDigest := "sha256:" + hex.EncodeToString(payloadSum[:])
+	r.blobs[payloadDigest] = payload
+
+	manifest, err := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"mediaType":     mediaTypeOCIManifest,
+		"layers": []map[string]any{{
+			"mediaType":   "application/vnd.dev.cosign.simplesigning.v1+json",
+			"digest":      payloadDigest,
+			"size":        len(payload),
+			"annotations": map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("encoding signature manifest: %v", err)
+	}
+	r.manifests[cosignSignatureTag(digest)] = manifest
+}
+
+func newTestSigningKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshalling public key: %v", err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestCosignVerifierVerifiesSignedImageAndCachesByDigest(t *testing.T) {
+	t.Parallel()
+
+	registry := newTestRegistry(t)
+	signer, publicKeyPEM := newTestSigningKey(t)
+	digest := registry.pushImage("v1")
+	registry.sign(t, digest, signer)
+
+	keys, err := parsePublicKeys(publicKeyPEM)
+	if err != nil {
+		t.Fatalf("parsing public key: %v", err)
+	}
+	verifier := newCosignVerifier(http.DefaultClient)
+	image := registry.host() + "/team/app@" + digest
+
+	if err := verifier.Verify(context.Background(), image, keys, true); err != nil {
+		t.Fatalf("expected signed image to verify, got %v", err)
+	}
+	requests := registry.requests.Load()
+	if err := verifier.Verify(context.Background(), image, keys, true); err != nil {
+		t.Fatalf("expected cached verification to succeed, got %v", err)
+	}
+	if got := registry.requests.Load(); got != requests {
+		t.Fatalf("expected cached verification not to contact the registry, got %d new request(s)", got-requests)
+	}
+}
+
+func TestCosignVerifierUsesBearerToken(t *testing.T) {
+	t.Parallel()
+
+	registry := newTLSTestRegistry(t)
+	registry.requireToken = true
+	signer, publicKeyPEM := newTestSigningKey(t)
+	digest := registry.pushImage("v1")
+	registry.sign(t, digest, signer)
+
+	keys, err := parsePublicKeys(publicKeyPEM)
+	if err != nil {
+		t.Fatalf("parsing public key: %v", err)
+	}
+	verifier := newCosignVerifier(registry.server.Client())
+	if err := verifier.Verify(context.Background(), registry.host()+"/team/app@"+digest, keys, false); err != nil {
+		t.Fatalf("expected image to verify with a bearer token, got %v", err)
+	}
+	if err := verifier.Verify(context.Background(), registry.host()+"/team/app:v1", keys, false); err == nil {
+		t.Fatalf("expected an image referenced by tag not to verify")
+	}
+}
+
+func TestRegistryClientRejectsUntrustedTokenRealms(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { requests.Add(1) }))
+	t.Cleanup(server.Close)
+
+	client := &registryClient{httpClient: server.Client()}
+	repo := newRegistryRepository(governance.ParseImageReference("registry.example.com/team/app"), false)
+	for _, realm := range []string{
+		server.URL + "/token",
+		"http://registry.example.com/token",
+		"https://169.254.169.254/latest/meta-data",
+		"https://registry.example.com.attacker.test/token",
+	} {
+		if _, err := client.token(context.Background(), fmt.Sprintf(`Bearer realm=%q`, realm), repo); err == nil ||
+			!strings.Contains(err.Error(), "untrusted token realm") {
+			t.Fatalf("expected realm %s to be rejected, got %v", realm, err)
+		}
+	}
+	if requests.Load() != 0 {
+		t.Fatalf("expected no request to an untrusted realm")
+	}
+
+	if !isTrustedTokenRealmHost(dockerHubRegistryHost, "auth.docker.io") {
+		t.Fatalf("expected the Docker Hub token service to be trusted")
+	}
+}
+
+func TestCosignVerifierRejectsUntrustedAndUnsignedImages(t *testing.T) {
+	t.Parallel()
+
+	registry := newTestRegistry(t)
+	signer, _ := newTestSigningKey(t)
+	_, otherPublicKeyPEM := newTestSigningKey(t)
+	signed := registry.pushImage("signed")
+	registry.sign(t, signed, signer)
+	unsigned := registry.pushImage("unsigned")
+
+	keys, err := parsePublicKeys(otherPublicKeyPEM)
+	if err != nil {
+		t.Fatalf("parsing public key: %v", err)
+	}
+	verifier := newCosignVerifier(http.DefaultClient)
+	for _, digest := range []string{signed, unsigned} {
+		err := verifier.Verify(context.Background(), registry.host()+"/team/app@"+digest, keys, true)
+		var sigErr *signatureError
+		if !errors.As(err, &sigErr) {
+			t.Fatalf("expected signature error for %s, got %v", digest, err)
+		}
+	}
+}
+
+func TestParsePublicKeysRejectsNonKeyData(t *testing.T) {
+	t.Parallel()
+
+	if _, err := parsePublicKeys([]byte("not a key")); err == nil {
+		t.Fatalf("expected an error for data without a PEM public key")
+	}
+	_, first := newTestSigningKey(t)
+	_, second := newTestSigningKey(t)
+	keys, err := parsePublicKeys(append(first, second...))
+	if err != nil || len(keys) != 2 {
+		t.Fatalf("expected two keys, got %d (%v)", len(keys), err)
+	}
+	if publicKeysFingerprint([]crypto.PublicKey{keys[0], keys[1]}) != publicKeysFingerprint([]crypto.PublicKey{keys[1], keys[0]}) {
+		t.Fatalf("expected key set fingerprint to be order independent")
+	}
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// podBaselineViolationsTotal counts SecurityBaseline violations observed by the
+// Pod validating webhook, labelled by the enforcement action that was applied.
+var podBaselineViolationsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "platform_governance_pod_baseline_violations_total",
+		Help: "Number of Pod admission requests that violated a SecurityBaseline, by enforcement action.",
+	},
+	[]string{"namespace", "baseline", "action"},
+)
+
+// workloadBaselineViolationsTotal counts SecurityBaseline violations found in
+// the Pod templates of workloads, labelled by the enforcement action that was
+// applied and the workload kind.
+var workloadBaselineViolationsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "platform_governance_workload_baseline_violations_total",
+		Help: "Number of SecurityBaseline violations in workload Pod templates, by enforcement action and workload kind.",
+	},
+	[]string{"namespace", "baseline", "action", "kind"},
+)
+
+// imageVerificationFailuresTotal counts container images that failed signature
+// verification against an ImageVerificationPolicy, labelled by the enforcement
+// action that was applied.
+var imageVerificationFailuresTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "platform_governance_image_verification_failures_total",
+		Help: "Number of container images that failed ImageVerificationPolicy signature verification, by enforcement action.",
+	},
+	[]string{"namespace", "policy", "action"},
+)
+
+// podBaselineExemptionsTotal counts SecurityBaseline violations that were
+// admitted because an active PolicyException exempted them.
+var podBaselineExemptionsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "platform_governance_pod_baseline_exemptions_total",
+		Help: "Number of SecurityBaseline violations exempted by a PolicyException.",
+	},
+	[]string{"namespace", "baseline", "exception"},
+)
+
+// podResourceBoundsViolationsTotal counts containers whose requests or limits
+// fell outside the resource bounds of a WorkloadPolicy or
+// ClusterWorkloadPolicy.
+var podResourceBoundsViolationsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "platform_governance_pod_resource_bounds_violations_total",
+		Help: "Number of container requests and limits outside the resource bounds of a WorkloadPolicy, by policy kind.",
+	},
+	[]string{"namespace", "kind", "policy"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(podBaselineViolationsTotal, workloadBaselineViolationsTotal, imageVerificationFailuresTotal,
+		podBaselineExemptionsTotal, podResourceBoundsViolationsTotal)
+}
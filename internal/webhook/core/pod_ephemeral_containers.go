@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/f3nr1r/platform-governance-operator/internal/governance"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport"
+)
+
+// ephemeralContainersSubresource is the Pod subresource ephemeral containers
+// are added through, e.g. by kubectl debug.
+const ephemeralContainersSubresource = "ephemeralcontainers"
+
+// runsContainer reports whether the Pod, which may be nil, has a container of
+// the same name running the same image.
+func runsContainer(pod *corev1.Pod, c *corev1.Container) bool {
+	if pod == nil {
+		return false
+	}
+	found := false
+	governance.ForEachContainer(pod, func(existing *corev1.Container, _ *field.Path) {
+		found = found || (existing.Name == c.Name && existing.Image == c.Image)
+	})
+	return found
+}
+
+// remediateEphemeralContainers injects the secure defaults of baselines with
+// remediation Mutate into the ephemeral containers a request adds to a running
+// Pod. Nothing else of a running Pod may change, so WorkloadPolicy and
+// TelemetryProfile defaults and Pod-level settings are left alone.
+func (m *PodMutator) remediateEphemeralContainers(ctx context.Context, req admission.Request, pod *corev1.Pod,
+	mutation *podMutation) admission.Response {
+	existing := &corev1.Pod{}
+	if err := m.decoder.DecodeRaw(req.OldObject, existing); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	added := map[int]string{}
+	for i := range pod.Spec.EphemeralContainers {
+		if !runsContainer(existing, (*corev1.Container)(&pod.Spec.EphemeralContainers[i].EphemeralContainerCommon)) {
+			added[i] = field.NewPath("spec", "ephemeralContainers").Index(i).String() + "."
+		}
+	}
+	if len(added) == 0 {
+		return admission.Allowed("No ephemeral containers added")
+	}
+
+	remediated := pod.DeepCopy()
+	remediations, err := governance.RemediateBaselines(ctx, m.Client, req.Namespace, remediated, &req.UserInfo)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	var applied []governance.BaselineRemediation
+	for _, remediation := range remediations {
+		remediation.Fields = slices.DeleteFunc(remediation.Fields, func(path string) bool {
+			for _, prefix := range added {
+				if strings.HasPrefix(path, prefix) {
+					return false
+				}
+			}
+			return true
+		})
+		if len(remediation.Fields) > 0 {
+			applied = append(applied, remediation)
+		}
+	}
+	if len(applied) == 0 {
+		return admission.Allowed("No mutations applied")
+	}
+
+	for i := range added {
+		pod.Spec.EphemeralContainers[i].SecurityContext = remediated.Spec.EphemeralContainers[i].SecurityContext
+	}
+	m.recordRemediations(applied, mutation)
+	if m.Reports != nil {
+		m.Reports.Record(policyreport.TriggerAdmission, time.Now(), mutation.results)
+	}
+
+	marshaledPod, err := json.Marshal(pod)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledPod)
+}
@@ -0,0 +1,182 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// runningPod returns a Pod admitted before the read-only root filesystem
+// requirement existed.
+func runningPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx:1.27"}}},
+	}
+}
+
+// newEphemeralContainersRequest returns a request of user adding the
+// ephemeral container to the running Pod.
+func newEphemeralContainersRequest(t *testing.T, existing *corev1.Pod, container corev1.EphemeralContainer,
+	user authenticationv1.UserInfo) admission.Request {
+	t.Helper()
+
+	pod := existing.DeepCopy()
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, container)
+	req := newWorkloadRequest(t, admissionv1.Update, metav1.GroupVersionKind{Version: "v1", Kind: "Pod"}, pod, existing)
+	req.SubResource = ephemeralContainersSubresource
+	req.UserInfo = user
+	return req
+}
+
+func debugContainer(securityContext *corev1.SecurityContext) corev1.EphemeralContainer {
+	return corev1.EphemeralContainer{EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+		Name: "debugger", Image: "busybox:1.36", SecurityContext: securityContext,
+	}}
+}
+
+func newEphemeralTestValidator(t *testing.T, objects ...client.Object) *PodValidator {
+	t.Helper()
+
+	scheme := newWebhookTestScheme(t)
+	return &PodValidator{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		Recorder: record.NewFakeRecorder(10),
+		decoder:  admission.NewDecoder(scheme),
+	}
+}
+
+func TestPodValidatorEphemeralContainersReportsOnlyIntroducedViolations(t *testing.T) {
+	t.Parallel()
+
+	validator := newEphemeralTestValidator(t, &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "read-only", Namespace: "team-a"},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{ReadOnlyRootFilesystem: ptr.To(true)},
+	})
+	user := authenticationv1.UserInfo{Username: "alice"}
+
+	resp := validator.Handle(context.Background(), newEphemeralContainersRequest(t, runningPod(), debugContainer(nil), user))
+	if resp.Allowed {
+		t.Fatal("expected the writable ephemeral container to be denied")
+	}
+	if !strings.Contains(resp.Result.Message, "spec.ephemeralContainers[0].securityContext.readOnlyRootFilesystem") ||
+		strings.Contains(resp.Result.Message, "spec.containers[0]") {
+		t.Fatalf("expected only the ephemeral container to be reported, got %q", resp.Result.Message)
+	}
+
+	readOnly := debugContainer(&corev1.SecurityContext{ReadOnlyRootFilesystem: ptr.To(true)})
+	if resp := validator.Handle(context.Background(), newEphemeralContainersRequest(t, runningPod(), readOnly, user)); !resp.Allowed {
+		t.Fatalf("expected the existing violation of the running Pod not to block debugging: %s", resp.Result.Message)
+	}
+}
+
+func TestPodValidatorDebugBaselineReplacesBaselinesOfItsKind(t *testing.T) {
+	t.Parallel()
+
+	validator := newEphemeralTestValidator(t,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&platformv1alpha1.ClusterSecurityBaseline{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-privileged"},
+			Spec: platformv1alpha1.ClusterSecurityBaselineSpec{
+				SecurityBaselineSpec: platformv1alpha1.SecurityBaselineSpec{DisallowPrivileged: ptr.To(true)},
+			},
+		},
+		&platformv1alpha1.SecurityBaseline{
+			ObjectMeta: metav1.ObjectMeta{Name: "read-only", Namespace: "team-a"},
+			Spec:       platformv1alpha1.SecurityBaselineSpec{ReadOnlyRootFilesystem: ptr.To(true)},
+		},
+		&platformv1alpha1.SecurityBaseline{
+			ObjectMeta: metav1.ObjectMeta{Name: "break-glass", Namespace: "team-a"},
+			Spec: platformv1alpha1.SecurityBaselineSpec{
+				DisallowPrivilegeEscalation: ptr.To(true),
+				Debug:                       &platformv1alpha1.DebugPolicy{Groups: []string{"sre"}},
+			},
+		},
+	)
+	sre := authenticationv1.UserInfo{Username: "bob", Groups: []string{"system:authenticated", "sre"}}
+	noEscalation := func(sc *corev1.SecurityContext) *corev1.SecurityContext {
+		sc.AllowPrivilegeEscalation = ptr.To(false)
+		return sc
+	}
+
+	if resp := validator.Handle(context.Background(), newEphemeralContainersRequest(t, runningPod(),
+		debugContainer(noEscalation(&corev1.SecurityContext{})), sre)); !resp.Allowed {
+		t.Fatalf("expected the debug baseline to replace the read-only baseline for SREs: %s", resp.Result.Message)
+	}
+
+	resp := validator.Handle(context.Background(), newEphemeralContainersRequest(t, runningPod(),
+		debugContainer(&corev1.SecurityContext{}), sre))
+	if resp.Allowed || !strings.Contains(resp.Result.Message, "[break-glass]") {
+		t.Fatalf("expected the debug baseline itself to be enforced, got %+v", resp.Result)
+	}
+
+	resp = validator.Handle(context.Background(), newEphemeralContainersRequest(t, runningPod(),
+		debugContainer(noEscalation(&corev1.SecurityContext{Privileged: ptr.To(true)})), sre))
+	if resp.Allowed || !strings.Contains(resp.Result.Message, "[no-privileged]") {
+		t.Fatalf("expected a namespaced debug baseline not to relax cluster baselines, got %+v", resp.Result)
+	}
+
+	resp = validator.Handle(context.Background(), newEphemeralContainersRequest(t, runningPod(),
+		debugContainer(noEscalation(&corev1.SecurityContext{})), authenticationv1.UserInfo{Username: "mallory"}))
+	if resp.Allowed || !strings.Contains(resp.Result.Message, "[read-only]") {
+		t.Fatalf("expected users outside the debug policy to get the regular baselines, got %+v", resp.Result)
+	}
+}
+
+func TestPodValidatorIgnoresDebugBaselinesForPods(t *testing.T) {
+	t.Parallel()
+
+	validator := newEphemeralTestValidator(t, &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "break-glass", Namespace: "team-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			ReadOnlyRootFilesystem: ptr.To(true),
+			Debug:                  &platformv1alpha1.DebugPolicy{Users: []string{"bob"}},
+		},
+	})
+	req := newAdmissionRequest(t, "team-a", runningPod())
+	req.UserInfo = authenticationv1.UserInfo{Username: "bob"}
+	if resp := validator.Handle(context.Background(), req); !resp.Allowed {
+		t.Fatalf("expected a debug baseline not to apply to Pods: %s", resp.Result.Message)
+	}
+}
+
+func TestPodMutatorRemediatesOnlyAddedEphemeralContainers(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	recorder := record.NewFakeRecorder(10)
+	mutator := &PodMutator{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}, remediatingBaseline()).Build(),
+		Recorder: recorder,
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	resp := mutator.Handle(context.Background(), newEphemeralContainersRequest(t, runningPod(), debugContainer(nil),
+		authenticationv1.UserInfo{Username: "alice"}))
+	if !resp.Allowed || len(resp.Patches) == 0 {
+		t.Fatalf("expected the ephemeral container to be remediated, got %+v", resp)
+	}
+	for _, patch := range resp.Patches {
+		if !strings.HasPrefix(patch.Path, "/spec/ephemeralContainers/0") {
+			t.Fatalf("expected only the added ephemeral container to change, got patch %s %s", patch.Operation, patch.Path)
+		}
+	}
+	event := <-recorder.Events
+	if !strings.Contains(event, "spec.ephemeralContainers[0].securityContext.readOnlyRootFilesystem") ||
+		strings.Contains(event, "spec.securityContext") {
+		t.Fatalf("expected the event to list only ephemeral container fields, got %q", event)
+	}
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/governance"
+)
+
+// evaluateImageVerificationPolicy verifies the signature of every container
+// image covered by the policy. Images that cannot be verified, including when
+// the policy's keys cannot be loaded or the registry is unreachable, are
+// reported as violations so the policy's enforcement action decides whether
+// the Pod is admitted. Covered images must be referenced by digest: a tag may
+// be moved to an unverified image after admission, before the kubelet pulls
+// it. Containers that already run in existing, the Pod before an update of
+// its ephemeral containers, are not verified again.
+func (v *PodValidator) evaluateImageVerificationPolicy(ctx context.Context, pod, existing *corev1.Pod,
+	policy *platformv1alpha1.ImageVerificationPolicy) []governance.Violation {
+	var covered []imageContainer
+	governance.ForEachContainer(pod, func(c *corev1.Container, fldPath *field.Path) {
+		ref := governance.ParseImageReference(c.Image)
+		if slices.ContainsFunc(policy.Spec.Images, ref.HasRepositoryPrefix) && !runsContainer(existing, c) {
+			covered = append(covered, imageContainer{name: c.Name, image: c.Image, fldPath: fldPath})
+		}
+	})
+	if len(covered) == 0 {
+		return nil
+	}
+
+	keys, keysErr := v.loadPublicKeys(ctx, policy)
+
+	var violations []governance.Violation
+	for _, c := range covered {
+		ref := governance.ParseImageReference(c.image)
+		if ref.Digest == "" {
+			violations = append(violations, governance.Violation{
+				Baseline:    policy.Name,
+				Container:   c.name,
+				Field:       c.fldPath.Child("image"),
+				Message:     fmt.Sprintf("image %q must be referenced by digest so that the verified image is the one that runs", c.image),
+				Remediation: fmt.Sprintf("reference the image as %s@<digest> using the digest of its signed manifest", ref.Name()),
+			})
+			continue
+		}
+
+		err := keysErr
+		if err == nil {
+			insecure := slices.Contains(policy.Spec.InsecureRegistries, ref.Registry)
+			err = v.verifier.Verify(ctx, c.image, keys, insecure)
+		}
+		if err == nil {
+			continue
+		}
+		violations = append(violations, governance.Violation{
+			Baseline:    policy.Name,
+			Container:   c.name,
+			Field:       c.fldPath.Child("image"),
+			Message:     fmt.Sprintf("image %q signature verification failed: %v", c.image, err),
+			Remediation: "sign the image with cosign using a key trusted by the policy",
+		})
+	}
+	return violations
+}
+
+// imageContainer is a container image covered by an ImageVerificationPolicy.
+type imageContainer struct {
+	name    string
+	image   string
+	fldPath *field.Path
+}
+
+// loadPublicKeys reads every public key referenced by the policy from Secrets
+// in the key namespace and ConfigMaps in the policy namespace.
+func (v *PodValidator) loadPublicKeys(ctx context.Context, policy *platformv1alpha1.ImageVerificationPolicy) ([]crypto.PublicKey, error) {
+	reader := v.APIReader
+	if reader == nil {
+		reader = v.Client
+	}
+
+	var keys []crypto.PublicKey
+	for _, source := range policy.Spec.PublicKeys {
+		var (
+			data []byte
+			err  error
+		)
+		switch {
+		case source.SecretKeyRef != nil:
+			data, err = readSecretKey(ctx, reader, v.KeyNamespace, source.SecretKeyRef)
+		case source.ConfigMapKeyRef != nil:
+			data, err = readConfigMapKey(ctx, reader, policy.Namespace, source.ConfigMapKeyRef)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, err := parsePublicKeys(data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, parsed...)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("policy has no usable public keys")
+	}
+	return keys, nil
+}
+
+func readSecretKey(ctx context.Context, reader client.Reader, namespace string, selector *corev1.SecretKeySelector) ([]byte, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("reading public key Secret %s: no namespace is configured for key Secrets", selector.Name)
+	}
+	var secret corev1.Secret
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: selector.Name}, &secret); err != nil {
+		return nil, fmt.Errorf("reading public key Secret %s: %w", selector.Name, err)
+	}
+	data, ok := secret.Data[selector.Key]
+	if !ok {
+		return nil, fmt.Errorf("public key Secret %s has no key %q", selector.Name, selector.Key)
+	}
+	return data, nil
+}
+
+func readConfigMapKey(ctx context.Context, reader client.Reader, namespace string, selector *corev1.ConfigMapKeySelector) ([]byte, error) {
+	var configMap corev1.ConfigMap
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: selector.Name}, &configMap); err != nil {
+		return nil, fmt.Errorf("reading public key ConfigMap %s: %w", selector.Name, err)
+	}
+	data, ok := configMap.Data[selector.Key]
+	if !ok {
+		return nil, fmt.Errorf("public key ConfigMap %s has no key %q", selector.Name, selector.Key)
+	}
+	return []byte(data), nil
+}
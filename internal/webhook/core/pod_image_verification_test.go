@@ -0,0 +1,168 @@
+package core
+
+import (
+	"context"
+	"crypto"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+func newImageVerificationPolicy(registryHost string, action platformv1alpha1.EnforcementAction) *platformv1alpha1.ImageVerificationPolicy {
+	return &platformv1alpha1.ImageVerificationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "signed-images", Namespace: "team-a"},
+		Spec: platformv1alpha1.ImageVerificationPolicySpec{
+			EnforcementAction: action,
+			Images:            []string{registryHost + "/team"},
+			PublicKeys: []platformv1alpha1.PublicKeySource{{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "cosign"},
+					Key:                  "cosign.pub",
+				},
+			}},
+			InsecureRegistries: []string{registryHost},
+		},
+	}
+}
+
+func TestPodValidatorVerifiesImageSignatures(t *testing.T) {
+	t.Parallel()
+
+	registry := newTestRegistry(t)
+	signer, publicKeyPEM := newTestSigningKey(t)
+	signed := registry.pushImage("signed")
+	registry.sign(t, signed, signer)
+	unsigned := registry.pushImage("unsigned")
+
+	scheme := newWebhookTestScheme(t)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cosign", Namespace: "platform-system"},
+		Data:       map[string][]byte{"cosign.pub": publicKeyPEM},
+	}
+	policy := newImageVerificationPolicy(registry.host(), platformv1alpha1.EnforcementActionEnforce)
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, policy).Build()
+	validator := &PodValidator{
+		Client:       cl,
+		KeyNamespace: "platform-system",
+		Recorder:     record.NewFakeRecorder(10),
+		decoder:      admission.NewDecoder(scheme),
+		verifier:     newCosignVerifier(http.DefaultClient),
+	}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+		{Name: "app", Image: registry.host() + "/team/app@" + signed},
+		{Name: "public", Image: "nginx:1.27"},
+	}}}
+	if resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod)); !resp.Allowed {
+		t.Fatalf("expected pod with signed image to be allowed: %s", resp.Result.Message)
+	}
+
+	pod.Spec.Containers[0].Image = registry.host() + "/team/app:signed"
+	resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod))
+	if resp.Allowed || !strings.Contains(resp.Result.Message, "must be referenced by digest") {
+		t.Fatalf("expected pod with a tagged image to be denied even though the tag is signed, got %+v", resp.Result)
+	}
+
+	pod.Spec.Containers[0].Image = registry.host() + "/team/app@" + unsigned
+	resp = validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod))
+	if resp.Allowed {
+		t.Fatalf("expected pod with unsigned image to be denied")
+	}
+	if !strings.Contains(resp.Result.Message, "team/app@"+unsigned) {
+		t.Fatalf("expected denial to name the image, got %q", resp.Result.Message)
+	}
+	if len(resp.Result.Details.Causes) != 1 || resp.Result.Details.Causes[0].Field != "spec.containers[0].image" {
+		t.Fatalf("expected a single cause for spec.containers[0].image, got %+v", resp.Result.Details.Causes)
+	}
+}
+
+func TestPodValidatorImageVerificationWarnModeAndMissingKey(t *testing.T) {
+	t.Parallel()
+
+	registry := newTestRegistry(t)
+	scheme := newWebhookTestScheme(t)
+	policy := newImageVerificationPolicy(registry.host(), platformv1alpha1.EnforcementActionWarn)
+	// Key Secrets are only read from the key namespace, never from the policy
+	// namespace.
+	_, publicKeyPEM := newTestSigningKey(t)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cosign", Namespace: "team-a"},
+		Data:       map[string][]byte{"cosign.pub": publicKeyPEM},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, policy).Build()
+	validator := &PodValidator{
+		Client:       cl,
+		KeyNamespace: "platform-system",
+		Recorder:     record.NewFakeRecorder(10),
+		decoder:      admission.NewDecoder(scheme),
+		verifier:     newCosignVerifier(http.DefaultClient),
+	}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+		{Name: "app", Image: registry.host() + "/team/app@" + registry.pushImage("v1")},
+	}}}
+	resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod))
+	if !resp.Allowed {
+		t.Fatalf("expected pod to be allowed in Warn mode: %s", resp.Result.Message)
+	}
+	if len(resp.Warnings) != 1 || !strings.Contains(resp.Warnings[0], "public key Secret cosign") {
+		t.Fatalf("expected a warning about the missing key Secret, got %v", resp.Warnings)
+	}
+	if registry.requests.Load() != 0 {
+		t.Fatalf("expected no registry requests without usable keys")
+	}
+}
+
+// deadlineRecordingVerifier records the deadlines of the contexts images are
+// verified with.
+type deadlineRecordingVerifier struct {
+	deadlines []time.Time
+}
+
+func (v *deadlineRecordingVerifier) Verify(ctx context.Context, _ string, _ []crypto.PublicKey, _ bool) error {
+	deadline, _ := ctx.Deadline()
+	v.deadlines = append(v.deadlines, deadline)
+	return nil
+}
+
+func TestPodValidatorVerifiesImagesWithinOneDeadline(t *testing.T) {
+	t.Parallel()
+
+	_, publicKeyPEM := newTestSigningKey(t)
+	scheme := newWebhookTestScheme(t)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cosign", Namespace: "platform-system"},
+		Data:       map[string][]byte{"cosign.pub": publicKeyPEM},
+	}
+	verifier := &deadlineRecordingVerifier{}
+	validator := &PodValidator{
+		Client:       fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, newImageVerificationPolicy("registry.example.com", "")).Build(),
+		KeyNamespace: "platform-system",
+		Recorder:     record.NewFakeRecorder(10),
+		decoder:      admission.NewDecoder(scheme),
+		verifier:     verifier,
+	}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+		{Name: "app", Image: "registry.example.com/team/app@sha256:0123456789abcdef"},
+		{Name: "sidecar", Image: "registry.example.com/team/sidecar@sha256:0123456789abcdef"},
+	}}}
+	if resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod)); !resp.Allowed {
+		t.Fatalf("expected pod to be allowed: %s", resp.Result.Message)
+	}
+	if len(verifier.deadlines) != 2 || !verifier.deadlines[0].Equal(verifier.deadlines[1]) {
+		t.Fatalf("expected both images to be verified under the same deadline, got %v", verifier.deadlines)
+	}
+	if deadline := verifier.deadlines[0]; deadline.IsZero() || deadline.After(time.Now().Add(podValidationTimeout)) {
+		t.Fatalf("expected a deadline within %s of admission, got %v", podValidationTimeout, deadline)
+	}
+}
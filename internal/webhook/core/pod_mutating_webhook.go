@@ -4,11 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
-	"sort"
+	"slices"
+	"strings"
+	"time"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -16,13 +21,19 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/governance"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport/v1alpha2"
 )
 
-// PodMutator mutates Pods based on WorkloadPolicy
+// PodMutator mutates Pods based on WorkloadPolicies and TelemetryProfiles
 type PodMutator struct {
 	Client   client.Client
 	Recorder record.EventRecorder
-	decoder  admission.Decoder
+	// Reports receives a PolicyReport result for every policy applied to a
+	// Pod. Reporting is disabled when nil.
+	Reports *policyreport.Store
+	decoder admission.Decoder
 }
 
 // InjectDecoder injects the decoder for admission requests.
@@ -32,10 +43,17 @@ func (m *PodMutator) InjectDecoder(d admission.Decoder) error {
 }
 
 // +kubebuilder:webhook:path=/mutate-core-v1-pod,mutating=true,failurePolicy=fail,sideEffects=None,groups="",resources=pods,verbs=create;update,versions=v1,name=mpod.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-core-v1-pod,mutating=true,failurePolicy=fail,sideEffects=None,groups="",resources=pods/ephemeralcontainers,verbs=update,versions=v1,name=mpod-ephemeral.kb.io,admissionReviewVersions=v1
 
 // Handle mutates an incoming Pod admission request by applying defaults from
 // WorkloadPolicy (labels, resource requests/limits) and injecting telemetry
-// environment variables from TelemetryProfile resources active in the namespace.
+// environment variables from TelemetryProfile resources active in the namespace
+// whose podSelector matches the Pod. ClusterWorkloadPolicies and
+// ClusterTelemetryProfiles selecting the namespace are applied afterwards, so
+// namespaced defaults take precedence over cluster-wide ones. On creation,
+// baselines with remediation Mutate fill in missing security settings; for
+// ephemeral containers added to a running Pod, remediation is all that
+// happens.
 func (m *PodMutator) Handle(ctx context.Context, req admission.Request) admission.Response {
 	if m.decoder == nil {
 		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("admission decoder is not initialized"))
@@ -49,99 +67,265 @@ func (m *PodMutator) Handle(ctx context.Context, req admission.Request) admissio
 
 	podlog.Info("Mutating Pod", "name", pod.Name, "namespace", pod.Namespace)
 
-	var policies platformv1alpha1.WorkloadPolicyList
-	if err := m.Client.List(ctx, &policies, client.InNamespace(req.Namespace)); err != nil {
+	mutation := &podMutation{
+		target:  fmt.Sprintf("Pod %s in namespace %s", pod.Name, req.Namespace),
+		subject: policyreport.SubjectForPod(pod, req.Namespace),
+	}
+	if req.SubResource == ephemeralContainersSubresource {
+		return m.remediateEphemeralContainers(ctx, req, pod, mutation)
+	}
+
+	applied, err := templateDefaultsApplied(ctx, m.Client, req.Namespace, pod)
+	if err != nil {
 		return admission.Errored(http.StatusInternalServerError, err)
 	}
+	mutated := false
+	if !applied {
+		if mutated, err = m.applyDefaults(ctx, req.Namespace, pod, mutation); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+	}
+	// Security settings of a running Pod are immutable, so remediation only
+	// happens when the Pod is created.
+	if req.Operation != admissionv1.Update {
+		remediated, err := m.applyRemediation(ctx, req.Namespace, pod, mutation)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		mutated = mutated || remediated
+	}
+	if m.Reports != nil {
+		m.Reports.Record(policyreport.TriggerAdmission, time.Now(), mutation.results)
+	}
 
-	sortWorkloadPoliciesByPriority(policies.Items)
+	if !mutated {
+		return admission.Allowed("No mutations applied")
+	}
 
-	mutated := false
+	marshaledPod, err := json.Marshal(pod)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledPod)
+}
+
+// applyDefaults applies the WorkloadPolicies and TelemetryProfiles of the
+// namespace, and the cluster variants selecting it, to the Pod. The Pod may
+// stand for the Pod template of a workload, which mutation describes.
+func (m *PodMutator) applyDefaults(ctx context.Context, namespace string, pod *corev1.Pod, mutation *podMutation) (bool, error) {
+	// Policies are selected against the labels the Pod was submitted with, so
+	// labels added by one policy never change which other policies apply.
+	podLabels := maps.Clone(pod.Labels)
+
+	var policies platformv1alpha1.WorkloadPolicyList
+	if err := m.Client.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		return false, err
+	}
+	policies.Items = slices.DeleteFunc(policies.Items, func(policy platformv1alpha1.WorkloadPolicy) bool {
+		return !governance.SelectsPod(policy.Spec.PodSelector, podLabels)
+	})
 
-	// Apply TelemetryProfiles
 	var telemetryProfiles platformv1alpha1.TelemetryProfileList
-	if err := m.Client.List(ctx, &telemetryProfiles, client.InNamespace(req.Namespace)); err != nil {
-		return admission.Errored(http.StatusInternalServerError, err)
+	if err := m.Client.List(ctx, &telemetryProfiles, client.InNamespace(namespace)); err != nil {
+		return false, err
 	}
+	telemetryProfiles.Items = slices.DeleteFunc(telemetryProfiles.Items, func(profile platformv1alpha1.TelemetryProfile) bool {
+		return !governance.SelectsPod(profile.Spec.PodSelector, podLabels)
+	})
 
 	sortTelemetryProfilesByPriority(telemetryProfiles.Items)
 
-	telemetryMutated := m.applyTelemetry(pod, telemetryProfiles.Items)
-	if telemetryMutated {
-		mutated = true
+	clusterPolicies, clusterProfiles, err := m.clusterDefaults(ctx, namespace, podLabels)
+	if err != nil {
+		return false, err
 	}
 
-	// Apply policies
-	for _, policy := range policies.Items {
-		policyMutated := m.applyPolicyLabels(pod, &policy)
-
-		resourcesMutated, err := m.applyPolicyResources(pod, &policy)
-		if err != nil {
-			podlog.Error(err, "Skipping resource defaults from WorkloadPolicy due to invalid configuration",
-				"policy", policy.Name, "namespace", policy.Namespace)
-			continue
-		}
-		policyMutated = policyMutated || resourcesMutated
+	mutated := false
 
-		if policyMutated {
+	// Apply TelemetryProfiles. Every default only fills a gap, so namespaced
+	// profiles go first and cluster profiles only supply what is still missing.
+	if m.applyTelemetry(pod, telemetryProfiles.Items, mutation) {
+		mutated = true
+	}
+	for _, profile := range clusterProfiles {
+		injected := m.injectTelemetry(pod, &profile.Spec.TelemetryProfileSpec)
+		mutation.telemetry("ClusterTelemetryProfile", &profile, &profile.Spec.TelemetryProfileSpec, injected)
+		if injected {
 			mutated = true
-			m.Recorder.Event(&policy, "Normal", "PodMutated", fmt.Sprintf("Applied workload policy defaults to Pod %s in namespace %s", pod.Name, pod.Namespace))
+			m.Recorder.Event(&profile, "Normal", "PodMutated", "Injected telemetry config to "+mutation.target)
 		}
 	}
 
-	if !mutated {
-		return admission.Allowed("No mutations applied")
+	// Apply policies, namespaced first for the same reason. Within each
+	// scope, the merge strategies decide what lower priority policies add.
+	var namespaced, cluster []governance.WorkloadPolicyCandidate
+	for i, policy := range policies.Items {
+		namespaced = append(namespaced, governance.WorkloadPolicyCandidate{Kind: "WorkloadPolicy", Object: &policies.Items[i], Policy: policy})
+	}
+	for i, clusterPolicy := range clusterPolicies {
+		cluster = append(cluster, governance.WorkloadPolicyCandidate{
+			Kind:   "ClusterWorkloadPolicy",
+			Object: &clusterPolicies[i],
+			Policy: platformv1alpha1.WorkloadPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterPolicy.Name},
+				Spec:       clusterPolicy.Spec.WorkloadPolicySpec,
+			},
+		})
+	}
+	initRequests := initContainerRequests(pod)
+	for _, candidates := range [][]governance.WorkloadPolicyCandidate{namespaced, cluster} {
+		for _, candidate := range governance.MergeWorkloadPolicies(candidates) {
+			if candidate.SupersededBy != "" {
+				mutation.append(candidate.Kind, &candidate.Policy, "mergeStrategy", v1alpha2.PolicyResultSkip,
+					"superseded by "+candidate.SupersededBy+" with mergeStrategy HighestPriorityOnly")
+				continue
+			}
+			if m.applyWorkloadPolicy(pod, &candidate.Policy, candidate.Object, candidate.Kind, mutation) {
+				mutated = true
+			}
+		}
+	}
+	// Init container requests are capped once every policy has defaulted the
+	// containers that keep running.
+	if capInitContainerRequests(pod, initRequests) {
+		mutated = true
 	}
 
-	marshaledPod, err := json.Marshal(pod)
+	return mutated, nil
+}
+
+// applyRemediation injects the secure defaults required by SecurityBaselines
+// and ClusterSecurityBaselines with remediation Mutate into the Pod and
+// records an event on every baseline that injected a setting.
+func (m *PodMutator) applyRemediation(ctx context.Context, namespace string, pod *corev1.Pod, mutation *podMutation) (bool, error) {
+	remediations, err := governance.RemediateBaselines(ctx, m.Client, namespace, pod, nil)
 	if err != nil {
-		return admission.Errored(http.StatusInternalServerError, err)
+		return false, err
 	}
+	m.recordRemediations(remediations, mutation)
+	return len(remediations) > 0, nil
+}
 
-	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledPod)
+// recordRemediations reports the injected settings and records an event on
+// every baseline that injected them.
+func (m *PodMutator) recordRemediations(remediations []governance.BaselineRemediation, mutation *podMutation) {
+	for _, remediation := range remediations {
+		fields := strings.Join(remediation.Fields, ", ")
+		mutation.append(remediation.Kind, remediation.Object, "remediation", v1alpha2.PolicyResultPass, "injected secure defaults: "+fields)
+		m.Recorder.Event(remediation.Object, "Normal", "PodRemediated",
+			fmt.Sprintf("Injected secure defaults (%s) into %s", fields, mutation.target))
+	}
 }
 
-func sortWorkloadPoliciesByPriority(policies []platformv1alpha1.WorkloadPolicy) {
-	sort.Slice(policies, func(i, j int) bool {
-		return policies[i].Spec.Priority > policies[j].Spec.Priority
+// clusterDefaults returns the ClusterWorkloadPolicies and
+// ClusterTelemetryProfiles selecting the namespace and the Pod, the profiles
+// sorted by priority.
+func (m *PodMutator) clusterDefaults(ctx context.Context, namespace string, podLabels map[string]string) (
+	[]platformv1alpha1.ClusterWorkloadPolicy, []platformv1alpha1.ClusterTelemetryProfile, error) {
+	var policies platformv1alpha1.ClusterWorkloadPolicyList
+	if err := m.Client.List(ctx, &policies); err != nil {
+		return nil, nil, err
+	}
+	var profiles platformv1alpha1.ClusterTelemetryProfileList
+	if err := m.Client.List(ctx, &profiles); err != nil {
+		return nil, nil, err
+	}
+	if len(policies.Items) == 0 && len(profiles.Items) == 0 {
+		return nil, nil, nil
+	}
+
+	nsLabels, err := governance.NamespaceLabels(ctx, m.Client, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policies.Items = slices.DeleteFunc(policies.Items, func(policy platformv1alpha1.ClusterWorkloadPolicy) bool {
+		return !governance.SelectsNamespace(policy.Spec.NamespaceSelector, nsLabels) || !governance.SelectsPod(policy.Spec.PodSelector, podLabels)
+	})
+
+	profiles.Items = slices.DeleteFunc(profiles.Items, func(profile platformv1alpha1.ClusterTelemetryProfile) bool {
+		return !governance.SelectsNamespace(profile.Spec.NamespaceSelector, nsLabels) || !governance.SelectsPod(profile.Spec.PodSelector, podLabels)
+	})
+	slices.SortFunc(profiles.Items, func(a, b platformv1alpha1.ClusterTelemetryProfile) int {
+		return governance.ByPriority(a.Spec.Priority, a.Name, b.Spec.Priority, b.Name)
 	})
+
+	return policies.Items, profiles.Items, nil
+}
+
+// applyWorkloadPolicy applies the label and resource defaults of a policy and
+// records an event on object, the WorkloadPolicy or ClusterWorkloadPolicy the
+// policy was read from, whose kind is given for the PolicyReport.
+func (m *PodMutator) applyWorkloadPolicy(pod *corev1.Pod, policy *platformv1alpha1.WorkloadPolicy, object runtime.Object,
+	kind string, mutation *podMutation) bool {
+	policyMutated := m.applyPolicyLabels(pod, policy)
+	if len(policy.Spec.MandatoryLabels) > 0 {
+		mutation.add(kind, policy, "mandatoryLabels", policyMutated, "added missing mandatory labels",
+			"Pod already carries every mandatory label")
+	}
+
+	resourcesMutated, err := m.applyPolicyResources(pod, policy)
+	if err != nil {
+		podlog.Error(err, "Skipping resource defaults from WorkloadPolicy due to invalid configuration",
+			"policy", policy.Name, "namespace", policy.Namespace)
+		mutation.failed(kind, policy, "resourceDefaults", err)
+		return policyMutated
+	}
+	if hasResourceDefaults(&policy.Spec) {
+		mutation.add(kind, policy, "resourceDefaults", resourcesMutated, "added default resource requests and limits",
+			"every container already sets the default resources")
+	}
+	policyMutated = policyMutated || resourcesMutated
+
+	if policyMutated {
+		m.Recorder.Event(object, "Normal", "PodMutated", "Applied workload policy defaults to "+mutation.target)
+	}
+	return policyMutated
 }
 
 func sortTelemetryProfilesByPriority(profiles []platformv1alpha1.TelemetryProfile) {
-	sort.Slice(profiles, func(i, j int) bool {
-		return profiles[i].Spec.Priority > profiles[j].Spec.Priority
+	slices.SortFunc(profiles, func(a, b platformv1alpha1.TelemetryProfile) int {
+		return governance.ByPriority(a.Spec.Priority, a.Name, b.Spec.Priority, b.Name)
 	})
 }
 
-func (m *PodMutator) applyTelemetry(pod *corev1.Pod, profiles []platformv1alpha1.TelemetryProfile) bool {
+func (m *PodMutator) applyTelemetry(pod *corev1.Pod, profiles []platformv1alpha1.TelemetryProfile, mutation *podMutation) bool {
 	mutated := false
 	for _, profile := range profiles {
-		if !profile.Spec.InjectEnvVars || profile.Spec.TracingEndpoint == "" {
-			continue
+		injected := m.injectTelemetry(pod, &profile.Spec)
+		mutation.telemetry("TelemetryProfile", &profile, &profile.Spec, injected)
+		if injected {
+			mutated = true
+			m.Recorder.Event(&profile, "Normal", "PodMutated", "Injected telemetry config to "+mutation.target)
 		}
+	}
+	return mutated
+}
 
-		profileMutated := false
-		for i := range pod.Spec.Containers {
-			if !containerHasEnvVar(pod.Spec.Containers[i].Env, "OTEL_EXPORTER_OTLP_ENDPOINT") {
-				pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, corev1.EnvVar{
-					Name:  "OTEL_EXPORTER_OTLP_ENDPOINT",
-					Value: profile.Spec.TracingEndpoint,
-				})
-				profileMutated = true
-			}
+// injectTelemetry adds the OpenTelemetry environment variables of a profile to
+// every container that does not set them yet.
+func (m *PodMutator) injectTelemetry(pod *corev1.Pod, spec *platformv1alpha1.TelemetryProfileSpec) bool {
+	if !spec.InjectEnvVars || spec.TracingEndpoint == "" {
+		return false
+	}
 
-			if profile.Spec.SamplingRate != "" && !containerHasEnvVar(pod.Spec.Containers[i].Env, "OTEL_TRACES_SAMPLER_ARG") {
-				pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, corev1.EnvVar{
-					Name:  "OTEL_TRACES_SAMPLER_ARG",
-					Value: profile.Spec.SamplingRate,
-				})
-				profileMutated = true
-			}
+	mutated := false
+	for i := range pod.Spec.Containers {
+		if !containerHasEnvVar(pod.Spec.Containers[i].Env, "OTEL_EXPORTER_OTLP_ENDPOINT") {
+			pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, corev1.EnvVar{
+				Name:  "OTEL_EXPORTER_OTLP_ENDPOINT",
+				Value: spec.TracingEndpoint,
+			})
+			mutated = true
 		}
 
-		if profileMutated {
+		if spec.SamplingRate != "" && !containerHasEnvVar(pod.Spec.Containers[i].Env, "OTEL_TRACES_SAMPLER_ARG") {
+			pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, corev1.EnvVar{
+				Name:  "OTEL_TRACES_SAMPLER_ARG",
+				Value: spec.SamplingRate,
+			})
 			mutated = true
-			m.Recorder.Event(&profile, "Normal", "PodMutated", fmt.Sprintf("Injected telemetry config to Pod %s in namespace %s", pod.Name, pod.Namespace))
 		}
 	}
 	return mutated
@@ -163,56 +347,87 @@ func (m *PodMutator) applyPolicyLabels(pod *corev1.Pod, policy *platformv1alpha1
 	return mutated
 }
 
+// applyPolicyResources applies the resource defaults of a policy to the
+// containers, native sidecars and other init containers of the Pod. The first
+// containerResources rule matching a container goes first, then the defaults
+// of its kind of containers fill in what the rule leaves unset.
 func (m *PodMutator) applyPolicyResources(pod *corev1.Pod, policy *platformv1alpha1.WorkloadPolicy) (bool, error) {
+	spec := &policy.Spec
 	mutated := false
-	for i := range pod.Spec.Containers {
-		if pod.Spec.Containers[i].Resources.Requests == nil {
-			pod.Spec.Containers[i].Resources.Requests = make(corev1.ResourceList)
+	apply := func(c *corev1.Container, defaults *platformv1alpha1.ContainerResourceDefaults, prefix string) error {
+		applied, err := applyContainerRule(c, policy.Name, spec.ContainerResources)
+		if err != nil {
+			return err
 		}
-		for rName, rVal := range policy.Spec.DefaultRequests {
-			rn := corev1.ResourceName(rName)
-			if _, exists := pod.Spec.Containers[i].Resources.Requests[rn]; exists {
-				continue
-			}
-
-			qty, parseErr := resource.ParseQuantity(rVal)
-			if parseErr != nil {
-				return false, fmt.Errorf(
-					"WorkloadPolicy %s has invalid defaultRequests quantity for %s: %q",
-					policy.Name,
-					rName,
-					rVal,
-				)
-			}
-			pod.Spec.Containers[i].Resources.Requests[rn] = qty
-			mutated = true
+		mutated = mutated || applied
+		if defaults == nil {
+			return nil
 		}
+		applied, err = applyContainerResources(c, policy.Name, prefix, defaults.DefaultRequests, defaults.DefaultLimits)
+		mutated = mutated || applied
+		return err
+	}
 
-		if pod.Spec.Containers[i].Resources.Limits == nil {
-			pod.Spec.Containers[i].Resources.Limits = make(corev1.ResourceList)
+	containerDefaults := &platformv1alpha1.ContainerResourceDefaults{DefaultRequests: spec.DefaultRequests, DefaultLimits: spec.DefaultLimits}
+	for i := range pod.Spec.Containers {
+		if err := apply(&pod.Spec.Containers[i], containerDefaults, ""); err != nil {
+			return false, err
 		}
-		for rName, rVal := range policy.Spec.DefaultLimits {
-			rn := corev1.ResourceName(rName)
-			if _, exists := pod.Spec.Containers[i].Resources.Limits[rn]; exists {
-				continue
-			}
-
-			qty, parseErr := resource.ParseQuantity(rVal)
-			if parseErr != nil {
-				return false, fmt.Errorf(
-					"WorkloadPolicy %s has invalid defaultLimits quantity for %s: %q",
-					policy.Name,
-					rName,
-					rVal,
-				)
-			}
-			pod.Spec.Containers[i].Resources.Limits[rn] = qty
-			mutated = true
+	}
+	for i := range pod.Spec.InitContainers {
+		c := &pod.Spec.InitContainers[i]
+		defaults, prefix := spec.InitContainerResources, "initContainerResources."
+		if isSidecar(c) {
+			defaults, prefix = spec.SidecarResources, "sidecarResources."
+		}
+		if err := apply(c, defaults, prefix); err != nil {
+			return false, err
 		}
 	}
 	return mutated, nil
 }
 
+// podMutation describes the Pod spec being defaulted, a Pod or the Pod
+// template of a workload, and collects a PolicyReport result for every policy
+// rule applied to it: pass when the rule changed the Pod, skip when there was
+// nothing left to default and error when the policy is invalid.
+type podMutation struct {
+	// target names the mutated object in events.
+	target  string
+	subject corev1.ObjectReference
+	results []policyreport.Result
+}
+
+func (r *podMutation) add(kind string, policy metav1.Object, rule string, applied bool, appliedMessage, skippedMessage string) {
+	result, message := v1alpha2.PolicyResultSkip, skippedMessage
+	if applied {
+		result, message = v1alpha2.PolicyResultPass, appliedMessage
+	}
+	r.append(kind, policy, rule, result, message)
+}
+
+func (r *podMutation) failed(kind string, policy metav1.Object, rule string, err error) {
+	r.append(kind, policy, rule, v1alpha2.PolicyResultError, err.Error())
+}
+
+func (r *podMutation) telemetry(kind string, profile metav1.Object, spec *platformv1alpha1.TelemetryProfileSpec, injected bool) {
+	if !spec.InjectEnvVars || spec.TracingEndpoint == "" {
+		return
+	}
+	r.add(kind, profile, "telemetry", injected, "injected OpenTelemetry environment variables",
+		"every container already sets the telemetry environment variables")
+}
+
+func (r *podMutation) append(kind string, policy metav1.Object, rule string, result v1alpha2.PolicyResult, message string) {
+	r.results = append(r.results, policyreport.Result{
+		Policy:  policyreport.PolicyRef{Kind: kind, Namespace: policy.GetNamespace(), Name: policy.GetName()},
+		Rule:    rule,
+		Result:  result,
+		Subject: r.subject,
+		Message: message,
+	})
+}
+
 func containerHasEnvVar(envs []corev1.EnvVar, key string) bool {
 	for _, env := range envs {
 		if env.Name == key {
@@ -225,11 +440,12 @@ func containerHasEnvVar(envs []corev1.EnvVar, key string) bool {
 // SetupPodMutatorWebhookWithManager registers the Pod mutating webhook with the Manager.
 // Uses imperative registration (mgr.GetWebhookServer().Register) because core/v1
 // types are not CRDs and cannot use the kubebuilder declarative webhook builder.
-func SetupPodMutatorWebhookWithManager(mgr ctrl.Manager) error {
+func SetupPodMutatorWebhookWithManager(mgr ctrl.Manager, reports *policyreport.Store) error {
 	handler := &PodMutator{
 		Client: mgr.GetClient(),
 		//nolint:staticcheck // controller-runtime recorder migration pending
 		Recorder: mgr.GetEventRecorderFor("pod-mutator-webhook"),
+		Reports:  reports,
 		decoder:  admission.NewDecoder(mgr.GetScheme()),
 	}
 
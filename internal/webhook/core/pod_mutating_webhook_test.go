@@ -2,16 +2,23 @@ package core
 
 import (
 	"context"
+	"encoding/json"
+	"slices"
+	"strings"
 	"testing"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport/v1alpha2"
 )
 
 func TestPodMutatorApplyTelemetryUsesHighestPriorityFirst(t *testing.T) {
@@ -45,7 +52,7 @@ func TestPodMutatorApplyTelemetryUsesHighestPriorityFirst(t *testing.T) {
 	}
 
 	sortTelemetryProfilesByPriority(profiles)
-	mutated := mutator.applyTelemetry(pod, profiles)
+	mutated := mutator.applyTelemetry(pod, profiles, &podMutation{})
 	if !mutated {
 		t.Fatalf("expected pod to be mutated")
 	}
@@ -263,6 +270,139 @@ func TestPodMutatorHandleFullFlow(t *testing.T) {
 	}
 }
 
+func TestPodMutatorHandleAppliesOnlySelectedPoliciesAndProfiles(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	webPolicy := &platformv1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"},
+		Spec: platformv1alpha1.WorkloadPolicySpec{
+			PodSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}},
+			DefaultRequests: map[string]string{"cpu": "250m"},
+			// Labels added by a policy must not change which policies select the Pod.
+			MandatoryLabels: map[string]string{"workload": "batch"},
+		},
+	}
+	batchPolicy := &platformv1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: "team-a"},
+		Spec: platformv1alpha1.WorkloadPolicySpec{
+			PodSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"workload": "batch"}},
+			DefaultRequests: map[string]string{"cpu": "2"},
+		},
+	}
+	webProfile := &platformv1alpha1.TelemetryProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"},
+		Spec: platformv1alpha1.TelemetryProfileSpec{
+			PodSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}},
+			InjectEnvVars:   true,
+			TracingEndpoint: "http://web-otel:4317",
+		},
+	}
+	batchProfile := &platformv1alpha1.TelemetryProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: "team-a"},
+		Spec: platformv1alpha1.TelemetryProfileSpec{
+			PodSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "workload", Operator: metav1.LabelSelectorOpIn, Values: []string{"batch"}},
+			}},
+			Priority:        100,
+			InjectEnvVars:   true,
+			TracingEndpoint: "http://batch-otel:4317",
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(webPolicy, batchPolicy, webProfile, batchProfile).Build()
+	mutator := &PodMutator{
+		Client:   cl,
+		Recorder: record.NewFakeRecorder(10),
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"tier": "web"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}},
+		},
+	}
+	resp := mutator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod))
+	if !resp.Allowed {
+		t.Fatalf("expected pod to be allowed (mutating webhook), got denied")
+	}
+	patches, err := json.Marshal(resp.Patches)
+	if err != nil {
+		t.Fatalf("failed to marshal patches: %v", err)
+	}
+	for _, want := range []string{"250m", "http://web-otel:4317"} {
+		if !strings.Contains(string(patches), want) {
+			t.Fatalf("expected patches to contain %q, got %s", want, patches)
+		}
+	}
+	for _, unwanted := range []string{`"2"`, "http://batch-otel:4317"} {
+		if strings.Contains(string(patches), unwanted) {
+			t.Fatalf("expected patches not to contain %q, got %s", unwanted, patches)
+		}
+	}
+}
+
+func TestPodMutatorHandleAppliesClusterDefaultsAfterNamespacedOnes(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"env": "production"}}}
+	policy := &platformv1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "team-a"},
+		Spec: platformv1alpha1.WorkloadPolicySpec{
+			DefaultRequests: map[string]string{"cpu": "250m"},
+		},
+	}
+	clusterPolicy := &platformv1alpha1.ClusterWorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "production"},
+		Spec: platformv1alpha1.ClusterWorkloadPolicySpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "production"}},
+			WorkloadPolicySpec: platformv1alpha1.WorkloadPolicySpec{
+				// Higher priority than the namespaced policy, which still wins.
+				Priority:        100,
+				DefaultRequests: map[string]string{"cpu": "1", "memory": "128Mi"},
+				MandatoryLabels: map[string]string{"cost-center": "platform"},
+			},
+		},
+	}
+	otherClusterProfile := &platformv1alpha1.ClusterTelemetryProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev"},
+		Spec: platformv1alpha1.ClusterTelemetryProfileSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+			TelemetryProfileSpec: platformv1alpha1.TelemetryProfileSpec{
+				InjectEnvVars:   true,
+				TracingEndpoint: "http://dev-otel:4317",
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace, policy, clusterPolicy, otherClusterProfile).Build()
+	mutator := &PodMutator{
+		Client:   cl,
+		Recorder: record.NewFakeRecorder(10),
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}
+	resp := mutator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod))
+	if !resp.Allowed {
+		t.Fatalf("expected pod to be allowed (mutating webhook), got denied")
+	}
+	patches, err := json.Marshal(resp.Patches)
+	if err != nil {
+		t.Fatalf("failed to marshal patches: %v", err)
+	}
+	for _, want := range []string{"250m", "128Mi", "cost-center"} {
+		if !strings.Contains(string(patches), want) {
+			t.Fatalf("expected patches to contain %q, got %s", want, patches)
+		}
+	}
+	for _, unwanted := range []string{`"1"`, "http://dev-otel:4317"} {
+		if strings.Contains(string(patches), unwanted) {
+			t.Fatalf("expected patches not to contain %q, got %s", unwanted, patches)
+		}
+	}
+}
+
 func TestPodMutatorHandleNoMutationsNeeded(t *testing.T) {
 	t.Parallel()
 
@@ -355,7 +495,7 @@ func TestPodMutatorTelemetryDoesNotOverrideExistingEnvVars(t *testing.T) {
 		},
 	}
 
-	mutated := mutator.applyTelemetry(pod, profiles)
+	mutated := mutator.applyTelemetry(pod, profiles, &podMutation{})
 	if mutated {
 		t.Fatalf("expected no mutation when env var already exists")
 	}
@@ -420,7 +560,7 @@ func TestPodMutatorApplyTelemetrySkipsInitContainers(t *testing.T) {
 		},
 	}
 
-	mutated := mutator.applyTelemetry(pod, profiles)
+	mutated := mutator.applyTelemetry(pod, profiles, &podMutation{})
 	if !mutated {
 		t.Fatalf("expected pod to be mutated (regular container got env vars)")
 	}
@@ -490,7 +630,7 @@ func TestPodMutatorApplyTelemetryInjectEnvVarsFalseSkips(t *testing.T) {
 		},
 	}
 
-	mutated := mutator.applyTelemetry(pod, profiles)
+	mutated := mutator.applyTelemetry(pod, profiles, &podMutation{})
 	if mutated {
 		t.Fatalf("expected no mutation when InjectEnvVars is false")
 	}
@@ -518,7 +658,7 @@ func TestPodMutatorApplyTelemetryEmptyEndpointSkips(t *testing.T) {
 		},
 	}
 
-	mutated := mutator.applyTelemetry(pod, profiles)
+	mutated := mutator.applyTelemetry(pod, profiles, &podMutation{})
 	if mutated {
 		t.Fatalf("expected no mutation when TracingEndpoint is empty")
 	}
@@ -544,7 +684,7 @@ func TestPodMutatorApplyTelemetrySamplingRateNotInjectedWhenEmpty(t *testing.T)
 		},
 	}
 
-	mutated := mutator.applyTelemetry(pod, profiles)
+	mutated := mutator.applyTelemetry(pod, profiles, &podMutation{})
 	if !mutated {
 		t.Fatalf("expected mutation (endpoint env var should be injected)")
 	}
@@ -594,19 +734,162 @@ func TestSortTelemetryProfilesByPriority(t *testing.T) {
 	}
 }
 
-func TestSortWorkloadPoliciesByPriority(t *testing.T) {
+func TestPodMutatorRecordsPolicyReportResults(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	policy := &platformv1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "team-a"},
+		Spec: platformv1alpha1.WorkloadPolicySpec{
+			DefaultRequests: map[string]string{"cpu": "250m"},
+			MandatoryLabels: map[string]string{"team": "a"},
+		},
+	}
+	profile := &platformv1alpha1.TelemetryProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "otel", Namespace: "team-a"},
+		Spec: platformv1alpha1.TelemetryProfileSpec{
+			InjectEnvVars:   true,
+			TracingEndpoint: "http://otel:4317",
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(policy, profile).Build()
+	store := policyreport.NewStore(nil)
+	mutator := &PodMutator{
+		Client:   cl,
+		Recorder: record.NewFakeRecorder(10),
+		Reports:  store,
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Labels: map[string]string{"team": "a"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+	if resp := mutator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod)); !resp.Allowed {
+		t.Fatalf("expected pod to be allowed (mutating webhook), got denied")
+	}
+
+	results := resultsByRule(flushPolicyReport(t, store, "team-a"))
+	want := map[string]v1alpha2.PolicyResult{
+		"WorkloadPolicy/defaults/mandatoryLabels":  v1alpha2.PolicyResultSkip,
+		"WorkloadPolicy/defaults/resourceDefaults": v1alpha2.PolicyResultPass,
+		"TelemetryProfile/otel/telemetry":          v1alpha2.PolicyResultPass,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("expected %d results, got %+v", len(want), results)
+	}
+	for key, result := range want {
+		if results[key].Result != result {
+			t.Fatalf("expected %s to be %s, got %+v", key, result, results[key])
+		}
+	}
+}
+
+func TestPodMutatorRemediatesOnCreateOnly(t *testing.T) {
 	t.Parallel()
 
-	policies := []platformv1alpha1.WorkloadPolicy{
-		{ObjectMeta: metav1.ObjectMeta{Name: "low"}, Spec: platformv1alpha1.WorkloadPolicySpec{Priority: 1}},
-		{ObjectMeta: metav1.ObjectMeta{Name: "high"}, Spec: platformv1alpha1.WorkloadPolicySpec{Priority: 10}},
-		{ObjectMeta: metav1.ObjectMeta{Name: "mid"}, Spec: platformv1alpha1.WorkloadPolicySpec{Priority: 5}},
+	scheme := newWebhookTestScheme(t)
+	recorder := record.NewFakeRecorder(10)
+	mutator := &PodMutator{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}, remediatingBaseline()).Build(),
+		Recorder: recorder,
+		decoder:  admission.NewDecoder(scheme),
 	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx"}}},
+	}
+	req := newAdmissionRequest(t, "team-a", pod)
+	req.Operation = admissionv1.Create
 
-	sortWorkloadPoliciesByPriority(policies)
+	resp := mutator.Handle(context.Background(), req)
+	patches, err := json.Marshal(resp.Patches)
+	if err != nil {
+		t.Fatalf("failed to marshal patches: %v", err)
+	}
+	for _, want := range []string{"RuntimeDefault", "readOnlyRootFilesystem", "remediated-fields"} {
+		if !strings.Contains(string(patches), want) {
+			t.Fatalf("expected patches to contain %q, got %s", want, patches)
+		}
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Normal PodRemediated Injected secure defaults (spec.securityContext.runAsNonRoot") {
+		t.Fatalf("unexpected event: %q", event)
+	}
 
-	if policies[0].Name != "high" || policies[1].Name != "mid" || policies[2].Name != "low" {
-		t.Fatalf("expected descending priority order, got %s, %s, %s",
-			policies[0].Name, policies[1].Name, policies[2].Name)
+	req.Operation = admissionv1.Update
+	if resp := mutator.Handle(context.Background(), req); len(resp.Patches) != 0 {
+		t.Fatalf("expected no remediation on update, got %+v", resp.Patches)
+	}
+}
+
+func TestPodMutatorAppliesMergeStrategies(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	platform := &platformv1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform", Namespace: "team-a"},
+		Spec: platformv1alpha1.WorkloadPolicySpec{
+			Priority:        10,
+			MergeStrategy:   platformv1alpha1.MergeStrategyOverride,
+			DefaultRequests: map[string]string{"cpu": "500m"},
+		},
+	}
+	team := &platformv1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "team-a"},
+		Spec: platformv1alpha1.WorkloadPolicySpec{
+			DefaultRequests: map[string]string{"cpu": "100m", "memory": "128Mi"},
+			DefaultLimits:   map[string]string{"memory": "256Mi"},
+		},
+	}
+	mutator := &PodMutator{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(platform, team).Build(),
+		Recorder: record.NewFakeRecorder(10),
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}
+	if _, err := mutator.applyDefaults(context.Background(), "team-a", pod, &podMutation{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resources := pod.Spec.Containers[0].Resources
+	if cpu := resources.Requests[corev1.ResourceCPU]; cpu.String() != "500m" {
+		t.Fatalf("expected the overriding cpu request, got %s", cpu.String())
+	}
+	if _, ok := resources.Requests[corev1.ResourceMemory]; ok {
+		t.Fatal("expected the overridden requests not to be merged with the team policy")
+	}
+	if _, ok := resources.Limits[corev1.ResourceMemory]; !ok {
+		t.Fatal("expected the team policy to still default limits")
+	}
+
+	platform.Spec.MergeStrategy = platformv1alpha1.MergeStrategyHighestPriorityOnly
+	if err := mutator.Client.Update(context.Background(), platform); err != nil {
+		t.Fatalf("failed to update the policy: %v", err)
+	}
+	mutation := &podMutation{}
+	pod = &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}
+	if _, err := mutator.applyDefaults(context.Background(), "team-a", pod, mutation); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pod.Spec.Containers[0].Resources.Limits) != 0 {
+		t.Fatalf("expected the team policy to be superseded, got %v", pod.Spec.Containers[0].Resources)
+	}
+	if !slices.ContainsFunc(mutation.results, func(result policyreport.Result) bool {
+		return result.Policy.Name == "team" && result.Rule == "mergeStrategy" && result.Result == v1alpha2.PolicyResultSkip
+	}) {
+		t.Fatalf("expected a mergeStrategy skip result for the superseded policy, got %+v", mutation.results)
+	}
+}
+
+func remediatingBaseline() *platformv1alpha1.SecurityBaseline {
+	return &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted", Namespace: "team-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			Profile:                platformv1alpha1.PodSecurityProfileRestricted,
+			RunAsNonRoot:           ptr.To(true),
+			ReadOnlyRootFilesystem: ptr.To(true),
+			Remediation:            platformv1alpha1.RemediationMutate,
+		},
 	}
 }
@@ -0,0 +1,172 @@
+package core
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+// isSidecar reports whether the init container is a native sidecar, which
+// keeps running next to the containers of the Pod.
+func isSidecar(c *corev1.Container) bool {
+	return c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways
+}
+
+// hasResourceDefaults reports whether the policy defaults the resources of any
+// kind of container.
+func hasResourceDefaults(spec *platformv1alpha1.WorkloadPolicySpec) bool {
+	if len(spec.DefaultRequests) > 0 || len(spec.DefaultLimits) > 0 || len(spec.ContainerResources) > 0 {
+		return true
+	}
+	for _, defaults := range []*platformv1alpha1.ContainerResourceDefaults{spec.InitContainerResources, spec.SidecarResources} {
+		if defaults != nil && (len(defaults.DefaultRequests) > 0 || len(defaults.DefaultLimits) > 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectsContainer reports whether the container matches every field of the
+// selector that is set.
+func selectsContainer(selector *platformv1alpha1.ContainerSelector, c *corev1.Container) bool {
+	if selector.Name != "" {
+		if matched, err := path.Match(selector.Name, c.Name); err != nil || !matched {
+			return false
+		}
+	}
+	return strings.HasPrefix(c.Image, selector.ImagePrefix)
+}
+
+// applyContainerRule applies the defaults of the first rule matching the
+// container, if any.
+func applyContainerRule(c *corev1.Container, policyName string, rules []platformv1alpha1.ContainerResourceRule) (bool, error) {
+	for i := range rules {
+		rule := &rules[i]
+		if selectsContainer(&rule.ContainerSelector, c) {
+			return applyContainerResources(c, policyName, fmt.Sprintf("containerResources[%d].", i),
+				rule.DefaultRequests, rule.DefaultLimits)
+		}
+	}
+	return false, nil
+}
+
+// applyContainerResources sets the default requests and limits the container
+// does not set yet. A defaulted request never exceeds the limit of the
+// container, and a defaulted limit is never below its request, since the API
+// server rejects such containers. prefix locates the defaults in the policy
+// spec for error messages.
+func applyContainerResources(c *corev1.Container, policyName, prefix string, requests, limits map[string]string) (bool, error) {
+	mutated := false
+	for rName, rVal := range requests {
+		rn := corev1.ResourceName(rName)
+		if _, exists := c.Resources.Requests[rn]; exists {
+			continue
+		}
+
+		qty, parseErr := resource.ParseQuantity(rVal)
+		if parseErr != nil {
+			return false, fmt.Errorf("WorkloadPolicy %s has invalid %sdefaultRequests quantity for %s: %q", policyName, prefix, rName, rVal)
+		}
+		if limit, ok := c.Resources.Limits[rn]; ok && qty.Cmp(limit) > 0 {
+			qty = limit.DeepCopy()
+		}
+		if c.Resources.Requests == nil {
+			c.Resources.Requests = make(corev1.ResourceList)
+		}
+		c.Resources.Requests[rn] = qty
+		mutated = true
+	}
+
+	for rName, rVal := range limits {
+		rn := corev1.ResourceName(rName)
+		if _, exists := c.Resources.Limits[rn]; exists {
+			continue
+		}
+
+		qty, parseErr := resource.ParseQuantity(rVal)
+		if parseErr != nil {
+			return false, fmt.Errorf("WorkloadPolicy %s has invalid %sdefaultLimits quantity for %s: %q", policyName, prefix, rName, rVal)
+		}
+		if request, ok := c.Resources.Requests[rn]; ok && qty.Cmp(request) < 0 {
+			qty = request.DeepCopy()
+		}
+		if c.Resources.Limits == nil {
+			c.Resources.Limits = make(corev1.ResourceList)
+		}
+		c.Resources.Limits[rn] = qty
+		mutated = true
+	}
+	return mutated, nil
+}
+
+// initContainerRequests returns the resource requests of the init containers
+// of the Pod, before any defaults are applied.
+func initContainerRequests(pod *corev1.Pod) []corev1.ResourceList {
+	requests := make([]corev1.ResourceList, len(pod.Spec.InitContainers))
+	for i := range pod.Spec.InitContainers {
+		requests[i] = maps.Clone(pod.Spec.InitContainers[i].Resources.Requests)
+	}
+	return requests
+}
+
+// capInitContainerRequests lowers the defaulted requests of init containers
+// that run to completion, so that the init phase never requests more than the
+// running Pod. Kubernetes schedules and charges quota for the larger of the
+// requests of the running Pod, its containers and sidecars, and the requests
+// of every other init container plus those of the sidecars started before it.
+// Requests an init container was submitted with, as recorded by
+// initContainerRequests, are kept.
+func capInitContainerRequests(pod *corev1.Pod, submitted []corev1.ResourceList) bool {
+	running := corev1.ResourceList{}
+	for i := range pod.Spec.Containers {
+		addResources(running, pod.Spec.Containers[i].Resources.Requests)
+	}
+	for i := range pod.Spec.InitContainers {
+		if isSidecar(&pod.Spec.InitContainers[i]) {
+			addResources(running, pod.Spec.InitContainers[i].Resources.Requests)
+		}
+	}
+
+	mutated := false
+	sidecars := corev1.ResourceList{}
+	for i := range pod.Spec.InitContainers {
+		c := &pod.Spec.InitContainers[i]
+		if isSidecar(c) {
+			addResources(sidecars, c.Resources.Requests)
+			continue
+		}
+		for name, request := range c.Resources.Requests {
+			if _, explicit := submitted[i][name]; explicit {
+				continue
+			}
+			// Resources the running Pod does not request leave nothing to
+			// cap against.
+			budget, ok := running[name]
+			if !ok {
+				continue
+			}
+			budget = budget.DeepCopy()
+			budget.Sub(sidecars[name])
+			if budget.Sign() > 0 && request.Cmp(budget) > 0 {
+				c.Resources.Requests[name] = budget
+				mutated = true
+			}
+		}
+	}
+	return mutated
+}
+
+// addResources adds the quantities of add to sum.
+func addResources(sum, add corev1.ResourceList) {
+	for name, qty := range add {
+		total := sum[name].DeepCopy()
+		total.Add(qty)
+		sum[name] = total
+	}
+}
@@ -0,0 +1,205 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+func cpuRequest(c *corev1.Container) string {
+	if qty, ok := c.Resources.Requests[corev1.ResourceCPU]; ok {
+		return qty.String()
+	}
+	return ""
+}
+
+func TestPodMutatorApplyResourcesToInitContainersAndSidecars(t *testing.T) {
+	t.Parallel()
+
+	mutator := &PodMutator{Recorder: record.NewFakeRecorder(10)}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			{Name: "migrate"},
+			{Name: "proxy", RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways)},
+		},
+		Containers: []corev1.Container{{Name: "app"}},
+	}}
+	policy := &platformv1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+		Spec: platformv1alpha1.WorkloadPolicySpec{
+			DefaultRequests: map[string]string{"cpu": "500m"},
+			InitContainerResources: &platformv1alpha1.ContainerResourceDefaults{
+				DefaultRequests: map[string]string{"cpu": "200m"},
+			},
+			SidecarResources: &platformv1alpha1.ContainerResourceDefaults{
+				DefaultRequests: map[string]string{"cpu": "50m"},
+				DefaultLimits:   map[string]string{"memory": "64Mi"},
+			},
+		},
+	}
+
+	mutated, err := mutator.applyPolicyResources(pod, policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !mutated {
+		t.Fatal("expected resources to be mutated")
+	}
+	for c, want := range map[*corev1.Container]string{
+		&pod.Spec.InitContainers[0]: "200m",
+		&pod.Spec.InitContainers[1]: "50m",
+		&pod.Spec.Containers[0]:     "500m",
+	} {
+		if got := cpuRequest(c); got != want {
+			t.Fatalf("container %s: expected cpu request %s, got %q", c.Name, want, got)
+		}
+	}
+	if _, ok := pod.Spec.InitContainers[1].Resources.Limits[corev1.ResourceMemory]; !ok {
+		t.Fatal("expected the sidecar to get the sidecar memory limit")
+	}
+
+	policy.Spec.SidecarResources.DefaultRequests = map[string]string{"cpu": "not-a-quantity"}
+	pod.Spec.InitContainers[1].Resources = corev1.ResourceRequirements{}
+	if _, err := mutator.applyPolicyResources(pod, policy); err == nil {
+		t.Fatal("expected an invalid sidecar quantity to be reported")
+	}
+}
+
+func TestPodMutatorLeavesInitContainersAloneWithoutInitDefaults(t *testing.T) {
+	t.Parallel()
+
+	mutator := &PodMutator{Recorder: record.NewFakeRecorder(10)}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "migrate"}},
+		Containers:     []corev1.Container{{Name: "app"}},
+	}}
+	policy := &platformv1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+		Spec:       platformv1alpha1.WorkloadPolicySpec{DefaultRequests: map[string]string{"cpu": "500m"}},
+	}
+
+	if _, err := mutator.applyPolicyResources(pod, policy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod.Spec.InitContainers[0].Resources.Requests != nil {
+		t.Fatalf("expected init containers to be left alone, got %v", pod.Spec.InitContainers[0].Resources)
+	}
+}
+
+func TestPodMutatorApplyContainerResourceRules(t *testing.T) {
+	t.Parallel()
+
+	mutator := &PodMutator{Recorder: record.NewFakeRecorder(10)}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			{Name: "istio-proxy", Image: "docker.io/istio/proxyv2:1.24", RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways)},
+		},
+		Containers: []corev1.Container{
+			{Name: "app", Image: "registry.example.com/web:1.0"},
+			{Name: "shipper", Image: "fluent/fluent-bit:3.2"},
+		},
+	}}
+	policy := &platformv1alpha1.WorkloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+		Spec: platformv1alpha1.WorkloadPolicySpec{
+			DefaultRequests: map[string]string{"cpu": "500m", "memory": "512Mi"},
+			ContainerResources: []platformv1alpha1.ContainerResourceRule{
+				{
+					ContainerSelector:         platformv1alpha1.ContainerSelector{Name: "istio-*"},
+					ContainerResourceDefaults: platformv1alpha1.ContainerResourceDefaults{DefaultRequests: map[string]string{"cpu": "100m"}},
+				},
+				{
+					ContainerSelector:         platformv1alpha1.ContainerSelector{ImagePrefix: "fluent/"},
+					ContainerResourceDefaults: platformv1alpha1.ContainerResourceDefaults{DefaultRequests: map[string]string{"cpu": "20m"}},
+				},
+				{
+					ContainerSelector:         platformv1alpha1.ContainerSelector{Name: "shipper"},
+					ContainerResourceDefaults: platformv1alpha1.ContainerResourceDefaults{DefaultRequests: map[string]string{"cpu": "1"}},
+				},
+			},
+		},
+	}
+
+	if _, err := mutator.applyPolicyResources(pod, policy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for c, want := range map[*corev1.Container]string{
+		&pod.Spec.InitContainers[0]: "100m",
+		&pod.Spec.Containers[0]:     "500m",
+		&pod.Spec.Containers[1]:     "20m",
+	} {
+		if got := cpuRequest(c); got != want {
+			t.Fatalf("container %s: expected cpu request %s, got %q", c.Name, want, got)
+		}
+	}
+	if memory := pod.Spec.Containers[1].Resources.Requests[corev1.ResourceMemory]; memory.String() != "512Mi" {
+		t.Fatalf("expected the container defaults to fill in what the rule leaves unset, got memory request %q", memory.String())
+	}
+	if _, ok := pod.Spec.InitContainers[0].Resources.Requests[corev1.ResourceMemory]; ok {
+		t.Fatal("expected the sidecar not to fall back to the defaults of regular containers")
+	}
+
+	policy.Spec.ContainerResources[0].DefaultLimits = map[string]string{"memory": "lots"}
+	pod.Spec.InitContainers[0].Resources = corev1.ResourceRequirements{}
+	if _, err := mutator.applyPolicyResources(pod, policy); err == nil ||
+		!strings.Contains(err.Error(), "containerResources[0].defaultLimits") {
+		t.Fatalf("expected the invalid rule quantity to be reported, got %v", err)
+	}
+}
+
+func TestApplyContainerResourcesKeepsRequestsWithinLimits(t *testing.T) {
+	t.Parallel()
+
+	c := &corev1.Container{Resources: corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+	}}
+	if _, err := applyContainerResources(c, "policy", "",
+		map[string]string{"cpu": "250m"}, map[string]string{"memory": "512Mi"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cpuRequest(c); got != "100m" {
+		t.Fatalf("expected the default cpu request to be capped at the limit, got %q", got)
+	}
+	if limit := c.Resources.Limits[corev1.ResourceMemory]; limit.Cmp(resource.MustParse("1Gi")) != 0 {
+		t.Fatalf("expected the default memory limit to be raised to the request, got %s", limit.String())
+	}
+}
+
+func TestCapInitContainerRequests(t *testing.T) {
+	t.Parallel()
+
+	requests := func(cpu string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}}
+	}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			{Name: "explicit"},
+			{Name: "proxy", RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways), Resources: requests("200m")},
+			{Name: "migrate"},
+			{Name: "small"},
+		},
+		Containers: []corev1.Container{{Name: "app", Resources: requests("300m")}},
+	}}
+	pod.Spec.InitContainers[0].Resources = requests("2")
+	submitted := initContainerRequests(pod)
+	pod.Spec.InitContainers[2].Resources = requests("1")
+	pod.Spec.InitContainers[3].Resources = requests("100m")
+
+	if !capInitContainerRequests(pod, submitted) {
+		t.Fatal("expected a defaulted init request to be capped")
+	}
+	// The running Pod requests 500m; migrate starts after the 200m sidecar.
+	for i, want := range []string{"2", "200m", "300m", "100m"} {
+		if got := cpuRequest(&pod.Spec.InitContainers[i]); got != want {
+			t.Fatalf("init container %s: expected cpu request %s, got %q", pod.Spec.InitContainers[i].Name, want, got)
+		}
+	}
+}
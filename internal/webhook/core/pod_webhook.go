@@ -5,8 +5,15 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"strings"
+	"time"
 
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -15,15 +22,36 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/governance"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport"
 )
 
 var podlog = logf.Log.WithName("pod-webhook")
 
-// PodValidator validates Pods against SecurityBaselines
+// podValidationTimeout bounds the validation of a Pod as a whole, including
+// every registry request made to verify image signatures, so that admission
+// completes within the default webhook timeout of 10s.
+const podValidationTimeout = 8 * time.Second
+
+// PodValidator validates Pods against SecurityBaselines, honoring PolicyExceptions,
+// ImageVerificationPolicies and the resource bounds of WorkloadPolicies
 type PodValidator struct {
-	Client   client.Client
-	Recorder record.EventRecorder
+	Client client.Client
+	// APIReader reads the Secrets and ConfigMaps holding image verification
+	// keys directly from the API server, so the manager does not cache every
+	// Secret in the cluster. Defaults to Client when nil.
+	APIReader client.Reader
+	// KeyNamespace is the namespace image verification keys referenced by
+	// secretKeyRef are read from, usually the namespace the operator runs in,
+	// so the operator needs no access to Secrets anywhere else. Secret key
+	// references are refused when empty.
+	KeyNamespace string
+	Recorder     record.EventRecorder
+	// Reports receives a PolicyReport result for every baseline rule checked
+	// at admission. Reporting is disabled when nil.
+	Reports  *policyreport.Store
 	decoder  admission.Decoder
+	verifier imageVerifier
 }
 
 // InjectDecoder injects the decoder for admission requests.
@@ -32,14 +60,28 @@ func (v *PodValidator) InjectDecoder(d admission.Decoder) error {
 	return nil
 }
 
+// +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=imageverificationpolicies;policyexceptions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core.platform.f3nr1r.io,resources=clustersecuritybaselines;clusterworkloadpolicies;clustertelemetryprofiles,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+// +kubebuilder:rbac:groups="",namespace=system,resources=secrets,verbs=get
+
 // +kubebuilder:webhook:path=/validate-core-v1-pod,mutating=false,failurePolicy=fail,sideEffects=None,groups="",resources=pods,verbs=create;update,versions=v1,name=vpod.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-core-v1-pod,mutating=false,failurePolicy=fail,sideEffects=None,groups="",resources=pods/ephemeralcontainers,verbs=update,versions=v1,name=vpod-ephemeral.kb.io,admissionReviewVersions=v1
 
-// Handle validates an incoming Pod admission request against all SecurityBaselines
-// active in the request namespace. Returns Denied if any baseline rule is violated.
+// Handle validates an incoming Pod admission request against all
+// ClusterSecurityBaselines and SecurityBaselines selecting the Pod and all
+// ImageVerificationPolicies active in the request namespace, and new Pods
+// against the resource bounds of WorkloadPolicies. Every violation
+// across all policies and containers is collected: violations of Enforce policies
+// are returned together in a single denial, Warn violations become admission
+// warnings and Audit violations are only recorded as events and metrics.
 func (v *PodValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
 	if v.decoder == nil {
 		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("admission decoder is not initialized"))
 	}
+	ctx, cancel := context.WithTimeout(ctx, podValidationTimeout)
+	defer cancel()
 
 	pod := &corev1.Pod{}
 	err := v.decoder.Decode(req, pod)
@@ -49,55 +91,310 @@ func (v *PodValidator) Handle(ctx context.Context, req admission.Request) admiss
 
 	podlog.Info("Validating Pod", "name", pod.Name, "namespace", pod.Namespace)
 
-	// Fetch SecurityBaselines to enforce rules
-	var baselines platformv1alpha1.SecurityBaselineList
-	if err := v.Client.List(ctx, &baselines, client.InNamespace(req.Namespace)); err != nil {
+	result := podValidationResult{
+		kind:          "Pod",
+		name:          pod.Name,
+		namespace:     req.Namespace,
+		reportSubject: policyreport.SubjectForPod(pod, req.Namespace),
+	}
+	if req.SubResource == ephemeralContainersSubresource {
+		result.existing = &corev1.Pod{}
+		if err := v.decoder.DecodeRaw(req.OldObject, result.existing); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		result.debugUser = &req.UserInfo
+	}
+	if err := v.evaluateBaselines(ctx, &result, pod); err != nil {
 		return admission.Errored(http.StatusInternalServerError, err)
 	}
+	// The resources of a Pod cannot change on update: in-place resizes go
+	// through the resize subresource, which is not admitted here.
+	if req.Operation != admissionv1.Update {
+		if err := v.evaluateResourceBounds(ctx, &result, pod); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+	}
 
-	for _, baseline := range baselines.Items {
-		if slices.Contains(baseline.Spec.ExcludedNamespaces, req.Namespace) {
+	var policies platformv1alpha1.ImageVerificationPolicyList
+	if err := v.Client.List(ctx, &policies, client.InNamespace(req.Namespace)); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(policies.Items) > 0 && v.verifier == nil {
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("image verifier is not initialized"))
+	}
+	for _, policy := range policies.Items {
+		violations := v.evaluateImageVerificationPolicy(ctx, pod, result.existing, &policy)
+		if len(violations) == 0 {
 			continue
 		}
 
-		if baseline.Spec.RunAsNonRoot {
-			if pod.Spec.SecurityContext == nil || pod.Spec.SecurityContext.RunAsNonRoot == nil || !*pod.Spec.SecurityContext.RunAsNonRoot {
-				msg := "Pod violates SecurityBaseline: must run as non-root"
-				v.Recorder.Event(&baseline, "Warning", "PodDenied", fmt.Sprintf("Denied Pod %s in namespace %s: %s", pod.Name, pod.Namespace, msg))
-				return admission.Denied(msg)
-			}
+		action := governance.EffectiveEnforcementAction(policy.Spec.EnforcementAction)
+		imageVerificationFailuresTotal.WithLabelValues(req.Namespace, policy.Name, string(action)).Add(float64(len(violations)))
+		v.applyEnforcementAction(&result, &policy, "ImageVerificationPolicy", action, violations)
+	}
+
+	return result.response()
+}
+
+// evaluateBaselines checks the Pod against every ClusterSecurityBaseline and
+// SecurityBaseline selecting it in the namespace of the result, honoring the
+// active PolicyExceptions of that namespace.
+func (v *PodValidator) evaluateBaselines(ctx context.Context, result *podValidationResult, pod *corev1.Pod) error {
+	var exceptions platformv1alpha1.PolicyExceptionList
+	if err := v.Client.List(ctx, &exceptions, client.InNamespace(result.namespace)); err != nil {
+		return err
+	}
+	activeExceptions := governance.ActivePolicyExceptions(exceptions.Items, time.Now())
+
+	baselines, namespaceAnnotations, err := governance.SelectBaselines(ctx, v.Client, result.namespace, pod, result.debugUser)
+	if err != nil {
+		return err
+	}
+	for _, selected := range baselines {
+		v.evaluateBaseline(result, selected.Object, selected.Kind, &selected.Baseline, pod, namespaceAnnotations, activeExceptions)
+	}
+	return nil
+}
+
+// evaluateBaseline checks the Pod against a baseline that applies to it and
+// records the violations on the object the baseline was read from (a
+// SecurityBaseline or a ClusterSecurityBaseline). Violations exempted by a
+// PolicyException are reported as warnings instead of being enforced.
+func (v *PodValidator) evaluateBaseline(result *podValidationResult, object runtime.Object, kind string,
+	baseline *platformv1alpha1.SecurityBaseline, pod *corev1.Pod, namespaceAnnotations map[string]string,
+	exceptions []platformv1alpha1.PolicyException) {
+	evaluate := func(target *corev1.Pod) []governance.Violation {
+		return governance.EvaluateSecurityBaseline(target, baseline, namespaceAnnotations)
+	}
+	violations := result.introduced(evaluate(pod), evaluate)
+	violations, exempted := governance.ExemptViolations(result.reroot(violations), exceptions, kind, baseline.Name, pod)
+	v.recordExemptions(result, exceptions, kind, exempted)
+	if v.Reports != nil {
+		v.Reports.Record(policyreport.TriggerAdmission, time.Now(), governance.BaselineReportResults(
+			result.reportSubject, kind, baseline, governance.Findings(violations), governance.Findings(exempted)))
+	}
+	if len(violations) == 0 {
+		return
+	}
+
+	action := governance.EffectiveEnforcementAction(baseline.Spec.EnforcementAction)
+	if result.kind == "Pod" {
+		podBaselineViolationsTotal.WithLabelValues(result.namespace, baseline.Name, string(action)).Add(float64(len(violations)))
+	} else {
+		workloadBaselineViolationsTotal.WithLabelValues(result.namespace, baseline.Name, string(action), result.kind).Add(float64(len(violations)))
+	}
+	v.applyEnforcementAction(result, object, kind, action, violations)
+}
+
+// evaluateResourceBounds checks the containers of the Pod against the resource
+// bounds of every WorkloadPolicy and ClusterWorkloadPolicy selecting it. The
+// Pod already carries the defaults of those policies, so out-of-bounds
+// resources are always denied.
+func (v *PodValidator) evaluateResourceBounds(ctx context.Context, result *podValidationResult, pod *corev1.Pod) error {
+	candidates, err := governance.ResourceBoundsPolicies(ctx, v.Client, result.namespace, pod)
+	if err != nil {
+		return err
+	}
+	for _, candidate := range candidates {
+		violations, err := governance.EvaluateResourceBounds(pod, candidate.Policy.Name, &candidate.Policy.Spec)
+		if err != nil {
+			return err
+		}
+		if len(violations) == 0 {
+			continue
 		}
 
-		if baseline.Spec.ReadOnlyRootFilesystem {
-			for _, c := range pod.Spec.Containers {
-				if c.SecurityContext == nil || c.SecurityContext.ReadOnlyRootFilesystem == nil || !*c.SecurityContext.ReadOnlyRootFilesystem {
-					msg := "Pod violates SecurityBaseline: all containers must have read-only root filesystem"
-					v.Recorder.Event(&baseline, "Warning", "PodDenied", fmt.Sprintf("Denied Pod %s in namespace %s: %s", pod.Name, pod.Namespace, msg))
-					return admission.Denied(msg)
-				}
-			}
-			for _, c := range pod.Spec.InitContainers {
-				if c.SecurityContext == nil || c.SecurityContext.ReadOnlyRootFilesystem == nil || !*c.SecurityContext.ReadOnlyRootFilesystem {
-					msg := "Pod violates SecurityBaseline: all init containers must have read-only root filesystem"
-					v.Recorder.Event(&baseline, "Warning", "PodDenied", fmt.Sprintf("Denied Pod %s in namespace %s: %s", pod.Name, pod.Namespace, msg))
-					return admission.Denied(msg)
-				}
+		podResourceBoundsViolationsTotal.WithLabelValues(result.namespace, candidate.Kind, candidate.Policy.Name).Add(float64(len(violations)))
+		v.applyEnforcementAction(result, candidate.Object, candidate.Kind, platformv1alpha1.EnforcementActionEnforce, violations)
+	}
+	return nil
+}
+
+// recordExemptions surfaces exempted violations as admission warnings, metrics
+// and an exemption event on each PolicyException that was used.
+func (v *PodValidator) recordExemptions(result *podValidationResult, exceptions []platformv1alpha1.PolicyException, kind string,
+	exempted []governance.Violation) {
+	for i := range exceptions {
+		exception := &exceptions[i]
+		var used []governance.Violation
+		for _, violation := range exempted {
+			if violation.Exception == exception.Name {
+				used = append(used, violation)
 			}
 		}
+		if len(used) == 0 {
+			continue
+		}
+
+		if result.kind == "Pod" {
+			podBaselineExemptionsTotal.WithLabelValues(result.namespace, used[0].Baseline, exception.Name).Add(float64(len(used)))
+		}
+		v.Recorder.Event(exception, "Normal", result.eventReason("Exempted"), formatViolations(
+			fmt.Sprintf("Exempted %s from %d violation(s) until %s:", result.describe(), len(used),
+				exception.Spec.ExpiresAt.UTC().Format(time.RFC3339)), used))
+		for _, violation := range used {
+			result.warnings = append(result.warnings, kind+" "+violation.String())
+		}
+	}
+}
+
+// podValidationResult accumulates the outcome of every policy evaluated for a
+// Pod, or for the Pod template of a workload.
+type podValidationResult struct {
+	// kind and name identify the admitted object: a Pod or a workload.
+	kind      string
+	name      string
+	namespace string
+	// reportSubject is the resource PolicyReport results are recorded against.
+	reportSubject corev1.ObjectReference
+	// templatePath is the path of the Pod template within a workload, or nil
+	// when a Pod is validated.
+	templatePath *field.Path
+	// existing is the Pod before its ephemeral containers are updated, or
+	// nil for every other request. Only violations the update introduces are
+	// reported, so running Pods admitted under an exception or before a
+	// baseline existed can still be debugged.
+	existing *corev1.Pod
+	// debugUser is the user updating the ephemeral containers, matched
+	// against the users and groups of debug baselines.
+	debugUser *authenticationv1.UserInfo
+
+	denied   []governance.Violation
+	warnings admission.Warnings
+}
+
+// describe names the admitted object in events.
+func (r *podValidationResult) describe() string {
+	return fmt.Sprintf("%s %s in namespace %s", r.kind, r.name, r.namespace)
+}
+
+// eventReason prefixes an event reason with Pod, or with Workload for the
+// Pod templates of workloads.
+func (r *podValidationResult) eventReason(reason string) string {
+	if r.kind == "Pod" {
+		return "Pod" + reason
+	}
+	return "Workload" + reason
+}
+
+// reroot moves the paths of violations found in the Pod template of a
+// workload below the template, e.g. spec.containers[0] becomes
+// spec.template.spec.containers[0] for a Deployment.
+func (r *podValidationResult) reroot(violations []governance.Violation) []governance.Violation {
+	if r.templatePath == nil {
+		return violations
+	}
+	for i := range violations {
+		if violations[i].Field != nil {
+			// field.Path cannot be re-rooted, but only its string form is used.
+			violations[i].Field = field.NewPath(r.templatePath.String() + "." + violations[i].Field.String())
+		}
+	}
+	return violations
+}
+
+// introduced drops the violations the existing Pod already has, as found by
+// evaluate, when the ephemeral containers of a running Pod are updated. A
+// violation already exists when the existing Pod violates the same rule at
+// the same field; messages are not compared, since Pod-level violations may
+// list the containers involved.
+func (r *podValidationResult) introduced(violations []governance.Violation,
+	evaluate func(*corev1.Pod) []governance.Violation) []governance.Violation {
+	if r.existing == nil || len(violations) == 0 {
+		return violations
+	}
+	type key struct {
+		rule  platformv1alpha1.SecurityBaselineRule
+		field string
+	}
+	existing := map[key]bool{}
+	for _, violation := range evaluate(r.existing) {
+		existing[key{violation.Rule, violation.Field.String()}] = true
+	}
+	return slices.DeleteFunc(violations, func(violation governance.Violation) bool {
+		return existing[key{violation.Rule, violation.Field.String()}]
+	})
+}
+
+// response denies the request when an Enforce policy was violated and
+// otherwise admits it, carrying the collected warnings either way.
+func (r *podValidationResult) response() admission.Response {
+	if len(r.denied) > 0 {
+		return deniedWithViolations(r.kind, r.denied).WithWarnings(r.warnings...)
 	}
+	return admission.Allowed("").WithWarnings(r.warnings...)
+}
 
-	return admission.Allowed("")
+// applyEnforcementAction records the violations of a single policy according
+// to its enforcement action: an event on the policy, plus an admission warning
+// (Warn) or a denial (Enforce).
+func (v *PodValidator) applyEnforcementAction(result *podValidationResult, policy runtime.Object, kind string,
+	action platformv1alpha1.EnforcementAction, violations []governance.Violation) {
+	switch action {
+	case platformv1alpha1.EnforcementActionWarn:
+		v.Recorder.Event(policy, "Warning", result.eventReason("Warned"), formatViolations(
+			fmt.Sprintf("Admitted %s with %d warning(s):", result.describe(), len(violations)), violations))
+		for _, violation := range violations {
+			result.warnings = append(result.warnings, kind+" "+violation.String())
+		}
+	case platformv1alpha1.EnforcementActionAudit:
+		v.Recorder.Event(policy, "Warning", result.eventReason("Audited"), formatViolations(
+			fmt.Sprintf("Audited %s with %d violation(s):", result.describe(), len(violations)), violations))
+	default:
+		v.Recorder.Event(policy, "Warning", result.eventReason("Denied"), formatViolations(
+			fmt.Sprintf("Denied %s with %d violation(s):", result.describe(), len(violations)), violations))
+		result.denied = append(result.denied, violations...)
+	}
+}
+
+// deniedWithViolations builds a denial that lists every violation in the message
+// and carries each one as a structured StatusCause.
+func deniedWithViolations(kind string, violations []governance.Violation) admission.Response {
+	resp := admission.Denied(formatViolations(
+		fmt.Sprintf("%s violates governance policies: %d violation(s) found:", kind, len(violations)), violations))
+	resp.Result.Details = &metav1.StatusDetails{Causes: statusCauses(violations)}
+	return resp
+}
+
+// formatViolations renders violations as a multi-line message suitable for an
+// admission response or an event.
+func formatViolations(header string, violations []governance.Violation) string {
+	lines := make([]string, 0, len(violations)+1)
+	lines = append(lines, header)
+	for _, v := range violations {
+		lines = append(lines, "- "+v.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// statusCauses converts violations into metav1.StatusCauses so that clients
+// can consume each violation as a structured field error.
+func statusCauses(violations []governance.Violation) []metav1.StatusCause {
+	causes := make([]metav1.StatusCause, 0, len(violations))
+	for _, v := range violations {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: v.String(),
+			Field:   v.Field.String(),
+		})
+	}
+	return causes
 }
 
 // SetupPodWebhookWithManager registers the Pod validating webhook with the Manager.
 // Uses imperative registration (mgr.GetWebhookServer().Register) because core/v1
 // types are not CRDs and cannot use the kubebuilder declarative webhook builder.
-func SetupPodWebhookWithManager(mgr ctrl.Manager) error {
+// Image verification keys held in Secrets are read from keyNamespace.
+func SetupPodWebhookWithManager(mgr ctrl.Manager, reports *policyreport.Store, keyNamespace string) error {
 	handler := &PodValidator{
-		Client: mgr.GetClient(),
+		Client:       mgr.GetClient(),
+		APIReader:    mgr.GetAPIReader(),
+		KeyNamespace: keyNamespace,
 		//nolint:staticcheck // controller-runtime recorder migration pending
 		Recorder: mgr.GetEventRecorderFor("pod-validator-webhook"),
+		Reports:  reports,
 		decoder:  admission.NewDecoder(mgr.GetScheme()),
+		verifier: newCosignVerifier(&http.Client{}),
 	}
 
 	mgr.GetWebhookServer().Register("/validate-core-v1-pod", &webhook.Admission{
@@ -3,17 +3,27 @@ package core
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport/v1alpha2"
 )
 
 func TestPodValidatorDeniesRootPod(t *testing.T) {
@@ -23,7 +33,7 @@ func TestPodValidatorDeniesRootPod(t *testing.T) {
 	baseline := &platformv1alpha1.SecurityBaseline{
 		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
 		Spec: platformv1alpha1.SecurityBaselineSpec{
-			RunAsNonRoot: true,
+			RunAsNonRoot: ptr.To(true),
 		},
 	}
 	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline).Build()
@@ -47,7 +57,7 @@ func TestPodValidatorAllowsExcludedNamespace(t *testing.T) {
 	baseline := &platformv1alpha1.SecurityBaseline{
 		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
 		Spec: platformv1alpha1.SecurityBaselineSpec{
-			RunAsNonRoot:       true,
+			RunAsNonRoot:       ptr.To(true),
 			ExcludedNamespaces: []string{"team-a"},
 		},
 	}
@@ -65,6 +75,35 @@ func TestPodValidatorAllowsExcludedNamespace(t *testing.T) {
 	}
 }
 
+func TestPodValidatorBaselineProfileAdmitsRootPodWithWritableRootFilesystem(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{Profile: platformv1alpha1.PodSecurityProfileBaseline},
+	}
+	validator := &PodValidator{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline).Build(),
+		Recorder: record.NewFakeRecorder(10),
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{RunAsUser: ptr.To[int64](0)},
+			Containers: []corev1.Container{{
+				Name:            "app",
+				SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: ptr.To(false)},
+			}},
+		},
+	}
+	resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod))
+	if !resp.Allowed {
+		t.Fatalf("expected the PSS baseline profile to admit a root Pod with a writable root filesystem: %s", resp.Result.Message)
+	}
+}
+
 func TestPodValidatorDeniesReadWriteRootFilesystem(t *testing.T) {
 	t.Parallel()
 
@@ -72,7 +111,7 @@ func TestPodValidatorDeniesReadWriteRootFilesystem(t *testing.T) {
 	baseline := &platformv1alpha1.SecurityBaseline{
 		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
 		Spec: platformv1alpha1.SecurityBaselineSpec{
-			ReadOnlyRootFilesystem: true,
+			ReadOnlyRootFilesystem: ptr.To(true),
 		},
 	}
 	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline).Build()
@@ -101,7 +140,7 @@ func TestPodValidatorDeniesInitContainerWithoutReadOnly(t *testing.T) {
 	baseline := &platformv1alpha1.SecurityBaseline{
 		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
 		Spec: platformv1alpha1.SecurityBaselineSpec{
-			ReadOnlyRootFilesystem: true,
+			ReadOnlyRootFilesystem: ptr.To(true),
 		},
 	}
 	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline).Build()
@@ -139,8 +178,8 @@ func TestPodValidatorAllowsCompliantPod(t *testing.T) {
 	baseline := &platformv1alpha1.SecurityBaseline{
 		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
 		Spec: platformv1alpha1.SecurityBaselineSpec{
-			RunAsNonRoot:           true,
-			ReadOnlyRootFilesystem: true,
+			RunAsNonRoot:           ptr.To(true),
+			ReadOnlyRootFilesystem: ptr.To(true),
 		},
 	}
 	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline).Build()
@@ -176,13 +215,13 @@ func TestPodValidatorMultipleBaselines(t *testing.T) {
 	baseline1 := &platformv1alpha1.SecurityBaseline{
 		ObjectMeta: metav1.ObjectMeta{Name: "baseline-1", Namespace: "team-a"},
 		Spec: platformv1alpha1.SecurityBaselineSpec{
-			RunAsNonRoot: true,
+			RunAsNonRoot: ptr.To(true),
 		},
 	}
 	baseline2 := &platformv1alpha1.SecurityBaseline{
 		ObjectMeta: metav1.ObjectMeta{Name: "baseline-2", Namespace: "team-a"},
 		Spec: platformv1alpha1.SecurityBaselineSpec{
-			ReadOnlyRootFilesystem: true,
+			ReadOnlyRootFilesystem: ptr.To(true),
 		},
 	}
 	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline1, baseline2).Build()
@@ -234,7 +273,7 @@ func TestPodValidatorNilPodSecurityContextDenied(t *testing.T) {
 	baseline := &platformv1alpha1.SecurityBaseline{
 		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
 		Spec: platformv1alpha1.SecurityBaselineSpec{
-			RunAsNonRoot: true,
+			RunAsNonRoot: ptr.To(true),
 		},
 	}
 	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline).Build()
@@ -264,7 +303,7 @@ func TestPodValidatorNilContainerSecurityContextDenied(t *testing.T) {
 	baseline := &platformv1alpha1.SecurityBaseline{
 		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
 		Spec: platformv1alpha1.SecurityBaselineSpec{
-			ReadOnlyRootFilesystem: true,
+			ReadOnlyRootFilesystem: ptr.To(true),
 		},
 	}
 	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline).Build()
@@ -295,7 +334,7 @@ func TestPodValidatorNoContainersReadOnlyBaselineAllowed(t *testing.T) {
 	baseline := &platformv1alpha1.SecurityBaseline{
 		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
 		Spec: platformv1alpha1.SecurityBaselineSpec{
-			ReadOnlyRootFilesystem: true,
+			ReadOnlyRootFilesystem: ptr.To(true),
 		},
 	}
 	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline).Build()
@@ -322,11 +361,11 @@ func TestPodValidatorMultipleBaselinesAllSatisfied(t *testing.T) {
 
 	baseline1 := &platformv1alpha1.SecurityBaseline{
 		ObjectMeta: metav1.ObjectMeta{Name: "baseline-nonroot", Namespace: "team-a"},
-		Spec:       platformv1alpha1.SecurityBaselineSpec{RunAsNonRoot: true},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{RunAsNonRoot: ptr.To(true)},
 	}
 	baseline2 := &platformv1alpha1.SecurityBaseline{
 		ObjectMeta: metav1.ObjectMeta{Name: "baseline-readonly", Namespace: "team-a"},
-		Spec:       platformv1alpha1.SecurityBaselineSpec{ReadOnlyRootFilesystem: true},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{ReadOnlyRootFilesystem: ptr.To(true)},
 	}
 	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline1, baseline2).Build()
 	validator := &PodValidator{
@@ -360,7 +399,7 @@ func TestPodValidatorMultipleInitContainersOneViolates(t *testing.T) {
 	readOnly := true
 	baseline := &platformv1alpha1.SecurityBaseline{
 		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
-		Spec:       platformv1alpha1.SecurityBaselineSpec{ReadOnlyRootFilesystem: true},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{ReadOnlyRootFilesystem: ptr.To(true)},
 	}
 	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline).Build()
 	validator := &PodValidator{
@@ -394,6 +433,285 @@ func TestPodValidatorMultipleInitContainersOneViolates(t *testing.T) {
 	}
 }
 
+func TestPodValidatorWarnModeAllowsWithWarnings(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			EnforcementAction: platformv1alpha1.EnforcementActionWarn,
+			RunAsNonRoot:      ptr.To(true),
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline).Build()
+	recorder := record.NewFakeRecorder(10)
+	validator := &PodValidator{
+		Client:   cl,
+		Recorder: recorder,
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	pod := &corev1.Pod{}
+	resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod))
+	if !resp.Allowed {
+		t.Fatalf("expected pod to be allowed in Warn mode, got denied: %s", resp.Result.Message)
+	}
+	if len(resp.Warnings) != 1 {
+		t.Fatalf("expected exactly one admission warning, got %v", resp.Warnings)
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "PodWarned") {
+		t.Fatalf("expected PodWarned event, got %q", event)
+	}
+}
+
+func TestPodValidatorAuditModeAllowsSilently(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			EnforcementAction: platformv1alpha1.EnforcementActionAudit,
+			RunAsNonRoot:      ptr.To(true),
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline).Build()
+	recorder := record.NewFakeRecorder(10)
+	validator := &PodValidator{
+		Client:   cl,
+		Recorder: recorder,
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	pod := &corev1.Pod{}
+	resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod))
+	if !resp.Allowed {
+		t.Fatalf("expected pod to be allowed in Audit mode, got denied: %s", resp.Result.Message)
+	}
+	if len(resp.Warnings) != 0 {
+		t.Fatalf("expected no admission warnings in Audit mode, got %v", resp.Warnings)
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "PodAudited") {
+		t.Fatalf("expected PodAudited event, got %q", event)
+	}
+}
+
+func TestPodValidatorEnforceBaselineDeniesAlongsideWarnBaseline(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	warnBaseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline-warn", Namespace: "team-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			EnforcementAction: platformv1alpha1.EnforcementActionWarn,
+			RunAsNonRoot:      ptr.To(true),
+		},
+	}
+	enforceBaseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline-enforce", Namespace: "team-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			EnforcementAction:      platformv1alpha1.EnforcementActionEnforce,
+			ReadOnlyRootFilesystem: ptr.To(true),
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(warnBaseline, enforceBaseline).Build()
+	validator := &PodValidator{
+		Client:   cl,
+		Recorder: record.NewFakeRecorder(10),
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}},
+		},
+	}
+	resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod))
+	if resp.Allowed {
+		t.Fatalf("expected pod to be denied by the Enforce baseline")
+	}
+}
+
+func TestPodValidatorReportsAllViolationsInOneDenial(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	baseline1 := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline-nonroot", Namespace: "team-a"},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{RunAsNonRoot: ptr.To(true)},
+	}
+	baseline2 := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline-readonly", Namespace: "team-a"},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{ReadOnlyRootFilesystem: ptr.To(true)},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline1, baseline2).Build()
+	validator := &PodValidator{
+		Client:   cl,
+		Recorder: record.NewFakeRecorder(10),
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers:     []corev1.Container{{Name: "app"}, {Name: "sidecar"}},
+			InitContainers: []corev1.Container{{Name: "init"}},
+		},
+	}
+	resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod))
+	if resp.Allowed {
+		t.Fatalf("expected pod to be denied")
+	}
+	if resp.Result.Details == nil || len(resp.Result.Details.Causes) != 4 {
+		t.Fatalf("expected 4 structured causes, got %+v", resp.Result.Details)
+	}
+
+	expectedFields := []string{
+		"spec.securityContext.runAsNonRoot",
+		"spec.containers[0].securityContext.readOnlyRootFilesystem",
+		"spec.containers[1].securityContext.readOnlyRootFilesystem",
+		"spec.initContainers[0].securityContext.readOnlyRootFilesystem",
+	}
+	for i, cause := range resp.Result.Details.Causes {
+		if cause.Field != expectedFields[i] {
+			t.Fatalf("expected cause %d field %q, got %q", i, expectedFields[i], cause.Field)
+		}
+	}
+	for _, fragment := range []string{"[baseline-nonroot]", "[baseline-readonly]", `container "sidecar"`, `container "init"`} {
+		if !strings.Contains(resp.Result.Message, fragment) {
+			t.Fatalf("expected denial message to contain %q, got %q", fragment, resp.Result.Message)
+		}
+	}
+}
+
+func TestPodValidatorSkipsBaselinesNotSelectingPod(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-baseline", Namespace: "team-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			PodSelector:  &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}},
+			RunAsNonRoot: ptr.To(true),
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline).Build()
+	validator := &PodValidator{
+		Client:   cl,
+		Recorder: record.NewFakeRecorder(10),
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	batchPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"tier": "batch"}}}
+	if resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", batchPod)); !resp.Allowed {
+		t.Fatalf("expected pod not selected by the baseline to be allowed: %s", resp.Result.Message)
+	}
+
+	webPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"tier": "web"}}}
+	if resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", webPod)); resp.Allowed {
+		t.Fatalf("expected pod selected by the baseline to be denied")
+	}
+}
+
+func TestPodValidatorEnforcesClusterBaselinesSelectingNamespace(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	clusterBaseline := &platformv1alpha1.ClusterSecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "production"},
+		Spec: platformv1alpha1.ClusterSecurityBaselineSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "production"}},
+			SecurityBaselineSpec: platformv1alpha1.SecurityBaselineSpec{
+				RunAsNonRoot:       ptr.To(true),
+				ExcludedNamespaces: []string{"prod-system"},
+			},
+		},
+	}
+	// A namespaced baseline cannot relax a cluster baseline: both must pass.
+	relaxed := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "relaxed", Namespace: "prod-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			EnforcementAction: platformv1alpha1.EnforcementActionAudit,
+		},
+	}
+	namespaces := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod-a", Labels: map[string]string{"env": "production"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod-system", Labels: map[string]string{"env": "production"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-a", Labels: map[string]string{"env": "dev"}}},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(clusterBaseline, relaxed).WithObjects(namespaces...).Build()
+	recorder := record.NewFakeRecorder(10)
+	validator := &PodValidator{
+		Client:   cl,
+		Recorder: recorder,
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	resp := validator.Handle(context.Background(), newAdmissionRequest(t, "prod-a", &corev1.Pod{}))
+	if resp.Allowed {
+		t.Fatalf("expected pod in a selected namespace to be denied")
+	}
+	if !strings.Contains(resp.Result.Message, "[production]") {
+		t.Fatalf("expected denial to name the cluster baseline, got %q", resp.Result.Message)
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "PodDenied") {
+		t.Fatalf("expected PodDenied event on the cluster baseline, got %q", event)
+	}
+
+	for _, namespace := range []string{"dev-a", "prod-system"} {
+		if resp := validator.Handle(context.Background(), newAdmissionRequest(t, namespace, &corev1.Pod{})); !resp.Allowed {
+			t.Fatalf("expected pod in namespace %s to be allowed: %s", namespace, resp.Result.Message)
+		}
+	}
+}
+
+func TestPodValidatorRecordsPolicyReportResults(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted", Namespace: "team-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			EnforcementAction:  platformv1alpha1.EnforcementActionAudit,
+			RunAsNonRoot:       ptr.To(true),
+			DisallowPrivileged: ptr.To(true),
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline).Build()
+	store := policyreport.NewStore(nil)
+	validator := &PodValidator{
+		Client:   cl,
+		Recorder: record.NewFakeRecorder(10),
+		Reports:  store,
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "web-5d8f-",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-5d8f", Controller: ptr.To(true),
+			}},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+	if resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod)); !resp.Allowed {
+		t.Fatalf("expected the audited pod to be allowed: %s", resp.Result.Message)
+	}
+
+	results := resultsByRule(flushPolicyReport(t, store, "team-a"))
+	runAsNonRoot := results["SecurityBaseline/restricted/runAsNonRoot"]
+	if runAsNonRoot.Result != v1alpha2.PolicyResultFail || runAsNonRoot.Properties["trigger"] != "admission" {
+		t.Fatalf("expected an admission fail for runAsNonRoot, got %+v", runAsNonRoot)
+	}
+	if subject := runAsNonRoot.Subjects[0]; subject.Kind != "ReplicaSet" || subject.Name != "web-5d8f" || subject.Namespace != "team-a" {
+		t.Fatalf("expected the result to be reported against the ReplicaSet, got %+v", subject)
+	}
+	if privileged := results["SecurityBaseline/restricted/disallowPrivileged"]; privileged.Result != v1alpha2.PolicyResultPass {
+		t.Fatalf("expected a pass for disallowPrivileged, got %+v", privileged)
+	}
+}
+
 func newWebhookTestScheme(t *testing.T) *runtime.Scheme {
 	t.Helper()
 
@@ -401,6 +719,12 @@ func newWebhookTestScheme(t *testing.T) *runtime.Scheme {
 	if err := corev1.AddToScheme(scheme); err != nil {
 		t.Fatalf("failed to add corev1 to scheme: %v", err)
 	}
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add appsv1 to scheme: %v", err)
+	}
+	if err := batchv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add batchv1 to scheme: %v", err)
+	}
 	if err := platformv1alpha1.AddToScheme(scheme); err != nil {
 		t.Fatalf("failed to add platformv1alpha1 to scheme: %v", err)
 	}
@@ -424,3 +748,202 @@ func newAdmissionRequest(t *testing.T, namespace string, pod *corev1.Pod) admiss
 		},
 	}
 }
+
+// flushPolicyReport writes the pending results of store and returns the
+// results of the PolicyReport of namespace.
+func flushPolicyReport(t *testing.T, store *policyreport.Store, namespace string) []v1alpha2.PolicyReportResult {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add wgpolicyk8s.io/v1alpha2 to scheme: %v", err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	writer := &policyreport.Writer{Client: cl, Reader: cl, Store: store}
+	writer.Flush(context.Background(), time.Now())
+
+	report := &v1alpha2.PolicyReport{}
+	if err := cl.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: policyreport.ReportName}, report); err != nil {
+		t.Fatalf("expected a PolicyReport in namespace %s: %v", namespace, err)
+	}
+	return report.Results
+}
+
+func resultsByRule(results []v1alpha2.PolicyReportResult) map[string]v1alpha2.PolicyReportResult {
+	byRule := make(map[string]v1alpha2.PolicyReportResult, len(results))
+	for _, result := range results {
+		byRule[result.Category+"/"+result.Policy+"/"+result.Rule] = result
+	}
+	return byRule
+}
+
+func TestPodValidatorReadsIDRangesFromNamespace(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "team-a",
+		Annotations: map[string]string{"openshift.io/sa.scc.uid-range": "1000680000/10000"},
+	}}
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-uids", Namespace: "team-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			RunAsUser: &platformv1alpha1.IDRangePolicy{NamespaceAnnotation: "openshift.io/sa.scc.uid-range"},
+		},
+	}
+	validator := &PodValidator{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace, baseline).Build(),
+		Recorder: record.NewFakeRecorder(10),
+		decoder:  admission.NewDecoder(scheme),
+	}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		SecurityContext: &corev1.PodSecurityContext{RunAsUser: ptr.To[int64](1000680000)},
+		Containers:      []corev1.Container{{Name: "app"}},
+	}}
+
+	if resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod)); !resp.Allowed {
+		t.Fatalf("expected a UID within the namespace range to be allowed: %s", resp.Result.Message)
+	}
+
+	pod.Spec.SecurityContext.RunAsUser = ptr.To[int64](1000)
+	resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod))
+	if resp.Allowed {
+		t.Fatal("expected a UID outside of the namespace range to be denied")
+	}
+	if !strings.Contains(resp.Result.Message, "use a UID within 1000680000-1000689999") {
+		t.Fatalf("expected the namespace range in the denial, got %q", resp.Result.Message)
+	}
+}
+
+func TestPodValidatorDeniesPodsOutsideResourceBounds(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	validator := &PodValidator{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"tier": "shared"}}},
+			&platformv1alpha1.WorkloadPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "team-a"},
+				Spec: platformv1alpha1.WorkloadPolicySpec{
+					MergeStrategy: platformv1alpha1.MergeStrategyHighestPriorityOnly,
+					MaxLimits:     map[string]string{"cpu": "2"},
+				},
+			},
+			&platformv1alpha1.ClusterWorkloadPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared-ratio"},
+				Spec: platformv1alpha1.ClusterWorkloadPolicySpec{
+					NamespaceSelector:  &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "shared"}},
+					WorkloadPolicySpec: platformv1alpha1.WorkloadPolicySpec{MaxLimitRequestRatio: map[string]string{"cpu": "4"}},
+				},
+			},
+		).Build(),
+		Recorder: record.NewFakeRecorder(10),
+		decoder:  admission.NewDecoder(scheme),
+	}
+	pod := func(request, limit string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Resources: cpuResources(request, limit)}}},
+		}
+	}
+
+	if resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod("500m", "2"))); !resp.Allowed {
+		t.Fatalf("expected a Pod within bounds to be allowed: %s", resp.Result.Message)
+	}
+
+	resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod("100m", "4")))
+	if resp.Allowed {
+		t.Fatal("expected a Pod outside of the bounds to be denied")
+	}
+	if !strings.Contains(resp.Result.Message, "[small]") || !strings.Contains(resp.Result.Message, "[shared-ratio]") {
+		t.Fatalf("expected the bounds of every selecting policy to be enforced, got %q", resp.Result.Message)
+	}
+
+	update := newAdmissionRequest(t, "team-a", pod("100m", "4"))
+	update.Operation = admissionv1.Update
+	if resp := validator.Handle(context.Background(), update); !resp.Allowed {
+		t.Fatalf("expected updates of running Pods not to be checked against bounds: %s", resp.Result.Message)
+	}
+}
+
+func TestPodValidatorHonorsPolicyExceptions(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			ReadOnlyRootFilesystem: ptr.To(true),
+		},
+	}
+	exception := newPolicyException("legacy", "baseline", time.Now().Add(time.Hour), platformv1alpha1.RuleReadOnlyRootFilesystem)
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline, exception).Build()
+	recorder := record.NewFakeRecorder(10)
+	validator := &PodValidator{
+		Client:   cl,
+		Recorder: recorder,
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}
+	resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod))
+	if !resp.Allowed {
+		t.Fatalf("expected exempted pod to be allowed: %s", resp.Result.Message)
+	}
+	if len(resp.Warnings) != 1 || !strings.Contains(resp.Warnings[0], "exempted by PolicyException legacy") {
+		t.Fatalf("expected the exemption to be reported as a warning, got %v", resp.Warnings)
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "PodExempted") {
+		t.Fatalf("expected a PodExempted event, got %q", event)
+	}
+}
+
+func TestPodValidatorIgnoresExpiredPolicyExceptions(t *testing.T) {
+	t.Parallel()
+
+	scheme := newWebhookTestScheme(t)
+	baseline := &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: "team-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			ReadOnlyRootFilesystem: ptr.To(true),
+		},
+	}
+	exception := newPolicyException("legacy", "baseline", time.Now().Add(-time.Minute), platformv1alpha1.RuleReadOnlyRootFilesystem)
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseline, exception).Build()
+	validator := &PodValidator{
+		Client:   cl,
+		Recorder: record.NewFakeRecorder(10),
+		decoder:  admission.NewDecoder(scheme),
+	}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}
+	if resp := validator.Handle(context.Background(), newAdmissionRequest(t, "team-a", pod)); resp.Allowed {
+		t.Fatalf("expected pod to be denied once the exception expired")
+	}
+}
+
+func newPolicyException(name, baseline string, expiresAt time.Time, rules ...platformv1alpha1.SecurityBaselineRule) *platformv1alpha1.PolicyException {
+	return &platformv1alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+		Spec: platformv1alpha1.PolicyExceptionSpec{
+			Baseline:      platformv1alpha1.BaselineReference{Name: baseline},
+			Rules:         rules,
+			Justification: "legacy image writes to its root filesystem",
+			Owner:         "team-a",
+			ExpiresAt:     metav1.NewTime(expiresAt),
+		},
+	}
+}
+
+// cpuResources returns the requirements of a container requesting and
+// limiting CPU, leaving out empty quantities.
+func cpuResources(request, limit string) corev1.ResourceRequirements {
+	var resources corev1.ResourceRequirements
+	if request != "" {
+		resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(request)}
+	}
+	if limit != "" {
+		resources.Limits = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(limit)}
+	}
+	return resources
+}
@@ -0,0 +1,295 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport"
+)
+
+const (
+	// mutateTemplateAnnotation opts a workload, or every workload of a
+	// namespace, into having WorkloadPolicy and TelemetryProfile defaults
+	// applied to its Pod template. A value on the workload overrides the value
+	// on its namespace.
+	mutateTemplateAnnotation = "core.platform.f3nr1r.io/mutate-template"
+	// templateDefaultsAppliedAnnotation marks a Pod template the defaults were
+	// applied to. Pods created from it by a controller are not mutated again
+	// while the workload is opted in. It is removed from the templates of
+	// workloads that are not.
+	templateDefaultsAppliedAnnotation = "core.platform.f3nr1r.io/defaults-applied"
+)
+
+// WorkloadMutator applies WorkloadPolicy and TelemetryProfile defaults to the
+// Pod templates of workload controllers that opted in, so the defaults are
+// visible in the workload spec instead of only on the Pods created from it.
+type WorkloadMutator struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	// Reports receives a PolicyReport result for every policy applied to a
+	// Pod template. Reporting is disabled when nil.
+	Reports *policyreport.Store
+	decoder admission.Decoder
+}
+
+// InjectDecoder injects the decoder for admission requests.
+func (w *WorkloadMutator) InjectDecoder(d admission.Decoder) error {
+	w.decoder = d
+	return nil
+}
+
+// +kubebuilder:webhook:path=/mutate-workloads,mutating=true,failurePolicy=fail,sideEffects=None,groups=apps,resources=deployments;statefulsets;daemonsets,verbs=create;update,versions=v1,name=mworkload-apps.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-workloads,mutating=true,failurePolicy=fail,sideEffects=None,groups=batch,resources=jobs;cronjobs,verbs=create;update,versions=v1,name=mworkload-batch.kb.io,admissionReviewVersions=v1
+
+// +kubebuilder:rbac:groups=apps,resources=deployments;replicasets;statefulsets;daemonsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch
+
+// Handle applies the defaults the Pod mutating webhook would apply to the Pod
+// template of an incoming workload that opted in through the
+// core.platform.f3nr1r.io/mutate-template annotation. Updates that leave the
+// Pod template unchanged are admitted as they are, so enabling a policy never
+// rolls out existing workloads and immutable Job templates are left alone.
+// CronJobs pass their opt-in on to the Jobs they create instead.
+func (w *WorkloadMutator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if w.decoder == nil {
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("admission decoder is not initialized"))
+	}
+
+	object, err := newWorkloadObject(req.Kind)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := w.decoder.Decode(req, object); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if cronJob, ok := object.(*batchv1.CronJob); ok {
+		return mutateCronJob(req, cronJob)
+	}
+	template, _ := podTemplateOf(object)
+
+	if req.Operation == admissionv1.Update {
+		oldObject, _ := newWorkloadObject(req.Kind)
+		if err := w.decoder.DecodeRaw(req.OldObject, oldObject); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if oldTemplate, _ := podTemplateOf(oldObject); equality.Semantic.DeepEqual(template, oldTemplate) {
+			return admission.Allowed("Pod template unchanged")
+		}
+	}
+
+	enabled, warnings, err := templateMutationEnabled(ctx, w.Client, req.Namespace, object)
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+	if !enabled {
+		// A marker the defaults were not applied for would exempt the Pods of
+		// the workload from them.
+		if _, marked := template.Annotations[templateDefaultsAppliedAnnotation]; !marked {
+			return admission.Allowed("Pod template mutation not enabled").WithWarnings(warnings...)
+		}
+		delete(template.Annotations, templateDefaultsAppliedAnnotation)
+		return patchWorkload(req, object).WithWarnings(warnings...)
+	}
+
+	name := cmp.Or(object.GetName(), object.GetGenerateName())
+	workloadlog.Info("Mutating workload Pod template", "kind", req.Kind.Kind, "name", name, "namespace", req.Namespace)
+
+	pod := &corev1.Pod{
+		ObjectMeta: *template.ObjectMeta.DeepCopy(),
+		Spec:       *template.Spec.DeepCopy(),
+	}
+	mutation := &podMutation{
+		target: fmt.Sprintf("%s %s Pod template in namespace %s", req.Kind.Kind, name, req.Namespace),
+		subject: corev1.ObjectReference{
+			APIVersion: metav1.GroupVersion{Group: req.Kind.Group, Version: req.Kind.Version}.String(),
+			Kind:       req.Kind.Kind,
+			Namespace:  req.Namespace,
+			Name:       name,
+			UID:        object.GetUID(),
+		},
+	}
+	mutator := &PodMutator{Client: w.Client, Recorder: w.Recorder}
+	mutated, err := mutator.applyDefaults(ctx, req.Namespace, pod, mutation)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	remediated, err := mutator.applyRemediation(ctx, req.Namespace, pod, mutation)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	mutated = mutated || remediated
+	if w.Reports != nil {
+		w.Reports.Record(policyreport.TriggerAdmission, time.Now(), mutation.results)
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	if !mutated && pod.Annotations[templateDefaultsAppliedAnnotation] == "true" {
+		return admission.Allowed("No mutations applied")
+	}
+	pod.Annotations[templateDefaultsAppliedAnnotation] = "true"
+	template.ObjectMeta = pod.ObjectMeta
+	template.Spec = pod.Spec
+	return patchWorkload(req, object)
+}
+
+// templateMutationEnabled reports whether the workload opted into Pod template
+// mutation, either itself or through its namespace. An invalid namespace
+// annotation is not the fault of the workload, so it disables mutation with a
+// warning instead of denying every workload of the namespace.
+func templateMutationEnabled(ctx context.Context, c client.Reader, namespace string,
+	object metav1.Object) (bool, admission.Warnings, error) {
+	if raw, exists := object.GetAnnotations()[mutateTemplateAnnotation]; exists {
+		enabled, err := parseMutateTemplateAnnotation(raw)
+		return enabled, nil, err
+	}
+
+	var ns corev1.Namespace
+	if err := c.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+		return false, nil, client.IgnoreNotFound(err)
+	}
+	raw, exists := ns.Annotations[mutateTemplateAnnotation]
+	if !exists {
+		return false, nil, nil
+	}
+	enabled, err := parseMutateTemplateAnnotation(raw)
+	if err != nil {
+		workloadlog.Info("Ignoring invalid namespace annotation", "namespace", namespace, "annotation", mutateTemplateAnnotation,
+			"value", raw)
+		return false, admission.Warnings{fmt.Sprintf("Pod template defaults not applied: namespace %s has %v", namespace, err)}, nil
+	}
+	return enabled, nil, nil
+}
+
+// parseMutateTemplateAnnotation parses a core.platform.f3nr1r.io/mutate-template
+// annotation value.
+func parseMutateTemplateAnnotation(raw string) (bool, error) {
+	enabled, err := strconv.ParseBool(strings.TrimSpace(raw))
+	if err != nil {
+		return false, fmt.Errorf("invalid %s annotation value %q: must be true or false", mutateTemplateAnnotation, raw)
+	}
+	return enabled, nil
+}
+
+// mutateCronJob copies the opt-in annotation of a CronJob to its jobTemplate
+// instead of applying defaults to its Pod template. Defaults written into the
+// CronJob would be copied into every Job it creates and never follow policy
+// changes; this way each Job opts in itself and gets the defaults in effect
+// when it is created. A defaults-applied marker left in the jobTemplate is
+// removed for the same reason.
+func mutateCronJob(req admission.Request, cronJob *batchv1.CronJob) admission.Response {
+	original := cronJob.DeepCopy()
+	if raw, exists := cronJob.Annotations[mutateTemplateAnnotation]; exists {
+		enabled, err := parseMutateTemplateAnnotation(raw)
+		if err != nil {
+			return admission.Denied(err.Error())
+		}
+		metav1.SetMetaDataAnnotation(&cronJob.Spec.JobTemplate.ObjectMeta, mutateTemplateAnnotation, strconv.FormatBool(enabled))
+	}
+	delete(cronJob.Spec.JobTemplate.Spec.Template.Annotations, templateDefaultsAppliedAnnotation)
+	if equality.Semantic.DeepEqual(original, cronJob) {
+		return admission.Allowed("No mutations applied")
+	}
+	return patchWorkload(req, cronJob)
+}
+
+// patchWorkload returns a response patching the submitted workload into object.
+func patchWorkload(req admission.Request, object client.Object) admission.Response {
+	marshaled, err := json.Marshal(object)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// templateDefaultsApplied reports whether a Pod was created by a controller
+// from a Pod template the WorkloadMutator already applied the defaults to.
+// Such Pods do not get the defaults applied again, so a policy changed in
+// between cannot give the Pods of one workload different defaults than their
+// template. Baseline remediation still applies to them. Anyone can write the
+// marker into a template, so it is only trusted while the workload the Pod was
+// created from is opted in; if in doubt, the defaults are applied again.
+func templateDefaultsApplied(ctx context.Context, c client.Reader, namespace string, pod *corev1.Pod) (bool, error) {
+	if pod.Annotations[templateDefaultsAppliedAnnotation] != "true" {
+		return false, nil
+	}
+	workload, err := templateWorkloadOf(ctx, c, namespace, metav1.GetControllerOf(pod))
+	if workload == nil || err != nil {
+		return false, err
+	}
+	enabled, _, err := templateMutationEnabled(ctx, c, namespace, workload)
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return enabled, nil
+}
+
+// templateWorkloadOf returns the metadata of the workload whose Pod template
+// the Pods of owner are created from: owner itself, or the Deployment of a
+// ReplicaSet. It returns nil for controllers the WorkloadMutator does not
+// handle and for owners that no longer exist.
+func templateWorkloadOf(ctx context.Context, c client.Reader, namespace string,
+	owner *metav1.OwnerReference) (*metav1.PartialObjectMetadata, error) {
+	for owner != nil {
+		gvk := schema.FromAPIVersionAndKind(owner.APIVersion, owner.Kind)
+		switch gvk {
+		case appsv1.SchemeGroupVersion.WithKind("Deployment"), appsv1.SchemeGroupVersion.WithKind("StatefulSet"),
+			appsv1.SchemeGroupVersion.WithKind("DaemonSet"), appsv1.SchemeGroupVersion.WithKind("ReplicaSet"),
+			batchv1.SchemeGroupVersion.WithKind("Job"):
+		default:
+			return nil, nil
+		}
+
+		workload := &metav1.PartialObjectMetadata{}
+		workload.SetGroupVersionKind(gvk)
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: owner.Name}, workload); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		if workload.UID != owner.UID {
+			return nil, nil
+		}
+		if gvk.Kind != "ReplicaSet" {
+			return workload, nil
+		}
+		owner = metav1.GetControllerOf(workload)
+	}
+	return nil, nil
+}
+
+// SetupWorkloadMutatorWebhookWithManager registers the workload mutating
+// webhook with the Manager using imperative registration.
+func SetupWorkloadMutatorWebhookWithManager(mgr ctrl.Manager, reports *policyreport.Store) error {
+	handler := &WorkloadMutator{
+		Client: mgr.GetClient(),
+		//nolint:staticcheck // controller-runtime recorder migration pending
+		Recorder: mgr.GetEventRecorderFor("workload-mutator-webhook"),
+		Reports:  reports,
+		decoder:  admission.NewDecoder(mgr.GetScheme()),
+	}
+
+	mgr.GetWebhookServer().Register("/mutate-workloads", &webhook.Admission{
+		Handler: handler,
+	})
+	return nil
+}
@@ -0,0 +1,290 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+func newWorkloadMutator(t *testing.T, namespaceAnnotations map[string]string) (*WorkloadMutator, *record.FakeRecorder) {
+	t.Helper()
+
+	scheme := newWebhookTestScheme(t)
+	objects := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Annotations: namespaceAnnotations}},
+		&platformv1alpha1.WorkloadPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "team-a"},
+			Spec: platformv1alpha1.WorkloadPolicySpec{
+				MandatoryLabels: map[string]string{"cost-center": "platform"},
+				DefaultRequests: map[string]string{"cpu": "250m"},
+			},
+		},
+		&platformv1alpha1.TelemetryProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "otel", Namespace: "team-a"},
+			Spec: platformv1alpha1.TelemetryProfileSpec{
+				InjectEnvVars:   true,
+				TracingEndpoint: "http://otel:4317",
+			},
+		},
+	}
+	recorder := record.NewFakeRecorder(10)
+	return &WorkloadMutator{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		Recorder: recorder,
+		decoder:  admission.NewDecoder(scheme),
+	}, recorder
+}
+
+func optedInMeta() *metav1.ObjectMeta {
+	return &metav1.ObjectMeta{Name: "web", Namespace: "team-a", Annotations: map[string]string{mutateTemplateAnnotation: "true"}}
+}
+
+func marshalPatches(t *testing.T, resp admission.Response) string {
+	t.Helper()
+
+	patches, err := json.Marshal(resp.Patches)
+	if err != nil {
+		t.Fatalf("failed to marshal patches: %v", err)
+	}
+	return string(patches)
+}
+
+func TestWorkloadMutatorAppliesDefaultsToOptedInTemplate(t *testing.T) {
+	t.Parallel()
+
+	mutator, recorder := newWorkloadMutator(t, nil)
+	deployment := rootDeployment()
+	deployment.ObjectMeta = *optedInMeta()
+
+	resp := mutator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create, deploymentKind, deployment, nil))
+	if !resp.Allowed {
+		t.Fatalf("expected the Deployment to be allowed: %s", resp.Result.Message)
+	}
+	patches := marshalPatches(t, resp)
+	for _, want := range []string{"/spec/template/", "250m", "http://otel:4317", "cost-center", templateDefaultsAppliedAnnotation} {
+		if !strings.Contains(patches, want) {
+			t.Fatalf("expected patches to contain %q, got %s", want, patches)
+		}
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "Deployment web Pod template in namespace team-a") {
+		t.Fatalf("expected the event to name the Pod template, got %q", event)
+	}
+}
+
+func TestWorkloadMutatorHonoursNamespaceOptIn(t *testing.T) {
+	t.Parallel()
+
+	mutator, _ := newWorkloadMutator(t, map[string]string{mutateTemplateAnnotation: "true"})
+
+	resp := mutator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create, deploymentKind, rootDeployment(), nil))
+	if !resp.Allowed || len(resp.Patches) == 0 {
+		t.Fatalf("expected the namespace opt-in to mutate the Deployment, got %+v", resp)
+	}
+
+	optedOut := rootDeployment()
+	optedOut.Annotations = map[string]string{mutateTemplateAnnotation: "false"}
+	resp = mutator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create, deploymentKind, optedOut, nil))
+	if !resp.Allowed || len(resp.Patches) != 0 {
+		t.Fatalf("expected the workload annotation to override the namespace, got %+v", resp)
+	}
+}
+
+func TestWorkloadMutatorLeavesTemplatesAloneWithoutOptIn(t *testing.T) {
+	t.Parallel()
+
+	mutator, _ := newWorkloadMutator(t, nil)
+
+	resp := mutator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create, deploymentKind, rootDeployment(), nil))
+	if !resp.Allowed || len(resp.Patches) != 0 {
+		t.Fatalf("expected no mutation without an opt-in, got %+v", resp)
+	}
+}
+
+func TestWorkloadMutatorDeniesInvalidOptIn(t *testing.T) {
+	t.Parallel()
+
+	mutator, _ := newWorkloadMutator(t, nil)
+	deployment := rootDeployment()
+	deployment.Annotations = map[string]string{mutateTemplateAnnotation: "yes please"}
+
+	resp := mutator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create, deploymentKind, deployment, nil))
+	if resp.Allowed || !strings.Contains(resp.Result.Message, "invalid core.platform.f3nr1r.io/mutate-template annotation") {
+		t.Fatalf("expected an invalid annotation to be denied, got %+v", resp.Result)
+	}
+}
+
+func TestWorkloadMutatorSkipsUpdatesKeepingTheTemplate(t *testing.T) {
+	t.Parallel()
+
+	mutator, _ := newWorkloadMutator(t, nil)
+	oldDeployment := rootDeployment()
+	oldDeployment.ObjectMeta = *optedInMeta()
+	deployment := oldDeployment.DeepCopy()
+	deployment.Spec.Replicas = ptr.To(int32(5))
+
+	resp := mutator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Update, deploymentKind, deployment, oldDeployment))
+	if !resp.Allowed || len(resp.Patches) != 0 {
+		t.Fatalf("expected a scale-only update not to be mutated, got %+v", resp)
+	}
+}
+
+func TestWorkloadMutatorIgnoresInvalidNamespaceOptIn(t *testing.T) {
+	t.Parallel()
+
+	mutator, _ := newWorkloadMutator(t, map[string]string{mutateTemplateAnnotation: "yes please"})
+
+	resp := mutator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create, deploymentKind, rootDeployment(), nil))
+	if !resp.Allowed || len(resp.Patches) != 0 {
+		t.Fatalf("expected an invalid namespace annotation to admit the Deployment unchanged, got %+v", resp)
+	}
+	if len(resp.Warnings) != 1 || !strings.Contains(resp.Warnings[0], "namespace team-a has invalid") {
+		t.Fatalf("expected a warning naming the namespace, got %v", resp.Warnings)
+	}
+}
+
+func TestWorkloadMutatorPassesCronJobOptInToJobs(t *testing.T) {
+	t.Parallel()
+
+	mutator, _ := newWorkloadMutator(t, nil)
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: *optedInMeta(),
+		Spec: batchv1.CronJobSpec{
+			Schedule: "@hourly",
+			JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{templateDefaultsAppliedAnnotation: "true"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "job", Image: "busybox"}}},
+			}}},
+		},
+	}
+	kind := metav1.GroupVersionKind{Group: "batch", Version: "v1", Kind: "CronJob"}
+
+	resp := mutator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create, kind, cronJob, nil))
+	if !resp.Allowed {
+		t.Fatalf("expected the CronJob to be allowed: %s", resp.Result.Message)
+	}
+	patches := marshalPatches(t, resp)
+	if !strings.Contains(patches, `"path":"/spec/jobTemplate/metadata/annotations"`) || !strings.Contains(patches, mutateTemplateAnnotation) {
+		t.Fatalf("expected the opt-in to be copied to the jobTemplate, got %s", patches)
+	}
+	if !strings.Contains(patches, `"op":"remove"`) || strings.Contains(patches, "250m") {
+		t.Fatalf("expected the marker to be removed and no defaults to be applied, got %s", patches)
+	}
+}
+
+func TestWorkloadMutatorAppliesCurrentDefaultsToJobsOfCronJobs(t *testing.T) {
+	t.Parallel()
+
+	mutator, _ := newWorkloadMutator(t, nil)
+	var policy platformv1alpha1.WorkloadPolicy
+	if err := mutator.Client.Get(context.Background(), client.ObjectKey{Namespace: "team-a", Name: "defaults"}, &policy); err != nil {
+		t.Fatalf("failed to get WorkloadPolicy: %v", err)
+	}
+	policy.Spec.DefaultRequests = map[string]string{"cpu": "500m"}
+	if err := mutator.Client.Update(context.Background(), &policy); err != nil {
+		t.Fatalf("failed to update WorkloadPolicy: %v", err)
+	}
+	// A Job as the CronJob controller creates it from the jobTemplate.
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "report-29123456",
+			Namespace:   "team-a",
+			Annotations: map[string]string{mutateTemplateAnnotation: "true"},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "batch/v1", Kind: "CronJob", Name: "report", UID: "cronjob-uid", Controller: ptr.To(true),
+			}},
+		},
+		Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "job", Image: "busybox"}}},
+		}},
+	}
+	kind := metav1.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+
+	resp := mutator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create, kind, job, nil))
+	patches := marshalPatches(t, resp)
+	for _, want := range []string{"/spec/template/", "500m", templateDefaultsAppliedAnnotation} {
+		if !strings.Contains(patches, want) {
+			t.Fatalf("expected the Job to get the current defaults, %q missing from %s", want, patches)
+		}
+	}
+}
+
+func TestWorkloadMutatorRemovesMarkerWithoutOptIn(t *testing.T) {
+	t.Parallel()
+
+	mutator, _ := newWorkloadMutator(t, map[string]string{mutateTemplateAnnotation: "false"})
+	deployment := rootDeployment()
+	deployment.Spec.Template.Annotations = map[string]string{templateDefaultsAppliedAnnotation: "true"}
+
+	resp := mutator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create, deploymentKind, deployment, nil))
+	patches := marshalPatches(t, resp)
+	if !resp.Allowed || !strings.Contains(patches, `"op":"remove"`) || strings.Contains(patches, "250m") {
+		t.Fatalf("expected only the marker to be removed from an opted-out template, got %s", patches)
+	}
+}
+
+func TestPodMutatorSkipsPodsFromMutatedTemplates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	workloadMutator, _ := newWorkloadMutator(t, nil)
+	mutator := &PodMutator{
+		Client:   workloadMutator.Client,
+		Recorder: record.NewFakeRecorder(10),
+		decoder:  workloadMutator.decoder,
+	}
+	deployment := rootDeployment()
+	deployment.ObjectMeta = *optedInMeta()
+	if err := mutator.Client.Create(ctx, deployment); err != nil {
+		t.Fatalf("failed to create Deployment: %v", err)
+	}
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "web-5d8f", Namespace: "team-a"}}
+	if err := controllerutil.SetControllerReference(deployment, replicaSet, mutator.Client.Scheme()); err != nil {
+		t.Fatalf("failed to set owner: %v", err)
+	}
+	if err := mutator.Client.Create(ctx, replicaSet); err != nil {
+		t.Fatalf("failed to create ReplicaSet: %v", err)
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web-5d8f-abcde",
+			Namespace:   "team-a",
+			Annotations: map[string]string{templateDefaultsAppliedAnnotation: "true"},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx"}}},
+	}
+	if err := controllerutil.SetControllerReference(replicaSet, pod, mutator.Client.Scheme()); err != nil {
+		t.Fatalf("failed to set owner: %v", err)
+	}
+
+	if resp := mutator.Handle(ctx, newAdmissionRequest(t, "team-a", pod)); !resp.Allowed || len(resp.Patches) != 0 {
+		t.Fatalf("expected a Pod from a mutated template not to be mutated again, got %+v", resp)
+	}
+
+	deployment.Annotations[mutateTemplateAnnotation] = "false"
+	if err := mutator.Client.Update(ctx, deployment); err != nil {
+		t.Fatalf("failed to update Deployment: %v", err)
+	}
+	if resp := mutator.Handle(ctx, newAdmissionRequest(t, "team-a", pod)); len(resp.Patches) == 0 {
+		t.Fatal("expected the marker not to be trusted for a workload that is not opted in")
+	}
+
+	pod.OwnerReferences = nil
+	if resp := mutator.Handle(ctx, newAdmissionRequest(t, "team-a", pod)); len(resp.Patches) == 0 {
+		t.Fatal("expected a standalone Pod carrying the marker to still be mutated")
+	}
+}
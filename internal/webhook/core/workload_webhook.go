@@ -0,0 +1,177 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/f3nr1r/platform-governance-operator/internal/governance"
+	"github.com/f3nr1r/platform-governance-operator/internal/policyreport"
+)
+
+var workloadlog = logf.Log.WithName("workload-webhook")
+
+// WorkloadValidator validates the Pod templates of workload controllers
+// against SecurityBaselines, so a non-compliant workload is rejected when it is
+// applied instead of its controller silently failing to create Pods.
+type WorkloadValidator struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	// Reports receives a PolicyReport result for every baseline rule checked
+	// at admission. Reporting is disabled when nil.
+	Reports *policyreport.Store
+	decoder admission.Decoder
+}
+
+// InjectDecoder injects the decoder for admission requests.
+func (w *WorkloadValidator) InjectDecoder(d admission.Decoder) error {
+	w.decoder = d
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-workloads,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps,resources=deployments;statefulsets;daemonsets;replicasets,verbs=create;update,versions=v1,name=vworkload-apps.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-workloads,mutating=false,failurePolicy=fail,sideEffects=None,groups=batch,resources=jobs;cronjobs,verbs=create;update,versions=v1,name=vworkload-batch.kb.io,admissionReviewVersions=v1
+
+// Handle validates the Pod template of an incoming workload against all
+// ClusterSecurityBaselines and SecurityBaselines selecting it, exactly as the
+// Pod validating webhook would validate the Pods created from it. Updates
+// that leave the Pod template unchanged, such as scaling or the controllers'
+// own bookkeeping, are always admitted. Settings SecurityBaselines with
+// remediation Mutate would inject into the Pods are not reported as missing.
+func (w *WorkloadValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if w.decoder == nil {
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("admission decoder is not initialized"))
+	}
+
+	object, err := newWorkloadObject(req.Kind)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := w.decoder.Decode(req, object); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	template, templatePath := podTemplateOf(object)
+
+	if req.Operation == admissionv1.Update {
+		oldObject, _ := newWorkloadObject(req.Kind)
+		if err := w.decoder.DecodeRaw(req.OldObject, oldObject); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if oldTemplate, _ := podTemplateOf(oldObject); equality.Semantic.DeepEqual(template, oldTemplate) {
+			return admission.Allowed("Pod template unchanged")
+		}
+	}
+
+	name := cmp.Or(object.GetName(), object.GetGenerateName())
+	workloadlog.Info("Validating workload Pod template", "kind", req.Kind.Kind, "name", name, "namespace", req.Namespace)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   req.Namespace,
+			Labels:      template.Labels,
+			Annotations: maps.Clone(template.Annotations),
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	// Pods created from the template get the secure defaults of baselines
+	// with remediation Mutate injected, so only what remains is a violation.
+	if _, err := governance.RemediateBaselines(ctx, w.Client, req.Namespace, pod, nil); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	result := podValidationResult{
+		kind:      req.Kind.Kind,
+		name:      name,
+		namespace: req.Namespace,
+		reportSubject: corev1.ObjectReference{
+			APIVersion: metav1.GroupVersion{Group: req.Kind.Group, Version: req.Kind.Version}.String(),
+			Kind:       req.Kind.Kind,
+			Namespace:  req.Namespace,
+			Name:       name,
+			UID:        object.GetUID(),
+		},
+		templatePath: templatePath,
+	}
+	validator := &PodValidator{Client: w.Client, Recorder: w.Recorder, Reports: w.Reports}
+	if err := validator.evaluateBaselines(ctx, &result, pod); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return result.response()
+}
+
+// newWorkloadObject returns an empty object of a workload kind handled by the
+// WorkloadValidator.
+func newWorkloadObject(gvk metav1.GroupVersionKind) (client.Object, error) {
+	switch {
+	case gvk.Group == "apps" && gvk.Kind == "Deployment":
+		return &appsv1.Deployment{}, nil
+	case gvk.Group == "apps" && gvk.Kind == "StatefulSet":
+		return &appsv1.StatefulSet{}, nil
+	case gvk.Group == "apps" && gvk.Kind == "DaemonSet":
+		return &appsv1.DaemonSet{}, nil
+	case gvk.Group == "apps" && gvk.Kind == "ReplicaSet":
+		return &appsv1.ReplicaSet{}, nil
+	case gvk.Group == "batch" && gvk.Kind == "Job":
+		return &batchv1.Job{}, nil
+	case gvk.Group == "batch" && gvk.Kind == "CronJob":
+		return &batchv1.CronJob{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported workload kind %s", gvk.String())
+	}
+}
+
+// podTemplateOf returns the Pod template of a workload and its path within
+// the workload.
+func podTemplateOf(object client.Object) (*corev1.PodTemplateSpec, *field.Path) {
+	templatePath := field.NewPath("spec", "template")
+	switch o := object.(type) {
+	case *appsv1.Deployment:
+		return &o.Spec.Template, templatePath
+	case *appsv1.StatefulSet:
+		return &o.Spec.Template, templatePath
+	case *appsv1.DaemonSet:
+		return &o.Spec.Template, templatePath
+	case *appsv1.ReplicaSet:
+		return &o.Spec.Template, templatePath
+	case *batchv1.Job:
+		return &o.Spec.Template, templatePath
+	case *batchv1.CronJob:
+		return &o.Spec.JobTemplate.Spec.Template, field.NewPath("spec", "jobTemplate", "spec", "template")
+	default:
+		return &corev1.PodTemplateSpec{}, templatePath
+	}
+}
+
+// SetupWorkloadWebhookWithManager registers the workload validating webhook
+// with the Manager. Like the Pod webhooks it uses imperative registration,
+// because the workload types are not CRDs.
+func SetupWorkloadWebhookWithManager(mgr ctrl.Manager, reports *policyreport.Store) error {
+	handler := &WorkloadValidator{
+		Client: mgr.GetClient(),
+		//nolint:staticcheck // controller-runtime recorder migration pending
+		Recorder: mgr.GetEventRecorderFor("workload-validator-webhook"),
+		Reports:  reports,
+		decoder:  admission.NewDecoder(mgr.GetScheme()),
+	}
+
+	mgr.GetWebhookServer().Register("/validate-workloads", &webhook.Admission{
+		Handler: handler,
+	})
+	return nil
+}
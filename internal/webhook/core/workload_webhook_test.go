@@ -0,0 +1,200 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	platformv1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+func newWorkloadRequest(t *testing.T, operation admissionv1.Operation, kind metav1.GroupVersionKind, object, oldObject runtime.Object) admission.Request {
+	t.Helper()
+
+	raw, err := json.Marshal(object)
+	if err != nil {
+		t.Fatalf("failed to marshal object: %v", err)
+	}
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Kind:      kind,
+			Namespace: "team-a",
+			Operation: operation,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+	if oldObject != nil {
+		oldRaw, err := json.Marshal(oldObject)
+		if err != nil {
+			t.Fatalf("failed to marshal old object: %v", err)
+		}
+		req.OldObject = runtime.RawExtension{Raw: oldRaw}
+	}
+	return req
+}
+
+func newWorkloadValidator(t *testing.T, baselines ...*platformv1alpha1.SecurityBaseline) (*WorkloadValidator, *record.FakeRecorder) {
+	t.Helper()
+
+	scheme := newWebhookTestScheme(t)
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, baseline := range baselines {
+		builder = builder.WithObjects(baseline)
+	}
+	recorder := record.NewFakeRecorder(10)
+	return &WorkloadValidator{
+		Client:   builder.Build(),
+		Recorder: recorder,
+		decoder:  admission.NewDecoder(scheme),
+	}, recorder
+}
+
+var deploymentKind = metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+func rootDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx"}}},
+			},
+		},
+	}
+}
+
+func TestWorkloadValidatorDeniesNonCompliantDeployment(t *testing.T) {
+	t.Parallel()
+
+	validator, recorder := newWorkloadValidator(t, &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted", Namespace: "team-a"},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{RunAsNonRoot: ptr.To(true), ReadOnlyRootFilesystem: ptr.To(true)},
+	})
+
+	resp := validator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create, deploymentKind, rootDeployment(), nil))
+	if resp.Allowed {
+		t.Fatalf("expected the non-compliant Deployment to be denied")
+	}
+	if !strings.HasPrefix(resp.Result.Message, "Deployment violates governance policies: 2 violation(s) found:") {
+		t.Fatalf("unexpected denial message: %q", resp.Result.Message)
+	}
+	if !strings.Contains(resp.Result.Message, "spec.template.spec.containers[0].securityContext.readOnlyRootFilesystem") {
+		t.Fatalf("expected the violation path to point into the Pod template, got %q", resp.Result.Message)
+	}
+	if causes := resp.Result.Details.Causes; len(causes) != 2 || !strings.HasPrefix(causes[0].Field, "spec.template.spec.") {
+		t.Fatalf("expected causes with Pod template paths, got %+v", causes)
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "WorkloadDenied Denied Deployment web in namespace team-a") {
+		t.Fatalf("unexpected event: %q", event)
+	}
+}
+
+func TestWorkloadValidatorAllowsCompliantDeployment(t *testing.T) {
+	t.Parallel()
+
+	validator, _ := newWorkloadValidator(t, &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted", Namespace: "team-a"},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{RunAsNonRoot: ptr.To(true)},
+	})
+	deployment := rootDeployment()
+	deployment.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{RunAsNonRoot: ptr.To(true)}
+
+	if resp := validator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create, deploymentKind, deployment, nil)); !resp.Allowed {
+		t.Fatalf("expected the compliant Deployment to be allowed: %s", resp.Result.Message)
+	}
+}
+
+func TestWorkloadValidatorAllowsUpdatesKeepingTheTemplate(t *testing.T) {
+	t.Parallel()
+
+	validator, _ := newWorkloadValidator(t, &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted", Namespace: "team-a"},
+		Spec:       platformv1alpha1.SecurityBaselineSpec{RunAsNonRoot: ptr.To(true)},
+	})
+	oldDeployment := rootDeployment()
+	scaled := rootDeployment()
+	scaled.Spec.Replicas = ptr.To[int32](5)
+
+	if resp := validator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Update, deploymentKind, scaled, oldDeployment)); !resp.Allowed {
+		t.Fatalf("expected scaling an existing Deployment to be allowed: %s", resp.Result.Message)
+	}
+
+	changed := rootDeployment()
+	changed.Spec.Template.Spec.Containers[0].Image = "nginx:1.27"
+	if resp := validator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Update, deploymentKind, changed, oldDeployment)); resp.Allowed {
+		t.Fatalf("expected a non-compliant Pod template change to be denied")
+	}
+}
+
+func TestWorkloadValidatorWarnsForCronJobTemplates(t *testing.T) {
+	t.Parallel()
+
+	validator, _ := newWorkloadValidator(t, &platformv1alpha1.SecurityBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted", Namespace: "team-a"},
+		Spec: platformv1alpha1.SecurityBaselineSpec{
+			EnforcementAction: platformv1alpha1.EnforcementActionWarn,
+			RunAsNonRoot:      ptr.To(true),
+			PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "report"}},
+		},
+	})
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "team-a"},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "@daily",
+			JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "report"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "report", Image: "busybox"}}},
+			}}},
+		},
+	}
+
+	resp := validator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create,
+		metav1.GroupVersionKind{Group: "batch", Version: "v1", Kind: "CronJob"}, cronJob, nil))
+	if !resp.Allowed {
+		t.Fatalf("expected the CronJob to be admitted with warnings: %s", resp.Result.Message)
+	}
+	if len(resp.Warnings) != 1 || !strings.Contains(resp.Warnings[0], "spec.jobTemplate.spec.template.spec.securityContext.runAsNonRoot") {
+		t.Fatalf("expected a warning pointing into the job template, got %v", resp.Warnings)
+	}
+}
+
+func TestWorkloadValidatorRejectsUnsupportedKinds(t *testing.T) {
+	t.Parallel()
+
+	validator, _ := newWorkloadValidator(t)
+	resp := validator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create,
+		metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, &corev1.ConfigMap{}, nil))
+	if resp.Allowed || resp.Result.Code != 400 {
+		t.Fatalf("expected unsupported kinds to be rejected as bad requests, got %+v", resp.Result)
+	}
+}
+
+func TestWorkloadValidatorAccountsForRemediation(t *testing.T) {
+	t.Parallel()
+
+	baseline := remediatingBaseline()
+	baseline.Spec.Profile = ""
+	validator, _ := newWorkloadValidator(t, baseline)
+
+	if resp := validator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create, deploymentKind, rootDeployment(), nil)); !resp.Allowed {
+		t.Fatalf("expected the settings remediation injects not to be reported as missing: %s", resp.Result.Message)
+	}
+
+	deployment := rootDeployment()
+	deployment.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{RunAsNonRoot: ptr.To(false)}
+	if resp := validator.Handle(context.Background(), newWorkloadRequest(t, admissionv1.Create, deploymentKind, deployment, nil)); resp.Allowed {
+		t.Fatal("expected an explicit runAsNonRoot: false to be denied")
+	}
+}
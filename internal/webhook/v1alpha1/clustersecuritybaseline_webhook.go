@@ -0,0 +1,87 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+var clustersecuritybaselinelog = logf.Log.WithName("clustersecuritybaseline-resource")
+
+// SetupClusterSecurityBaselineWebhookWithManager registers the webhook for ClusterSecurityBaseline in the manager.
+func SetupClusterSecurityBaselineWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &corev1alpha1.ClusterSecurityBaseline{}).
+		WithValidator(&ClusterSecurityBaselineCustomValidator{}).
+		WithDefaulter(&ClusterSecurityBaselineCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-core-platform-f3nr1r-io-v1alpha1-clustersecuritybaseline,mutating=true,failurePolicy=fail,sideEffects=None,groups=core.platform.f3nr1r.io,resources=clustersecuritybaselines,verbs=create;update,versions=v1alpha1,name=mclustersecuritybaseline-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterSecurityBaselineCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind ClusterSecurityBaseline when those are created or updated.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as it is used only for temporary operations and does not need to be deeply copied.
+type ClusterSecurityBaselineCustomDefaulter struct{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind ClusterSecurityBaseline.
+func (d *ClusterSecurityBaselineCustomDefaulter) Default(_ context.Context, obj *corev1alpha1.ClusterSecurityBaseline) error {
+	clustersecuritybaselinelog.Info("Defaulting for ClusterSecurityBaseline", "name", obj.GetName())
+	defaultSecurityBaselineSpec(&obj.Spec.SecurityBaselineSpec)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-core-platform-f3nr1r-io-v1alpha1-clustersecuritybaseline,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.platform.f3nr1r.io,resources=clustersecuritybaselines,verbs=create;update,versions=v1alpha1,name=vclustersecuritybaseline-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterSecurityBaselineCustomValidator struct is responsible for validating the ClusterSecurityBaseline resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type ClusterSecurityBaselineCustomValidator struct{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ClusterSecurityBaseline.
+func (v *ClusterSecurityBaselineCustomValidator) ValidateCreate(_ context.Context, obj *corev1alpha1.ClusterSecurityBaseline) (admission.Warnings, error) {
+	clustersecuritybaselinelog.Info("Validation for ClusterSecurityBaseline upon creation", "name", obj.GetName())
+	return nil, validateClusterSecurityBaseline(obj)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ClusterSecurityBaseline.
+func (v *ClusterSecurityBaselineCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj *corev1alpha1.ClusterSecurityBaseline) (admission.Warnings, error) {
+	clustersecuritybaselinelog.Info("Validation for ClusterSecurityBaseline upon update", "name", newObj.GetName())
+	return nil, validateClusterSecurityBaseline(newObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ClusterSecurityBaseline.
+func (v *ClusterSecurityBaselineCustomValidator) ValidateDelete(_ context.Context, obj *corev1alpha1.ClusterSecurityBaseline) (admission.Warnings, error) {
+	clustersecuritybaselinelog.Info("Validation for ClusterSecurityBaseline upon deletion", "name", obj.GetName())
+	return nil, nil
+}
+
+func validateClusterSecurityBaseline(obj *corev1alpha1.ClusterSecurityBaseline) error {
+	if err := validateNamespaceSelector(obj.Spec.NamespaceSelector); err != nil {
+		return err
+	}
+	return validateSecurityBaselineSpec(&obj.Spec.SecurityBaselineSpec)
+}
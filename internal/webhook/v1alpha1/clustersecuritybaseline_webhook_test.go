@@ -0,0 +1,87 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+var _ = Describe("ClusterSecurityBaseline Webhook", func() {
+	var (
+		obj       *corev1alpha1.ClusterSecurityBaseline
+		oldObj    *corev1alpha1.ClusterSecurityBaseline
+		validator ClusterSecurityBaselineCustomValidator
+		defaulter ClusterSecurityBaselineCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		obj = &corev1alpha1.ClusterSecurityBaseline{}
+		oldObj = &corev1alpha1.ClusterSecurityBaseline{}
+		validator = ClusterSecurityBaselineCustomValidator{}
+		Expect(validator).NotTo(BeNil())
+		defaulter = ClusterSecurityBaselineCustomDefaulter{}
+		Expect(defaulter).NotTo(BeNil())
+		Expect(oldObj).NotTo(BeNil())
+		Expect(obj).NotTo(BeNil())
+	})
+
+	Context("When creating ClusterSecurityBaseline under Defaulting Webhook", func() {
+		It("Should default enforcementAction to Enforce", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.EnforcementAction).To(Equal(corev1alpha1.EnforcementActionEnforce))
+		})
+	})
+
+	Context("When creating or updating ClusterSecurityBaseline under Validating Webhook", func() {
+		It("Should admit a ClusterSecurityBaseline selecting namespaces by label", func() {
+			obj.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"env": "production"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny an invalid namespaceSelector", func() {
+			obj.Spec.NamespaceSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "env", Operator: metav1.LabelSelectorOpIn},
+			}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("namespaceSelector"))
+		})
+
+		It("Should validate the embedded SecurityBaseline rules", func() {
+			obj.Spec.ExcludedNamespaces = []string{""}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit excludedNamespaces without warnings", func() {
+			obj.Spec.ExcludedNamespaces = []string{"kube-system"}
+			warnings, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should admit deletion", func() {
+			_, err := validator.ValidateDelete(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
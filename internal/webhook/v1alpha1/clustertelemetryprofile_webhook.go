@@ -0,0 +1,86 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+var clustertelemetryprofilelog = logf.Log.WithName("clustertelemetryprofile-resource")
+
+// SetupClusterTelemetryProfileWebhookWithManager registers the webhook for ClusterTelemetryProfile in the manager.
+func SetupClusterTelemetryProfileWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &corev1alpha1.ClusterTelemetryProfile{}).
+		WithValidator(&ClusterTelemetryProfileCustomValidator{}).
+		WithDefaulter(&ClusterTelemetryProfileCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-core-platform-f3nr1r-io-v1alpha1-clustertelemetryprofile,mutating=true,failurePolicy=fail,sideEffects=None,groups=core.platform.f3nr1r.io,resources=clustertelemetryprofiles,verbs=create;update,versions=v1alpha1,name=mclustertelemetryprofile-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterTelemetryProfileCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind ClusterTelemetryProfile when those are created or updated.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as it is used only for temporary operations and does not need to be deeply copied.
+type ClusterTelemetryProfileCustomDefaulter struct{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind ClusterTelemetryProfile.
+func (d *ClusterTelemetryProfileCustomDefaulter) Default(_ context.Context, obj *corev1alpha1.ClusterTelemetryProfile) error {
+	clustertelemetryprofilelog.Info("Defaulting for ClusterTelemetryProfile", "name", obj.GetName())
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-core-platform-f3nr1r-io-v1alpha1-clustertelemetryprofile,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.platform.f3nr1r.io,resources=clustertelemetryprofiles,verbs=create;update,versions=v1alpha1,name=vclustertelemetryprofile-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterTelemetryProfileCustomValidator struct is responsible for validating the ClusterTelemetryProfile resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type ClusterTelemetryProfileCustomValidator struct{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ClusterTelemetryProfile.
+func (v *ClusterTelemetryProfileCustomValidator) ValidateCreate(_ context.Context, obj *corev1alpha1.ClusterTelemetryProfile) (admission.Warnings, error) {
+	clustertelemetryprofilelog.Info("Validation for ClusterTelemetryProfile upon creation", "name", obj.GetName())
+	return nil, validateClusterTelemetryProfile(obj)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ClusterTelemetryProfile.
+func (v *ClusterTelemetryProfileCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj *corev1alpha1.ClusterTelemetryProfile) (admission.Warnings, error) {
+	clustertelemetryprofilelog.Info("Validation for ClusterTelemetryProfile upon update", "name", newObj.GetName())
+	return nil, validateClusterTelemetryProfile(newObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ClusterTelemetryProfile.
+func (v *ClusterTelemetryProfileCustomValidator) ValidateDelete(_ context.Context, obj *corev1alpha1.ClusterTelemetryProfile) (admission.Warnings, error) {
+	clustertelemetryprofilelog.Info("Validation for ClusterTelemetryProfile upon deletion", "name", obj.GetName())
+	return nil, nil
+}
+
+func validateClusterTelemetryProfile(obj *corev1alpha1.ClusterTelemetryProfile) error {
+	if err := validateNamespaceSelector(obj.Spec.NamespaceSelector); err != nil {
+		return err
+	}
+	return validateTelemetryProfileSpec(&obj.Spec.TelemetryProfileSpec)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+var clusterworkloadpolicylog = logf.Log.WithName("clusterworkloadpolicy-resource")
+
+// SetupClusterWorkloadPolicyWebhookWithManager registers the webhook for ClusterWorkloadPolicy in the manager.
+func SetupClusterWorkloadPolicyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &corev1alpha1.ClusterWorkloadPolicy{}).
+		WithValidator(&ClusterWorkloadPolicyCustomValidator{}).
+		WithDefaulter(&ClusterWorkloadPolicyCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-core-platform-f3nr1r-io-v1alpha1-clusterworkloadpolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=core.platform.f3nr1r.io,resources=clusterworkloadpolicies,verbs=create;update,versions=v1alpha1,name=mclusterworkloadpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterWorkloadPolicyCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind ClusterWorkloadPolicy when those are created or updated.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as it is used only for temporary operations and does not need to be deeply copied.
+type ClusterWorkloadPolicyCustomDefaulter struct{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind ClusterWorkloadPolicy.
+func (d *ClusterWorkloadPolicyCustomDefaulter) Default(_ context.Context, obj *corev1alpha1.ClusterWorkloadPolicy) error {
+	clusterworkloadpolicylog.Info("Defaulting for ClusterWorkloadPolicy", "name", obj.GetName())
+	defaultWorkloadPolicySpec(&obj.Spec.WorkloadPolicySpec)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-core-platform-f3nr1r-io-v1alpha1-clusterworkloadpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.platform.f3nr1r.io,resources=clusterworkloadpolicies,verbs=create;update,versions=v1alpha1,name=vclusterworkloadpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterWorkloadPolicyCustomValidator struct is responsible for validating the ClusterWorkloadPolicy resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type ClusterWorkloadPolicyCustomValidator struct{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ClusterWorkloadPolicy.
+func (v *ClusterWorkloadPolicyCustomValidator) ValidateCreate(_ context.Context, obj *corev1alpha1.ClusterWorkloadPolicy) (admission.Warnings, error) {
+	clusterworkloadpolicylog.Info("Validation for ClusterWorkloadPolicy upon creation", "name", obj.GetName())
+	return nil, validateClusterWorkloadPolicy(obj)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ClusterWorkloadPolicy.
+func (v *ClusterWorkloadPolicyCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj *corev1alpha1.ClusterWorkloadPolicy) (admission.Warnings, error) {
+	clusterworkloadpolicylog.Info("Validation for ClusterWorkloadPolicy upon update", "name", newObj.GetName())
+	return nil, validateClusterWorkloadPolicy(newObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ClusterWorkloadPolicy.
+func (v *ClusterWorkloadPolicyCustomValidator) ValidateDelete(_ context.Context, obj *corev1alpha1.ClusterWorkloadPolicy) (admission.Warnings, error) {
+	clusterworkloadpolicylog.Info("Validation for ClusterWorkloadPolicy upon deletion", "name", obj.GetName())
+	return nil, nil
+}
+
+func validateClusterWorkloadPolicy(obj *corev1alpha1.ClusterWorkloadPolicy) error {
+	if err := validateNamespaceSelector(obj.Spec.NamespaceSelector); err != nil {
+		return err
+	}
+	if obj.Spec.HorizontalScaling != nil {
+		return fmt.Errorf("horizontalScaling is only supported on namespaced WorkloadPolicies")
+	}
+	if obj.Spec.GenerateLimitRange {
+		return fmt.Errorf("generateLimitRange is only supported on namespaced WorkloadPolicies")
+	}
+	if obj.Spec.Quota != nil {
+		return fmt.Errorf("quota is only supported on namespaced WorkloadPolicies")
+	}
+	return validateWorkloadPolicySpec(&obj.Spec.WorkloadPolicySpec)
+}
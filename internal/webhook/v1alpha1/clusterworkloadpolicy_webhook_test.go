@@ -0,0 +1,96 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+var _ = Describe("ClusterWorkloadPolicy Webhook", func() {
+	var (
+		obj       *corev1alpha1.ClusterWorkloadPolicy
+		oldObj    *corev1alpha1.ClusterWorkloadPolicy
+		validator ClusterWorkloadPolicyCustomValidator
+		defaulter ClusterWorkloadPolicyCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		obj = &corev1alpha1.ClusterWorkloadPolicy{}
+		oldObj = &corev1alpha1.ClusterWorkloadPolicy{}
+		validator = ClusterWorkloadPolicyCustomValidator{}
+		Expect(validator).NotTo(BeNil())
+		defaulter = ClusterWorkloadPolicyCustomDefaulter{}
+		Expect(defaulter).NotTo(BeNil())
+		Expect(oldObj).NotTo(BeNil())
+		Expect(obj).NotTo(BeNil())
+	})
+
+	Context("When creating ClusterWorkloadPolicy under Defaulting Webhook", func() {
+		It("Should apply defaults without error", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+		})
+	})
+
+	Context("When creating or updating ClusterWorkloadPolicy under Validating Webhook", func() {
+		It("Should admit a ClusterWorkloadPolicy selecting namespaces by label", func() {
+			obj.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"env": "production"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny an invalid namespaceSelector", func() {
+			obj.Spec.NamespaceSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "env", Operator: metav1.LabelSelectorOpIn},
+			}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("namespaceSelector"))
+		})
+
+		It("Should validate the embedded WorkloadPolicy defaults", func() {
+			obj.Spec.DefaultRequests = map[string]string{"cpu": "not-a-quantity"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny horizontalScaling", func() {
+			obj.Spec.HorizontalScaling = &corev1alpha1.HorizontalScalingPolicy{MinReplicas: 1, MaxReplicas: 3, TargetCPUUtilizationPercentage: 80}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny a generated LimitRange or ResourceQuota", func() {
+			obj.Spec.GenerateLimitRange = true
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("generateLimitRange is only supported on namespaced WorkloadPolicies")))
+
+			obj.Spec.GenerateLimitRange = false
+			obj.Spec.Quota = &corev1alpha1.QuotaPolicy{Hard: map[string]string{"pods": "10"}}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("quota is only supported on namespaced WorkloadPolicies")))
+		})
+
+		It("Should admit deletion", func() {
+			_, err := validator.ValidateDelete(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
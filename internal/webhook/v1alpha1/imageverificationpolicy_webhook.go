@@ -0,0 +1,121 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+var imageverificationpolicylog = logf.Log.WithName("imageverificationpolicy-resource")
+
+// SetupImageVerificationPolicyWebhookWithManager registers the webhook for ImageVerificationPolicy in the manager.
+func SetupImageVerificationPolicyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &corev1alpha1.ImageVerificationPolicy{}).
+		WithValidator(&ImageVerificationPolicyCustomValidator{}).
+		WithDefaulter(&ImageVerificationPolicyCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-core-platform-f3nr1r-io-v1alpha1-imageverificationpolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=core.platform.f3nr1r.io,resources=imageverificationpolicies,verbs=create;update,versions=v1alpha1,name=mimageverificationpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ImageVerificationPolicyCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind ImageVerificationPolicy when those are created or updated.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as it is used only for temporary operations and does not need to be deeply copied.
+type ImageVerificationPolicyCustomDefaulter struct{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind ImageVerificationPolicy.
+func (d *ImageVerificationPolicyCustomDefaulter) Default(_ context.Context, obj *corev1alpha1.ImageVerificationPolicy) error {
+	imageverificationpolicylog.Info("Defaulting for ImageVerificationPolicy", "name", obj.GetName())
+	if obj.Spec.EnforcementAction == "" {
+		obj.Spec.EnforcementAction = corev1alpha1.EnforcementActionEnforce
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-core-platform-f3nr1r-io-v1alpha1-imageverificationpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.platform.f3nr1r.io,resources=imageverificationpolicies,verbs=create;update,versions=v1alpha1,name=vimageverificationpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ImageVerificationPolicyCustomValidator struct is responsible for validating the ImageVerificationPolicy resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type ImageVerificationPolicyCustomValidator struct{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ImageVerificationPolicy.
+func (v *ImageVerificationPolicyCustomValidator) ValidateCreate(_ context.Context, obj *corev1alpha1.ImageVerificationPolicy) (admission.Warnings, error) {
+	imageverificationpolicylog.Info("Validation for ImageVerificationPolicy upon creation", "name", obj.GetName())
+	return nil, validateImageVerificationPolicySpec(obj)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ImageVerificationPolicy.
+func (v *ImageVerificationPolicyCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj *corev1alpha1.ImageVerificationPolicy) (admission.Warnings, error) {
+	imageverificationpolicylog.Info("Validation for ImageVerificationPolicy upon update", "name", newObj.GetName())
+	return nil, validateImageVerificationPolicySpec(newObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ImageVerificationPolicy.
+func (v *ImageVerificationPolicyCustomValidator) ValidateDelete(_ context.Context, obj *corev1alpha1.ImageVerificationPolicy) (admission.Warnings, error) {
+	imageverificationpolicylog.Info("Validation for ImageVerificationPolicy upon deletion", "name", obj.GetName())
+	return nil, nil
+}
+
+func validateImageVerificationPolicySpec(obj *corev1alpha1.ImageVerificationPolicy) error {
+	switch obj.Spec.EnforcementAction {
+	case "", corev1alpha1.EnforcementActionEnforce, corev1alpha1.EnforcementActionWarn, corev1alpha1.EnforcementActionAudit:
+	default:
+		return fmt.Errorf("enforcementAction must be one of Enforce, Warn or Audit, got %q", obj.Spec.EnforcementAction)
+	}
+
+	if len(obj.Spec.Images) == 0 {
+		return fmt.Errorf("images must list at least one registry or repository prefix")
+	}
+	if err := validateImagePrefixes("images", obj.Spec.Images); err != nil {
+		return err
+	}
+
+	if len(obj.Spec.PublicKeys) == 0 {
+		return fmt.Errorf("publicKeys must reference at least one public key")
+	}
+	for i, key := range obj.Spec.PublicKeys {
+		if (key.SecretKeyRef == nil) == (key.ConfigMapKeyRef == nil) {
+			return fmt.Errorf("publicKeys[%d] must set exactly one of secretKeyRef or configMapKeyRef", i)
+		}
+		if key.SecretKeyRef != nil && (key.SecretKeyRef.Name == "" || key.SecretKeyRef.Key == "") {
+			return fmt.Errorf("publicKeys[%d].secretKeyRef requires name and key", i)
+		}
+		if key.ConfigMapKeyRef != nil && (key.ConfigMapKeyRef.Name == "" || key.ConfigMapKeyRef.Key == "") {
+			return fmt.Errorf("publicKeys[%d].configMapKeyRef requires name and key", i)
+		}
+	}
+
+	for _, registry := range obj.Spec.InsecureRegistries {
+		if strings.TrimSpace(registry) == "" || strings.Contains(registry, "/") {
+			return fmt.Errorf("insecureRegistries entry %q must be a registry host, e.g. localhost:5001", registry)
+		}
+	}
+	return nil
+}
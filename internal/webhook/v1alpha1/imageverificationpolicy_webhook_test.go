@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+var _ = Describe("ImageVerificationPolicy Webhook", func() {
+	var (
+		obj       *corev1alpha1.ImageVerificationPolicy
+		oldObj    *corev1alpha1.ImageVerificationPolicy
+		validator ImageVerificationPolicyCustomValidator
+		defaulter ImageVerificationPolicyCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		obj = &corev1alpha1.ImageVerificationPolicy{
+			Spec: corev1alpha1.ImageVerificationPolicySpec{
+				Images: []string{"ghcr.io/my-org"},
+				PublicKeys: []corev1alpha1.PublicKeySource{{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "cosign"},
+						Key:                  "cosign.pub",
+					},
+				}},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = ImageVerificationPolicyCustomValidator{}
+		Expect(validator).NotTo(BeNil())
+		defaulter = ImageVerificationPolicyCustomDefaulter{}
+		Expect(defaulter).NotTo(BeNil())
+		Expect(oldObj).NotTo(BeNil())
+		Expect(obj).NotTo(BeNil())
+	})
+
+	Context("When creating ImageVerificationPolicy under Defaulting Webhook", func() {
+		It("Should default enforcementAction to Enforce", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.EnforcementAction).To(Equal(corev1alpha1.EnforcementActionEnforce))
+		})
+	})
+
+	Context("When creating or updating ImageVerificationPolicy under Validating Webhook", func() {
+		It("Should admit a valid ImageVerificationPolicy", func() {
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a policy without images", func() {
+			obj.Spec.Images = nil
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny a key source setting both secretKeyRef and configMapKeyRef", func() {
+			obj.Spec.PublicKeys[0].ConfigMapKeyRef = &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "cosign"},
+				Key:                  "cosign.pub",
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny a key source without a key", func() {
+			obj.Spec.PublicKeys[0].SecretKeyRef.Key = ""
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit a local insecure registry host", func() {
+			obj.Spec.InsecureRegistries = []string{"localhost:5001"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny an insecure registry entry with a repository path", func() {
+			obj.Spec.InsecureRegistries = []string{"localhost:5001/team"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit a valid update", func() {
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
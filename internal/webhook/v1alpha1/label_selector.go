@@ -0,0 +1,48 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// validatePodSelector checks that a policy's podSelector is a valid label selector.
+func validatePodSelector(selector *metav1.LabelSelector) error {
+	return validateLabelSelector(selector, "podSelector")
+}
+
+// validateNamespaceSelector checks that a cluster policy's namespaceSelector is
+// a valid label selector.
+func validateNamespaceSelector(selector *metav1.LabelSelector) error {
+	return validateLabelSelector(selector, "namespaceSelector")
+}
+
+func validateLabelSelector(selector *metav1.LabelSelector, fieldName string) error {
+	if selector == nil {
+		return nil
+	}
+	errs := metav1validation.ValidateLabelSelector(selector, metav1validation.LabelSelectorValidationOptions{},
+		field.NewPath("spec", fieldName))
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid %s: %w", fieldName, errs.ToAggregate())
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+var policyexceptionlog = logf.Log.WithName("policyexception-resource")
+
+// SetupPolicyExceptionWebhookWithManager registers the webhook for PolicyException in the manager.
+func SetupPolicyExceptionWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &corev1alpha1.PolicyException{}).
+		WithValidator(&PolicyExceptionCustomValidator{}).
+		WithDefaulter(&PolicyExceptionCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-core-platform-f3nr1r-io-v1alpha1-policyexception,mutating=true,failurePolicy=fail,sideEffects=None,groups=core.platform.f3nr1r.io,resources=policyexceptions,verbs=create;update,versions=v1alpha1,name=mpolicyexception-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// PolicyExceptionCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind PolicyException when those are created or updated.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as it is used only for temporary operations and does not need to be deeply copied.
+type PolicyExceptionCustomDefaulter struct{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind PolicyException.
+func (d *PolicyExceptionCustomDefaulter) Default(_ context.Context, obj *corev1alpha1.PolicyException) error {
+	policyexceptionlog.Info("Defaulting for PolicyException", "name", obj.GetName())
+	if obj.Spec.Baseline.Kind == "" {
+		obj.Spec.Baseline.Kind = "SecurityBaseline"
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-core-platform-f3nr1r-io-v1alpha1-policyexception,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.platform.f3nr1r.io,resources=policyexceptions,verbs=create;update,versions=v1alpha1,name=vpolicyexception-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// PolicyExceptionCustomValidator struct is responsible for validating the PolicyException resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type PolicyExceptionCustomValidator struct{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type PolicyException.
+func (v *PolicyExceptionCustomValidator) ValidateCreate(_ context.Context, obj *corev1alpha1.PolicyException) (admission.Warnings, error) {
+	policyexceptionlog.Info("Validation for PolicyException upon creation", "name", obj.GetName())
+	if err := validatePolicyExceptionSpec(&obj.Spec); err != nil {
+		return nil, err
+	}
+	if !obj.Spec.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("expiresAt must be in the future")
+	}
+	return policyExceptionWarnings(obj), nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type PolicyException.
+func (v *PolicyExceptionCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj *corev1alpha1.PolicyException) (admission.Warnings, error) {
+	policyexceptionlog.Info("Validation for PolicyException upon update", "name", newObj.GetName())
+	if err := validatePolicyExceptionSpec(&newObj.Spec); err != nil {
+		return nil, err
+	}
+	// Expired exceptions may still be updated (e.g. to edit labels), but an
+	// extension must move expiresAt into the future.
+	if !newObj.Spec.ExpiresAt.Equal(&oldObj.Spec.ExpiresAt) && !newObj.Spec.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("expiresAt must be in the future")
+	}
+	return policyExceptionWarnings(newObj), nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type PolicyException.
+func (v *PolicyExceptionCustomValidator) ValidateDelete(_ context.Context, obj *corev1alpha1.PolicyException) (admission.Warnings, error) {
+	policyexceptionlog.Info("Validation for PolicyException upon deletion", "name", obj.GetName())
+	return nil, nil
+}
+
+func validatePolicyExceptionSpec(spec *corev1alpha1.PolicyExceptionSpec) error {
+	switch spec.Baseline.Kind {
+	case "", "SecurityBaseline":
+	case "ClusterSecurityBaseline":
+		return fmt.Errorf("baseline.kind ClusterSecurityBaseline cannot be exempted by a namespaced PolicyException; " +
+			"exclude the namespace in the ClusterSecurityBaseline instead")
+	default:
+		return fmt.Errorf("baseline.kind must be SecurityBaseline, got %q", spec.Baseline.Kind)
+	}
+	if strings.TrimSpace(spec.Baseline.Name) == "" {
+		return fmt.Errorf("baseline.name cannot be empty")
+	}
+
+	if len(spec.Rules) == 0 {
+		return fmt.Errorf("rules must list at least one rule")
+	}
+	for _, rule := range spec.Rules {
+		if !slices.Contains(corev1alpha1.SecurityBaselineRules, rule) {
+			return fmt.Errorf("rules entry %q is not a SecurityBaseline rule, must be one of %v", rule, corev1alpha1.SecurityBaselineRules)
+		}
+	}
+
+	if err := validatePodSelector(spec.PodSelector); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(spec.Justification) == "" {
+		return fmt.Errorf("justification cannot be empty")
+	}
+	if strings.TrimSpace(spec.Owner) == "" {
+		return fmt.Errorf("owner cannot be empty")
+	}
+	if spec.ExpiresAt.IsZero() {
+		return fmt.Errorf("expiresAt is required")
+	}
+	if spec.Approval != nil && strings.TrimSpace(spec.Approval.ApprovedBy) == "" {
+		return fmt.Errorf("approval.approvedBy cannot be empty")
+	}
+	return nil
+}
+
+func policyExceptionWarnings(obj *corev1alpha1.PolicyException) admission.Warnings {
+	var warnings admission.Warnings
+	if obj.Spec.PodSelector == nil {
+		warnings = append(warnings, "spec.podSelector is unset, so the exception applies to every Pod in the namespace")
+	}
+	if obj.Spec.Approval == nil {
+		warnings = append(warnings, "spec.approval is unset; record who approved the exception for auditability")
+	}
+	return warnings
+}
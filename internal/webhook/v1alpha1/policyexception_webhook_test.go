@@ -0,0 +1,153 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+)
+
+var _ = Describe("PolicyException Webhook", func() {
+	var (
+		obj       *corev1alpha1.PolicyException
+		oldObj    *corev1alpha1.PolicyException
+		validator PolicyExceptionCustomValidator
+		defaulter PolicyExceptionCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		obj = &corev1alpha1.PolicyException{
+			Spec: corev1alpha1.PolicyExceptionSpec{
+				Baseline:      corev1alpha1.BaselineReference{Name: "restricted"},
+				Rules:         []corev1alpha1.SecurityBaselineRule{corev1alpha1.RuleReadOnlyRootFilesystem},
+				PodSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"app": "legacy"}},
+				Justification: "legacy image writes to its root filesystem",
+				Owner:         "team-a",
+				ExpiresAt:     metav1.NewTime(time.Now().Add(24 * time.Hour)),
+				Approval:      &corev1alpha1.PolicyExceptionApproval{ApprovedBy: "security-team"},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = PolicyExceptionCustomValidator{}
+		Expect(validator).NotTo(BeNil())
+		defaulter = PolicyExceptionCustomDefaulter{}
+		Expect(defaulter).NotTo(BeNil())
+		Expect(oldObj).NotTo(BeNil())
+		Expect(obj).NotTo(BeNil())
+	})
+
+	Context("When creating PolicyException under Defaulting Webhook", func() {
+		It("Should default baseline.kind to SecurityBaseline", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Baseline.Kind).To(Equal("SecurityBaseline"))
+		})
+	})
+
+	Context("When creating or updating PolicyException under Validating Webhook", func() {
+		It("Should admit a valid PolicyException without warnings", func() {
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should warn when the exception has no podSelector or approval", func() {
+			obj.Spec.PodSelector = nil
+			obj.Spec.Approval = nil
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(2))
+		})
+
+		It("Should deny an unknown rule", func() {
+			obj.Spec.Rules = []corev1alpha1.SecurityBaselineRule{"noRoot"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit exceptions for the UID and GID range rules", func() {
+			for _, rule := range []corev1alpha1.SecurityBaselineRule{
+				corev1alpha1.RuleRunAsUser,
+				corev1alpha1.RuleRunAsGroup,
+				corev1alpha1.RuleFSGroup,
+				corev1alpha1.RuleSupplementalGroups,
+			} {
+				obj.Spec.Rules = []corev1alpha1.SecurityBaselineRule{rule}
+				_, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).NotTo(HaveOccurred(), "rule %s", rule)
+			}
+		})
+
+		It("Should admit an exception for the serviceAccounts rule", func() {
+			obj.Spec.Rules = []corev1alpha1.SecurityBaselineRule{corev1alpha1.RuleServiceAccounts}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny an empty justification or owner", func() {
+			obj.Spec.Justification = " "
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+
+			obj.Spec.Justification = "legacy image"
+			obj.Spec.Owner = ""
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny an exception for a ClusterSecurityBaseline", func() {
+			obj.Spec.Baseline.Kind = "ClusterSecurityBaseline"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("cannot be exempted by a namespaced PolicyException")))
+		})
+
+		It("Should deny an unknown baseline kind", func() {
+			obj.Spec.Baseline.Kind = "WorkloadPolicy"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny creating an already expired exception", func() {
+			obj.Spec.ExpiresAt = metav1.NewTime(time.Now().Add(-time.Hour))
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit updates to an expired exception that keep expiresAt", func() {
+			oldObj.Spec.ExpiresAt = metav1.NewTime(time.Now().Add(-time.Hour))
+			obj.Spec.ExpiresAt = oldObj.Spec.ExpiresAt
+			obj.Spec.Owner = "team-b"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny moving expiresAt into the past", func() {
+			obj.Spec.ExpiresAt = metav1.NewTime(time.Now().Add(-time.Minute))
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit deletion", func() {
+			_, err := validator.ValidateDelete(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -19,8 +19,13 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"path"
+	"regexp"
+	"slices"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -30,6 +35,8 @@ import (
 
 var securitybaselinelog = logf.Log.WithName("securitybaseline-resource")
 
+var profileVersionPattern = regexp.MustCompile(`^(latest|v1\.[0-9]+)$`)
+
 // SetupSecurityBaselineWebhookWithManager registers the webhook for SecurityBaseline in the manager.
 func SetupSecurityBaselineWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, &corev1alpha1.SecurityBaseline{}).
@@ -50,9 +57,16 @@ type SecurityBaselineCustomDefaulter struct{}
 // Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind SecurityBaseline.
 func (d *SecurityBaselineCustomDefaulter) Default(_ context.Context, obj *corev1alpha1.SecurityBaseline) error {
 	securitybaselinelog.Info("Defaulting for SecurityBaseline", "name", obj.GetName())
+	defaultSecurityBaselineSpec(&obj.Spec)
 	return nil
 }
 
+func defaultSecurityBaselineSpec(spec *corev1alpha1.SecurityBaselineSpec) {
+	if spec.EnforcementAction == "" {
+		spec.EnforcementAction = corev1alpha1.EnforcementActionEnforce
+	}
+}
+
 // +kubebuilder:webhook:path=/validate-core-platform-f3nr1r-io-v1alpha1-securitybaseline,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.platform.f3nr1r.io,resources=securitybaselines,verbs=create;update,versions=v1alpha1,name=vsecuritybaseline-v1alpha1.kb.io,admissionReviewVersions=v1
 
 // SecurityBaselineCustomValidator struct is responsible for validating the SecurityBaseline resource
@@ -65,13 +79,13 @@ type SecurityBaselineCustomValidator struct{}
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type SecurityBaseline.
 func (v *SecurityBaselineCustomValidator) ValidateCreate(_ context.Context, obj *corev1alpha1.SecurityBaseline) (admission.Warnings, error) {
 	securitybaselinelog.Info("Validation for SecurityBaseline upon creation", "name", obj.GetName())
-	return nil, validateSecurityBaselineSpec(obj)
+	return securityBaselineWarnings(obj), validateSecurityBaselineSpec(&obj.Spec)
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type SecurityBaseline.
 func (v *SecurityBaselineCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj *corev1alpha1.SecurityBaseline) (admission.Warnings, error) {
 	securitybaselinelog.Info("Validation for SecurityBaseline upon update", "name", newObj.GetName())
-	return nil, validateSecurityBaselineSpec(newObj)
+	return securityBaselineWarnings(newObj), validateSecurityBaselineSpec(&newObj.Spec)
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type SecurityBaseline.
@@ -80,11 +94,240 @@ func (v *SecurityBaselineCustomValidator) ValidateDelete(_ context.Context, obj
 	return nil, nil
 }
 
-func validateSecurityBaselineSpec(obj *corev1alpha1.SecurityBaseline) error {
-	for _, namespace := range obj.Spec.ExcludedNamespaces {
+// securityBaselineWarnings flags fields that have no effect on a namespaced SecurityBaseline.
+func securityBaselineWarnings(obj *corev1alpha1.SecurityBaseline) admission.Warnings {
+	if len(obj.Spec.ExcludedNamespaces) == 0 {
+		return nil
+	}
+	return admission.Warnings{
+		"spec.excludedNamespaces on a namespaced SecurityBaseline can only exclude its own namespace; use a ClusterSecurityBaseline to exclude namespaces cluster-wide",
+	}
+}
+
+func validateSecurityBaselineSpec(spec *corev1alpha1.SecurityBaselineSpec) error {
+	if err := validatePodSelector(spec.PodSelector); err != nil {
+		return err
+	}
+
+	switch spec.EnforcementAction {
+	case "", corev1alpha1.EnforcementActionEnforce, corev1alpha1.EnforcementActionWarn, corev1alpha1.EnforcementActionAudit:
+	default:
+		return fmt.Errorf("enforcementAction must be one of Enforce, Warn or Audit, got %q", spec.EnforcementAction)
+	}
+
+	switch spec.Profile {
+	case "", corev1alpha1.PodSecurityProfilePrivileged, corev1alpha1.PodSecurityProfileBaseline, corev1alpha1.PodSecurityProfileRestricted:
+	default:
+		return fmt.Errorf("profile must be one of privileged, baseline or restricted, got %q", spec.Profile)
+	}
+	if spec.ProfileVersion != "" {
+		if spec.Profile == "" {
+			return fmt.Errorf("profileVersion requires profile to be set")
+		}
+		if !profileVersionPattern.MatchString(spec.ProfileVersion) {
+			return fmt.Errorf("profileVersion must be \"latest\" or of the form v1.<minor>, got %q", spec.ProfileVersion)
+		}
+	}
+
+	for _, namespace := range spec.ExcludedNamespaces {
 		if strings.TrimSpace(namespace) == "" {
 			return fmt.Errorf("excludedNamespaces entries cannot be empty")
 		}
 	}
+
+	if spec.Capabilities != nil {
+		if err := validateCapabilitiesPolicy(spec.Capabilities); err != nil {
+			return err
+		}
+	}
+
+	if spec.HostPath != nil {
+		if err := validateHostPathPolicy(spec.HostPath); err != nil {
+			return err
+		}
+	}
+
+	if spec.Seccomp != nil {
+		allowedTypes := make([]string, 0, len(spec.Seccomp.AllowedTypes))
+		for _, t := range spec.Seccomp.AllowedTypes {
+			allowedTypes = append(allowedTypes, string(t))
+		}
+		if err := validateProfilePolicy("seccomp", allowedTypes, spec.Seccomp.AllowedLocalhostProfiles); err != nil {
+			return err
+		}
+	}
+
+	if spec.AppArmor != nil {
+		allowedTypes := make([]string, 0, len(spec.AppArmor.AllowedTypes))
+		for _, t := range spec.AppArmor.AllowedTypes {
+			allowedTypes = append(allowedTypes, string(t))
+		}
+		if err := validateProfilePolicy("appArmor", allowedTypes, spec.AppArmor.AllowedLocalhostProfiles); err != nil {
+			return err
+		}
+	}
+
+	for _, policy := range []struct {
+		fieldName string
+		policy    *corev1alpha1.IDRangePolicy
+	}{
+		{"runAsUser", spec.RunAsUser},
+		{"runAsGroup", spec.RunAsGroup},
+		{"fsGroup", spec.FSGroup},
+		{"supplementalGroups", spec.SupplementalGroups},
+	} {
+		if policy.policy == nil {
+			continue
+		}
+		if err := validateIDRangePolicy(policy.fieldName, policy.policy); err != nil {
+			return err
+		}
+	}
+
+	if spec.Debug != nil {
+		if err := validateDebugPolicy(spec.Debug); err != nil {
+			return err
+		}
+	}
+
+	if spec.ServiceAccounts != nil {
+		if err := validateServiceAccountPolicy(spec.ServiceAccounts); err != nil {
+			return err
+		}
+	}
+
+	if spec.Images != nil {
+		if err := validateImagePolicy(spec.Images); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateDebugPolicy(policy *corev1alpha1.DebugPolicy) error {
+	if len(policy.Users) == 0 && len(policy.Groups) == 0 {
+		return fmt.Errorf("debug requires at least one of users or groups")
+	}
+	for _, subject := range slices.Concat(policy.Users, policy.Groups) {
+		if strings.TrimSpace(subject) == "" {
+			return fmt.Errorf("debug.users and debug.groups entries cannot be empty")
+		}
+	}
+	return nil
+}
+
+func validateServiceAccountPolicy(policy *corev1alpha1.ServiceAccountPolicy) error {
+	for _, pattern := range policy.Allowed {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("serviceAccounts.allowed entries cannot be empty")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("serviceAccounts.allowed entry %q is not a valid pattern: %w", pattern, err)
+		}
+		if policy.DisallowDefault && pattern == "default" {
+			return fmt.Errorf("serviceAccounts.allowed must not list \"default\" when disallowDefault is set")
+		}
+	}
+	return nil
+}
+
+// validateIDRangePolicy checks a runAsUser, runAsGroup, fsGroup or
+// supplementalGroups policy.
+func validateIDRangePolicy(fieldName string, policy *corev1alpha1.IDRangePolicy) error {
+	if len(policy.Ranges) == 0 && policy.NamespaceAnnotation == "" {
+		return fmt.Errorf("%s requires ranges or namespaceAnnotation", fieldName)
+	}
+	for _, r := range policy.Ranges {
+		if r.Min < 0 || r.Min > r.Max {
+			return fmt.Errorf("%s.ranges entry %d-%d must satisfy 0 <= min <= max", fieldName, r.Min, r.Max)
+		}
+	}
+	if policy.NamespaceAnnotation != "" {
+		if errs := validation.IsQualifiedName(policy.NamespaceAnnotation); len(errs) > 0 {
+			return fmt.Errorf("%s.namespaceAnnotation %q is not a valid annotation key: %s",
+				fieldName, policy.NamespaceAnnotation, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// validateProfilePolicy checks a seccomp or AppArmor policy.
+func validateProfilePolicy(fieldName string, allowedTypes, localhostProfiles []string) error {
+	for _, t := range allowedTypes {
+		if t != "RuntimeDefault" && t != "Localhost" {
+			return fmt.Errorf("%s.allowedTypes entries must be RuntimeDefault or Localhost, got %q", fieldName, t)
+		}
+	}
+	if len(localhostProfiles) > 0 && len(allowedTypes) > 0 && !slices.Contains(allowedTypes, "Localhost") {
+		return fmt.Errorf("%s.allowedLocalhostProfiles requires Localhost in %s.allowedTypes", fieldName, fieldName)
+	}
+	for _, profile := range localhostProfiles {
+		if strings.TrimSpace(profile) == "" {
+			return fmt.Errorf("%s.allowedLocalhostProfiles entries cannot be empty", fieldName)
+		}
+		if _, err := path.Match(profile, ""); err != nil {
+			return fmt.Errorf("%s.allowedLocalhostProfiles entry %q is not a valid pattern: %w", fieldName, profile, err)
+		}
+	}
+	return nil
+}
+
+func validateImagePolicy(policy *corev1alpha1.ImagePolicy) error {
+	return validateImagePrefixes("images.allowedRegistries", policy.AllowedRegistries)
+}
+
+// validateImagePrefixes checks a list of registry or repository prefixes such
+// as "ghcr.io/my-org".
+func validateImagePrefixes(fieldName string, prefixes []string) error {
+	for _, prefix := range prefixes {
+		if strings.TrimSpace(prefix) == "" {
+			return fmt.Errorf("%s entries cannot be empty", fieldName)
+		}
+		if strings.Contains(prefix, "://") {
+			return fmt.Errorf("%s entry %q must not include a URL scheme", fieldName, prefix)
+		}
+		if strings.Contains(prefix, "@") {
+			return fmt.Errorf("%s entry %q must not include a digest", fieldName, prefix)
+		}
+	}
+	return nil
+}
+
+func validateHostPathPolicy(policy *corev1alpha1.HostPathPolicy) error {
+	for _, prefix := range policy.AllowedReadOnlyPathPrefixes {
+		if strings.TrimSpace(prefix) == "" {
+			return fmt.Errorf("hostPath.allowedReadOnlyPathPrefixes entries cannot be empty")
+		}
+		if !path.IsAbs(prefix) {
+			return fmt.Errorf("hostPath.allowedReadOnlyPathPrefixes entry %q must be an absolute path", prefix)
+		}
+		if slices.Contains(strings.Split(prefix, "/"), "..") {
+			return fmt.Errorf("hostPath.allowedReadOnlyPathPrefixes entry %q must not contain '..'", prefix)
+		}
+	}
+	return nil
+}
+
+func validateCapabilitiesPolicy(policy *corev1alpha1.CapabilitiesPolicy) error {
+	seen := make(map[corev1.Capability]struct{}, len(policy.AllowedAdd))
+	for _, capability := range policy.AllowedAdd {
+		name := string(capability)
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("capabilities.allowedAdd entries cannot be empty")
+		}
+		if strings.EqualFold(name, "ALL") {
+			return fmt.Errorf("capabilities.allowedAdd cannot contain ALL")
+		}
+		if strings.HasPrefix(strings.ToUpper(name), "CAP_") {
+			return fmt.Errorf("capabilities.allowedAdd entry %q must not use the CAP_ prefix", name)
+		}
+		if name != strings.ToUpper(name) {
+			return fmt.Errorf("capabilities.allowedAdd entry %q must be upper case", name)
+		}
+		if _, dup := seen[capability]; dup {
+			return fmt.Errorf("capabilities.allowedAdd contains duplicate entry %q", name)
+		}
+		seen[capability] = struct{}{}
+	}
 	return nil
 }
@@ -19,6 +19,9 @@ package v1alpha1
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
 )
@@ -46,12 +49,23 @@ var _ = Describe("SecurityBaseline Webhook", func() {
 		It("Should apply defaults without error", func() {
 			Expect(defaulter.Default(ctx, obj)).To(Succeed())
 		})
+
+		It("Should default enforcementAction to Enforce", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.EnforcementAction).To(Equal(corev1alpha1.EnforcementActionEnforce))
+		})
+
+		It("Should preserve an explicit enforcementAction", func() {
+			obj.Spec.EnforcementAction = corev1alpha1.EnforcementActionAudit
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.EnforcementAction).To(Equal(corev1alpha1.EnforcementActionAudit))
+		})
 	})
 
 	Context("When creating or updating SecurityBaseline under Validating Webhook", func() {
 		It("Should admit a valid SecurityBaseline", func() {
-			obj.Spec.RunAsNonRoot = true
-			obj.Spec.ReadOnlyRootFilesystem = true
+			obj.Spec.RunAsNonRoot = ptr.To(true)
+			obj.Spec.ReadOnlyRootFilesystem = ptr.To(true)
 			_, err := validator.ValidateCreate(ctx, obj)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -62,8 +76,179 @@ var _ = Describe("SecurityBaseline Webhook", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("Should deny creation with an unknown enforcementAction", func() {
+			obj.Spec.EnforcementAction = "Block"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit a valid capabilities allowlist", func() {
+			obj.Spec.Capabilities = &corev1alpha1.CapabilitiesPolicy{
+				RequireDropAll: true,
+				AllowedAdd:     []corev1.Capability{"NET_BIND_SERVICE"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny ALL in the capabilities allowlist", func() {
+			obj.Spec.Capabilities = &corev1alpha1.CapabilitiesPolicy{AllowedAdd: []corev1.Capability{"ALL"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny CAP_-prefixed capabilities in the allowlist", func() {
+			obj.Spec.Capabilities = &corev1alpha1.CapabilitiesPolicy{AllowedAdd: []corev1.Capability{"CAP_NET_ADMIN"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny duplicate capabilities in the allowlist", func() {
+			obj.Spec.Capabilities = &corev1alpha1.CapabilitiesPolicy{
+				AllowedAdd: []corev1.Capability{"NET_BIND_SERVICE", "NET_BIND_SERVICE"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit absolute hostPath prefixes", func() {
+			obj.Spec.HostPath = &corev1alpha1.HostPathPolicy{AllowedReadOnlyPathPrefixes: []string{"/var/log"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny relative hostPath prefixes", func() {
+			obj.Spec.HostPath = &corev1alpha1.HostPathPolicy{AllowedReadOnlyPathPrefixes: []string{"var/log"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny hostPath prefixes containing '..'", func() {
+			obj.Spec.HostPath = &corev1alpha1.HostPathPolicy{AllowedReadOnlyPathPrefixes: []string{"/var/log/../../etc"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit a seccomp policy with a localhost allowlist", func() {
+			obj.Spec.Seccomp = &corev1alpha1.SeccompPolicy{
+				AllowedTypes:             []corev1.SeccompProfileType{corev1.SeccompProfileTypeLocalhost},
+				AllowedLocalhostProfiles: []string{"profiles/*.json"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny Unconfined in the seccomp allowed types", func() {
+			obj.Spec.Seccomp = &corev1alpha1.SeccompPolicy{
+				AllowedTypes: []corev1.SeccompProfileType{corev1.SeccompProfileTypeUnconfined},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny AppArmor localhost profiles when Localhost is not allowed", func() {
+			obj.Spec.AppArmor = &corev1alpha1.AppArmorPolicy{
+				AllowedTypes:             []corev1.AppArmorProfileType{corev1.AppArmorProfileTypeRuntimeDefault},
+				AllowedLocalhostProfiles: []string{"k8s-nginx"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit ID ranges read from a namespace annotation", func() {
+			obj.Spec.RunAsUser = &corev1alpha1.IDRangePolicy{
+				Ranges:              []corev1alpha1.IDRange{{Min: 1000, Max: 1999}},
+				NamespaceAnnotation: "openshift.io/sa.scc.uid-range",
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny an ID range ending before it starts", func() {
+			obj.Spec.SupplementalGroups = &corev1alpha1.IDRangePolicy{Ranges: []corev1alpha1.IDRange{{Min: 2000, Max: 1000}}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny an ID range policy without ranges or a namespace annotation", func() {
+			obj.Spec.FSGroup = &corev1alpha1.IDRangePolicy{}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit a ServiceAccount allowlist with wildcards", func() {
+			obj.Spec.ServiceAccounts = &corev1alpha1.ServiceAccountPolicy{
+				DisallowTokenAutomount: true,
+				DisallowDefault:        true,
+				Allowed:                []string{"web", "ci-*"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny allowing the default ServiceAccount when it is disallowed", func() {
+			obj.Spec.ServiceAccounts = &corev1alpha1.ServiceAccountPolicy{DisallowDefault: true, Allowed: []string{"default"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit a debug baseline for break-glass groups", func() {
+			obj.Spec.Debug = &corev1alpha1.DebugPolicy{Groups: []string{"sre-oncall"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a debug baseline without users or groups", func() {
+			obj.Spec.Debug = &corev1alpha1.DebugPolicy{}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit registry and repository prefixes in the image policy", func() {
+			obj.Spec.Images = &corev1alpha1.ImagePolicy{
+				AllowedRegistries: []string{"registry.example.com:5000", "ghcr.io/my-org"},
+				DisallowLatestTag: true,
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny allowed registries with a URL scheme", func() {
+			obj.Spec.Images = &corev1alpha1.ImagePolicy{AllowedRegistries: []string{"https://ghcr.io"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit a pinned Pod Security Standards profile", func() {
+			obj.Spec.Profile = corev1alpha1.PodSecurityProfileRestricted
+			obj.Spec.ProfileVersion = "v1.30"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a malformed profileVersion", func() {
+			obj.Spec.Profile = corev1alpha1.PodSecurityProfileBaseline
+			obj.Spec.ProfileVersion = "1.30"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny profileVersion without a profile", func() {
+			obj.Spec.ProfileVersion = "latest"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny an invalid podSelector", func() {
+			obj.Spec.PodSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: metav1.LabelSelectorOpIn},
+			}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("Should admit a valid update", func() {
-			obj.Spec.RunAsNonRoot = true
+			obj.Spec.RunAsNonRoot = ptr.To(true)
 			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -66,13 +66,13 @@ type TelemetryProfileCustomValidator struct{}
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type TelemetryProfile.
 func (v *TelemetryProfileCustomValidator) ValidateCreate(_ context.Context, obj *corev1alpha1.TelemetryProfile) (admission.Warnings, error) {
 	telemetryprofilelog.Info("Validation for TelemetryProfile upon creation", "name", obj.GetName())
-	return nil, validateTelemetryProfileSpec(obj)
+	return nil, validateTelemetryProfileSpec(&obj.Spec)
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type TelemetryProfile.
 func (v *TelemetryProfileCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj *corev1alpha1.TelemetryProfile) (admission.Warnings, error) {
 	telemetryprofilelog.Info("Validation for TelemetryProfile upon update", "name", newObj.GetName())
-	return nil, validateTelemetryProfileSpec(newObj)
+	return nil, validateTelemetryProfileSpec(&newObj.Spec)
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type TelemetryProfile.
@@ -81,21 +81,25 @@ func (v *TelemetryProfileCustomValidator) ValidateDelete(_ context.Context, obj
 	return nil, nil
 }
 
-func validateTelemetryProfileSpec(obj *corev1alpha1.TelemetryProfile) error {
-	if obj.Spec.TracingEndpoint != "" {
-		parsed, err := url.ParseRequestURI(obj.Spec.TracingEndpoint)
+func validateTelemetryProfileSpec(spec *corev1alpha1.TelemetryProfileSpec) error {
+	if err := validatePodSelector(spec.PodSelector); err != nil {
+		return err
+	}
+
+	if spec.TracingEndpoint != "" {
+		parsed, err := url.ParseRequestURI(spec.TracingEndpoint)
 		if err != nil {
-			return fmt.Errorf("invalid tracingEndpoint: %q", obj.Spec.TracingEndpoint)
+			return fmt.Errorf("invalid tracingEndpoint: %q", spec.TracingEndpoint)
 		}
 		if parsed.Scheme != "http" && parsed.Scheme != "https" {
 			return fmt.Errorf("tracingEndpoint must use http or https scheme, got: %q", parsed.Scheme)
 		}
 	}
 
-	if obj.Spec.SamplingRate != "" {
-		rate, err := strconv.ParseFloat(obj.Spec.SamplingRate, 64)
+	if spec.SamplingRate != "" {
+		rate, err := strconv.ParseFloat(spec.SamplingRate, 64)
 		if err != nil {
-			return fmt.Errorf("invalid samplingRate: %q", obj.Spec.SamplingRate)
+			return fmt.Errorf("invalid samplingRate: %q", spec.SamplingRate)
 		}
 		if rate < 0 || rate > 1 {
 			return fmt.Errorf("samplingRate must be between 0 and 1")
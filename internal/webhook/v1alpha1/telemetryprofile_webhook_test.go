@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
 )
@@ -90,6 +91,14 @@ var _ = Describe("TelemetryProfile Webhook", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("Should deny an invalid podSelector", func() {
+			obj.Spec.PodSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: metav1.LabelSelectorOpIn},
+			}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("Should admit a valid update", func() {
 			obj.Spec.SamplingRate = "1.0"
 			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
@@ -19,10 +19,12 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"path"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -34,7 +36,7 @@ var workloadpolicylog = logf.Log.WithName("workloadpolicy-resource")
 // SetupWorkloadPolicyWebhookWithManager registers the webhook for WorkloadPolicy in the manager.
 func SetupWorkloadPolicyWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, &corev1alpha1.WorkloadPolicy{}).
-		WithValidator(&WorkloadPolicyCustomValidator{}).
+		WithValidator(&WorkloadPolicyCustomValidator{Client: mgr.GetClient()}).
 		WithDefaulter(&WorkloadPolicyCustomDefaulter{}).
 		Complete()
 }
@@ -51,20 +53,24 @@ type WorkloadPolicyCustomDefaulter struct{}
 // Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind WorkloadPolicy.
 func (d *WorkloadPolicyCustomDefaulter) Default(_ context.Context, obj *corev1alpha1.WorkloadPolicy) error {
 	workloadpolicylog.Info("Defaulting for WorkloadPolicy", "name", obj.GetName())
-	if obj.Spec.HorizontalScaling == nil {
-		return nil
+	defaultWorkloadPolicySpec(&obj.Spec)
+	return nil
+}
+
+func defaultWorkloadPolicySpec(spec *corev1alpha1.WorkloadPolicySpec) {
+	if spec.HorizontalScaling == nil {
+		return
 	}
 
-	if obj.Spec.HorizontalScaling.MinReplicas == 0 {
-		obj.Spec.HorizontalScaling.MinReplicas = corev1alpha1.DefaultHPAMinReplicas
+	if spec.HorizontalScaling.MinReplicas == 0 {
+		spec.HorizontalScaling.MinReplicas = corev1alpha1.DefaultHPAMinReplicas
 	}
-	if obj.Spec.HorizontalScaling.MaxReplicas == 0 {
-		obj.Spec.HorizontalScaling.MaxReplicas = corev1alpha1.DefaultHPAMaxReplicas
+	if spec.HorizontalScaling.MaxReplicas == 0 {
+		spec.HorizontalScaling.MaxReplicas = corev1alpha1.DefaultHPAMaxReplicas
 	}
-	if obj.Spec.HorizontalScaling.TargetCPUUtilizationPercentage == 0 {
-		obj.Spec.HorizontalScaling.TargetCPUUtilizationPercentage = corev1alpha1.DefaultHPATargetCPU
+	if spec.HorizontalScaling.TargetCPUUtilizationPercentage == 0 {
+		spec.HorizontalScaling.TargetCPUUtilizationPercentage = corev1alpha1.DefaultHPATargetCPU
 	}
-	return nil
 }
 
 // +kubebuilder:webhook:path=/validate-core-platform-f3nr1r-io-v1alpha1-workloadpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.platform.f3nr1r.io,resources=workloadpolicies,verbs=create;update,versions=v1alpha1,name=vworkloadpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
@@ -74,18 +80,28 @@ func (d *WorkloadPolicyCustomDefaulter) Default(_ context.Context, obj *corev1al
 //
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as this struct is used only for temporary operations and does not need to be deeply copied.
-type WorkloadPolicyCustomValidator struct{}
+type WorkloadPolicyCustomValidator struct {
+	// Client lists the WorkloadPolicies of a namespace, so that only one of
+	// them generates a LimitRange. The check is skipped when nil.
+	Client client.Reader
+}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type WorkloadPolicy.
-func (v *WorkloadPolicyCustomValidator) ValidateCreate(_ context.Context, obj *corev1alpha1.WorkloadPolicy) (admission.Warnings, error) {
+func (v *WorkloadPolicyCustomValidator) ValidateCreate(ctx context.Context, obj *corev1alpha1.WorkloadPolicy) (admission.Warnings, error) {
 	workloadpolicylog.Info("Validation for WorkloadPolicy upon creation", "name", obj.GetName())
-	return nil, validateWorkloadPolicySpec(obj)
+	if err := validateWorkloadPolicySpec(&obj.Spec); err != nil {
+		return nil, err
+	}
+	return nil, v.validateSingleLimitRange(ctx, obj)
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type WorkloadPolicy.
-func (v *WorkloadPolicyCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj *corev1alpha1.WorkloadPolicy) (admission.Warnings, error) {
+func (v *WorkloadPolicyCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj *corev1alpha1.WorkloadPolicy) (admission.Warnings, error) {
 	workloadpolicylog.Info("Validation for WorkloadPolicy upon update", "name", newObj.GetName())
-	return nil, validateWorkloadPolicySpec(newObj)
+	if err := validateWorkloadPolicySpec(&newObj.Spec); err != nil {
+		return nil, err
+	}
+	return nil, v.validateSingleLimitRange(ctx, newObj)
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type WorkloadPolicy.
@@ -94,20 +110,66 @@ func (v *WorkloadPolicyCustomValidator) ValidateDelete(_ context.Context, obj *c
 	return nil, nil
 }
 
-func validateWorkloadPolicySpec(obj *corev1alpha1.WorkloadPolicy) error {
-	for resourceName, resourceValue := range obj.Spec.DefaultRequests {
-		if _, err := resource.ParseQuantity(resourceValue); err != nil {
-			return fmt.Errorf("invalid defaultRequests quantity for %q: %q", resourceName, resourceValue)
+// validateSingleLimitRange denies generateLimitRange when another
+// WorkloadPolicy of the namespace already sets it. The API server applies
+// every LimitRange of a namespace, so the defaults and bounds of several
+// generated LimitRanges would combine regardless of priority and
+// mergeStrategy.
+func (v *WorkloadPolicyCustomValidator) validateSingleLimitRange(ctx context.Context, policy *corev1alpha1.WorkloadPolicy) error {
+	if !policy.Spec.GenerateLimitRange || v.Client == nil {
+		return nil
+	}
+	var policies corev1alpha1.WorkloadPolicyList
+	if err := v.Client.List(ctx, &policies, client.InNamespace(policy.Namespace)); err != nil {
+		return err
+	}
+	for _, other := range policies.Items {
+		if other.Name != policy.Name && other.Spec.GenerateLimitRange {
+			return fmt.Errorf("generateLimitRange is already set on WorkloadPolicy %s, only one WorkloadPolicy per namespace may generate a LimitRange", other.Name)
 		}
 	}
+	return nil
+}
 
-	for resourceName, resourceValue := range obj.Spec.DefaultLimits {
-		if _, err := resource.ParseQuantity(resourceValue); err != nil {
-			return fmt.Errorf("invalid defaultLimits quantity for %q: %q", resourceName, resourceValue)
+func validateWorkloadPolicySpec(spec *corev1alpha1.WorkloadPolicySpec) error {
+	if err := validatePodSelector(spec.PodSelector); err != nil {
+		return err
+	}
+
+	if err := validateResourceDefaults("", spec.DefaultRequests, spec.DefaultLimits); err != nil {
+		return err
+	}
+	if defaults := spec.InitContainerResources; defaults != nil {
+		if err := validateResourceDefaults("initContainerResources.", defaults.DefaultRequests, defaults.DefaultLimits); err != nil {
+			return err
 		}
 	}
+	if defaults := spec.SidecarResources; defaults != nil {
+		if err := validateResourceDefaults("sidecarResources.", defaults.DefaultRequests, defaults.DefaultLimits); err != nil {
+			return err
+		}
+	}
+	for i, rule := range spec.ContainerResources {
+		prefix := fmt.Sprintf("containerResources[%d].", i)
+		if rule.Name == "" && rule.ImagePrefix == "" {
+			return fmt.Errorf("%s must set name or imagePrefix", strings.TrimSuffix(prefix, "."))
+		}
+		if _, err := path.Match(rule.Name, ""); err != nil {
+			return fmt.Errorf("invalid %sname pattern %q: %w", prefix, rule.Name, err)
+		}
+		if err := validateResourceDefaults(prefix, rule.DefaultRequests, rule.DefaultLimits); err != nil {
+			return err
+		}
+	}
+
+	if err := validateResourceBounds(spec); err != nil {
+		return err
+	}
+	if err := validateNamespaceResources(spec); err != nil {
+		return err
+	}
 
-	for key, value := range obj.Spec.MandatoryLabels {
+	for key, value := range spec.MandatoryLabels {
 		if strings.TrimSpace(key) == "" {
 			return fmt.Errorf("mandatoryLabels key cannot be empty")
 		}
@@ -116,20 +178,165 @@ func validateWorkloadPolicySpec(obj *corev1alpha1.WorkloadPolicy) error {
 		}
 	}
 
-	if obj.Spec.HorizontalScaling != nil {
-		if obj.Spec.HorizontalScaling.MinReplicas < 1 {
+	if spec.HorizontalScaling != nil {
+		if spec.HorizontalScaling.MinReplicas < 1 {
 			return fmt.Errorf("horizontalScaling.minReplicas must be >= 1")
 		}
-		if obj.Spec.HorizontalScaling.MaxReplicas < 1 {
+		if spec.HorizontalScaling.MaxReplicas < 1 {
 			return fmt.Errorf("horizontalScaling.maxReplicas must be >= 1")
 		}
-		if obj.Spec.HorizontalScaling.MaxReplicas < obj.Spec.HorizontalScaling.MinReplicas {
+		if spec.HorizontalScaling.MaxReplicas < spec.HorizontalScaling.MinReplicas {
 			return fmt.Errorf("horizontalScaling.maxReplicas must be >= horizontalScaling.minReplicas")
 		}
-		if obj.Spec.HorizontalScaling.TargetCPUUtilizationPercentage < 1 || obj.Spec.HorizontalScaling.TargetCPUUtilizationPercentage > 100 {
+		if spec.HorizontalScaling.TargetCPUUtilizationPercentage < 1 || spec.HorizontalScaling.TargetCPUUtilizationPercentage > 100 {
 			return fmt.Errorf("horizontalScaling.targetCPUUtilizationPercentage must be between 1 and 100")
 		}
 	}
 
 	return nil
 }
+
+// validateResourceDefaults checks that default requests and limits are valid
+// quantities. prefix locates them in the spec.
+func validateResourceDefaults(prefix string, requests, limits map[string]string) error {
+	for resourceName, resourceValue := range requests {
+		if _, err := resource.ParseQuantity(resourceValue); err != nil {
+			return fmt.Errorf("invalid %sdefaultRequests quantity for %q: %q", prefix, resourceName, resourceValue)
+		}
+	}
+
+	for resourceName, resourceValue := range limits {
+		if _, err := resource.ParseQuantity(resourceValue); err != nil {
+			return fmt.Errorf("invalid %sdefaultLimits quantity for %q: %q", prefix, resourceName, resourceValue)
+		}
+	}
+	return nil
+}
+
+// validateResourceBounds checks that resource bounds are valid quantities, that
+// minimum requests do not exceed maximum limits, and that the resource
+// defaults of the policy lie within its own bounds, so the policy never
+// defaults a container into a denial. Default requests and limits are
+// expected to be valid quantities already.
+func validateResourceBounds(spec *corev1alpha1.WorkloadPolicySpec) error {
+	minRequests, err := parseResourceBounds("minRequests", spec.MinRequests)
+	if err != nil {
+		return err
+	}
+	maxLimits, err := parseResourceBounds("maxLimits", spec.MaxLimits)
+	if err != nil {
+		return err
+	}
+	maxRatios, err := parseResourceBounds("maxLimitRequestRatio", spec.MaxLimitRequestRatio)
+	if err != nil {
+		return err
+	}
+
+	for resourceName, ratio := range maxRatios {
+		if ratio.Cmp(resource.MustParse("1")) < 0 {
+			return fmt.Errorf("maxLimitRequestRatio for %q must be at least 1", resourceName)
+		}
+	}
+	for resourceName, minimum := range minRequests {
+		if maximum, ok := maxLimits[resourceName]; ok && minimum.Cmp(maximum) > 0 {
+			return fmt.Errorf("minRequests for %q must not exceed maxLimits", resourceName)
+		}
+	}
+
+	type resourceDefaults struct {
+		prefix           string
+		requests, limits map[string]string
+	}
+	defaults := []resourceDefaults{{prefix: "", requests: spec.DefaultRequests, limits: spec.DefaultLimits}}
+	if d := spec.InitContainerResources; d != nil {
+		defaults = append(defaults, resourceDefaults{prefix: "initContainerResources.", requests: d.DefaultRequests, limits: d.DefaultLimits})
+	}
+	if d := spec.SidecarResources; d != nil {
+		defaults = append(defaults, resourceDefaults{prefix: "sidecarResources.", requests: d.DefaultRequests, limits: d.DefaultLimits})
+	}
+	for i, rule := range spec.ContainerResources {
+		defaults = append(defaults, resourceDefaults{
+			prefix: fmt.Sprintf("containerResources[%d].", i), requests: rule.DefaultRequests, limits: rule.DefaultLimits,
+		})
+	}
+	for _, d := range defaults {
+		for resourceName, value := range d.requests {
+			request := resource.MustParse(value)
+			if minimum, ok := minRequests[resourceName]; ok && request.Cmp(minimum) < 0 {
+				return fmt.Errorf("%sdefaultRequests for %q must not be below minRequests", d.prefix, resourceName)
+			}
+			if maximum, ok := maxLimits[resourceName]; ok && request.Cmp(maximum) > 0 {
+				return fmt.Errorf("%sdefaultRequests for %q must not exceed maxLimits", d.prefix, resourceName)
+			}
+		}
+		for resourceName, value := range d.limits {
+			limit := resource.MustParse(value)
+			if minimum, ok := minRequests[resourceName]; ok && limit.Cmp(minimum) < 0 {
+				return fmt.Errorf("%sdefaultLimits for %q must not be below minRequests", d.prefix, resourceName)
+			}
+			if maximum, ok := maxLimits[resourceName]; ok && limit.Cmp(maximum) > 0 {
+				return fmt.Errorf("%sdefaultLimits for %q must not exceed maxLimits", d.prefix, resourceName)
+			}
+			requestValue, hasRequest := d.requests[resourceName]
+			ratio, hasRatio := maxRatios[resourceName]
+			if !hasRequest || !hasRatio {
+				continue
+			}
+			request := resource.MustParse(requestValue)
+			if limit.AsApproximateFloat64() > request.AsApproximateFloat64()*ratio.AsApproximateFloat64() {
+				return fmt.Errorf("%sdefaultLimits for %q must not exceed maxLimitRequestRatio times %sdefaultRequests",
+					d.prefix, resourceName, d.prefix)
+			}
+		}
+	}
+	return nil
+}
+
+// validateNamespaceResources checks the LimitRange and ResourceQuota
+// generated for the policy. They apply to the whole namespace, so the policy
+// must not select Pods, and the API server rejects a LimitRange whose default
+// request exceeds its default limit.
+func validateNamespaceResources(spec *corev1alpha1.WorkloadPolicySpec) error {
+	if spec.GenerateLimitRange {
+		if spec.PodSelector != nil {
+			return fmt.Errorf("generateLimitRange is only supported on policies without a podSelector")
+		}
+		for resourceName, requestValue := range spec.DefaultRequests {
+			limitValue, ok := spec.DefaultLimits[resourceName]
+			if !ok {
+				continue
+			}
+			request := resource.MustParse(requestValue)
+			if request.Cmp(resource.MustParse(limitValue)) > 0 {
+				return fmt.Errorf("defaultRequests for %q must not exceed defaultLimits when generateLimitRange is set", resourceName)
+			}
+		}
+	}
+
+	if spec.Quota != nil {
+		if spec.PodSelector != nil {
+			return fmt.Errorf("quota is only supported on policies without a podSelector")
+		}
+		if _, err := parseResourceBounds("quota.hard", spec.Quota.Hard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseResourceBounds parses the non-negative quantities of a resource bound
+// or quota of a policy spec.
+func parseResourceBounds(field string, values map[string]string) (map[string]resource.Quantity, error) {
+	bounds := make(map[string]resource.Quantity, len(values))
+	for resourceName, resourceValue := range values {
+		qty, err := resource.ParseQuantity(resourceValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s quantity for %q: %q", field, resourceName, resourceValue)
+		}
+		if qty.Sign() < 0 {
+			return nil, fmt.Errorf("%s for %q must not be negative", field, resourceName)
+		}
+		bounds[resourceName] = qty
+	}
+	return bounds, nil
+}
@@ -19,6 +19,9 @@ package v1alpha1
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
 )
@@ -74,6 +77,125 @@ var _ = Describe("WorkloadPolicy Webhook", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("Should deny creation with an invalid sidecarResources quantity", func() {
+			obj.Spec.SidecarResources = &corev1alpha1.ContainerResourceDefaults{
+				DefaultRequests: map[string]string{"cpu": "not-a-quantity"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("sidecarResources.defaultRequests")))
+		})
+
+		It("Should deny a containerResources rule with an invalid name pattern", func() {
+			obj.Spec.ContainerResources = []corev1alpha1.ContainerResourceRule{{
+				ContainerSelector: corev1alpha1.ContainerSelector{Name: "istio-["},
+			}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("invalid containerResources[0].name pattern")))
+		})
+
+		It("Should deny a containerResources rule without a selector", func() {
+			obj.Spec.ContainerResources = []corev1alpha1.ContainerResourceRule{{
+				ContainerResourceDefaults: corev1alpha1.ContainerResourceDefaults{DefaultRequests: map[string]string{"cpu": "100m"}},
+			}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("containerResources[0] must set name or imagePrefix")))
+		})
+
+		It("Should deny a containerResources default outside of the bounds", func() {
+			obj.Spec.MaxLimits = map[string]string{"memory": "1Gi"}
+			obj.Spec.ContainerResources = []corev1alpha1.ContainerResourceRule{{
+				ContainerSelector:         corev1alpha1.ContainerSelector{ImagePrefix: "fluent/"},
+				ContainerResourceDefaults: corev1alpha1.ContainerResourceDefaults{DefaultLimits: map[string]string{"memory": "2Gi"}},
+			}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("containerResources[0].defaultLimits for \"memory\" must not exceed maxLimits")))
+		})
+
+		It("Should admit resource bounds that contain the defaults", func() {
+			obj.Spec.DefaultRequests = map[string]string{"cpu": "100m"}
+			obj.Spec.DefaultLimits = map[string]string{"cpu": "400m"}
+			obj.Spec.MinRequests = map[string]string{"cpu": "50m"}
+			obj.Spec.MaxLimits = map[string]string{"cpu": "2"}
+			obj.Spec.MaxLimitRequestRatio = map[string]string{"cpu": "4"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny creation when minRequests exceed maxLimits", func() {
+			obj.Spec.MinRequests = map[string]string{"memory": "2Gi"}
+			obj.Spec.MaxLimits = map[string]string{"memory": "1Gi"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("minRequests for \"memory\" must not exceed maxLimits")))
+		})
+
+		It("Should deny creation with a maxLimitRequestRatio below 1", func() {
+			obj.Spec.MaxLimitRequestRatio = map[string]string{"cpu": "500m"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("maxLimitRequestRatio for \"cpu\" must be at least 1")))
+		})
+
+		It("Should deny creation when a default lies outside of the bounds", func() {
+			obj.Spec.MinRequests = map[string]string{"cpu": "100m"}
+			obj.Spec.SidecarResources = &corev1alpha1.ContainerResourceDefaults{
+				DefaultRequests: map[string]string{"cpu": "10m"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("sidecarResources.defaultRequests for \"cpu\" must not be below minRequests")))
+		})
+
+		It("Should deny creation when the default limit exceeds the ratio to the default request", func() {
+			obj.Spec.DefaultRequests = map[string]string{"cpu": "100m"}
+			obj.Spec.DefaultLimits = map[string]string{"cpu": "1"}
+			obj.Spec.MaxLimitRequestRatio = map[string]string{"cpu": "4"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("defaultLimits for \"cpu\" must not exceed maxLimitRequestRatio")))
+		})
+
+		It("Should admit a generated LimitRange and ResourceQuota", func() {
+			obj.Spec.GenerateLimitRange = true
+			obj.Spec.DefaultRequests = map[string]string{"cpu": "100m"}
+			obj.Spec.DefaultLimits = map[string]string{"cpu": "500m"}
+			obj.Spec.Quota = &corev1alpha1.QuotaPolicy{Hard: map[string]string{"requests.cpu": "10", "pods": "50"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a second policy generating a LimitRange in the namespace", func() {
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1alpha1.WorkloadPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "team-a"},
+				Spec:       corev1alpha1.WorkloadPolicySpec{GenerateLimitRange: true},
+			}).Build()
+			obj.ObjectMeta = metav1.ObjectMeta{Name: "batch", Namespace: "team-a"}
+			obj.Spec.GenerateLimitRange = true
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("generateLimitRange is already set on WorkloadPolicy defaults")))
+
+			obj.Name = "defaults"
+			_, err = validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a generated LimitRange on a policy with a podSelector", func() {
+			obj.Spec.GenerateLimitRange = true
+			obj.Spec.PodSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"workload": "batch"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("generateLimitRange is only supported on policies without a podSelector")))
+		})
+
+		It("Should deny a generated LimitRange whose default request exceeds its default limit", func() {
+			obj.Spec.GenerateLimitRange = true
+			obj.Spec.DefaultRequests = map[string]string{"memory": "1Gi"}
+			obj.Spec.DefaultLimits = map[string]string{"memory": "512Mi"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("defaultRequests for \"memory\" must not exceed defaultLimits")))
+		})
+
+		It("Should deny an invalid quota quantity", func() {
+			obj.Spec.Quota = &corev1alpha1.QuotaPolicy{Hard: map[string]string{"pods": "lots"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("invalid quota.hard quantity for \"pods\"")))
+		})
+
 		It("Should deny creation with an empty mandatoryLabels key", func() {
 			obj.Spec.MandatoryLabels = map[string]string{"": "value"}
 			_, err := validator.ValidateCreate(ctx, obj)
@@ -86,6 +208,14 @@ var _ = Describe("WorkloadPolicy Webhook", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("Should deny an invalid podSelector", func() {
+			obj.Spec.PodSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: metav1.LabelSelectorOpIn},
+			}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("Should admit a valid update", func() {
 			obj.Spec.DefaultRequests = map[string]string{"cpu": "100m"}
 			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
@@ -0,0 +1,96 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/controller"
+)
+
+var _ = Describe("ClusterSecurityBaseline Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-clustersecuritybaseline"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name: resourceName,
+		}
+		clustersecuritybaseline := &corev1alpha1.ClusterSecurityBaseline{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind ClusterSecurityBaseline")
+			err := k8sClient.Get(ctx, typeNamespacedName, clustersecuritybaseline)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &corev1alpha1.ClusterSecurityBaseline{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: resourceName,
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			resource := &corev1alpha1.ClusterSecurityBaseline{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance ClusterSecurityBaseline")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should successfully reconcile the resource", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &controller.ClusterSecurityBaselineReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			reconciled := &corev1alpha1.ClusterSecurityBaseline{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, reconciled)).To(Succeed())
+			expectAvailableCondition(reconciled.Status.Conditions)
+			resourceVersionAfterFirstReconcile := reconciled.ResourceVersion
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			reconciledAfterSecondRun := &corev1alpha1.ClusterSecurityBaseline{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, reconciledAfterSecondRun)).To(Succeed())
+			Expect(reconciledAfterSecondRun.ResourceVersion).To(Equal(resourceVersionAfterFirstReconcile))
+			expectAvailableCondition(reconciledAfterSecondRun.Status.Conditions)
+		})
+	})
+})
@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/controller"
+)
+
+var _ = Describe("EffectiveGovernance Controller", func() {
+	Context("When reconciling a namespace", func() {
+		const policyName = "test-effective-governance"
+
+		ctx := context.Background()
+
+		BeforeEach(func() {
+			By("creating a WorkloadPolicy in the namespace")
+			Expect(k8sClient.Create(ctx, &corev1alpha1.WorkloadPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: policyName, Namespace: "default"},
+				Spec: corev1alpha1.WorkloadPolicySpec{
+					DefaultRequests: map[string]string{"cpu": "500m"},
+					MandatoryLabels: map[string]string{"cost-center": "platform"},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the WorkloadPolicy and the EffectiveGovernance")
+			Expect(k8sClient.Delete(ctx, &corev1alpha1.WorkloadPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: policyName, Namespace: "default"},
+			})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &corev1alpha1.EffectiveGovernance{
+				ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.EffectiveGovernanceName, Namespace: "default"},
+			})).To(Succeed())
+		})
+
+		It("should record the policy defaults with their source", func() {
+			controllerReconciler := &controller.EffectiveGovernanceReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "default"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			governance := &corev1alpha1.EffectiveGovernance{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      corev1alpha1.EffectiveGovernanceName,
+				Namespace: "default",
+			}, governance)).To(Succeed())
+			source := corev1alpha1.PolicySource{Kind: "WorkloadPolicy", Namespace: "default", Name: policyName}
+			Expect(governance.Status.ResourceDefaults).To(ConsistOf(corev1alpha1.EffectiveResourceDefault{
+				Containers: corev1alpha1.ContainerKindContainers,
+				Type:       "requests",
+				Resource:   "cpu",
+				Value:      "500m",
+				Source:     source,
+			}))
+			Expect(governance.Status.MandatoryLabels).To(ConsistOf(corev1alpha1.EffectiveLabel{
+				Key:    "cost-center",
+				Value:  "platform",
+				Source: source,
+			}))
+		})
+	})
+})
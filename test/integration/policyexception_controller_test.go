@@ -0,0 +1,117 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha1 "github.com/f3nr1r/platform-governance-operator/api/v1alpha1"
+	"github.com/f3nr1r/platform-governance-operator/internal/controller"
+)
+
+var _ = Describe("PolicyException Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-policyexception"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		policyexception := &corev1alpha1.PolicyException{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind PolicyException")
+			err := k8sClient.Get(ctx, typeNamespacedName, policyexception)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &corev1alpha1.PolicyException{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: corev1alpha1.PolicyExceptionSpec{
+						Baseline:      corev1alpha1.BaselineReference{Name: "restricted"},
+						Rules:         []corev1alpha1.SecurityBaselineRule{corev1alpha1.RuleReadOnlyRootFilesystem},
+						Justification: "legacy image writes to its root filesystem",
+						Owner:         "team-a",
+						ExpiresAt:     metav1.NewTime(time.Now().Add(30 * 24 * time.Hour)),
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			resource := &corev1alpha1.PolicyException{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance PolicyException")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should mark the exception active and then expired", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &controller.PolicyExceptionReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			reconciled := &corev1alpha1.PolicyException{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, reconciled)).To(Succeed())
+			active := meta.FindStatusCondition(reconciled.Status.Conditions, corev1alpha1.PolicyExceptionConditionActive)
+			Expect(active).NotTo(BeNil())
+			Expect(active.Status).To(Equal(metav1.ConditionTrue))
+			Expect(active.Reason).To(Equal(corev1alpha1.PolicyExceptionReasonActive))
+
+			By("Moving expiresAt into the past")
+			reconciled.Spec.ExpiresAt = metav1.NewTime(time.Now().Add(-time.Minute))
+			Expect(k8sClient.Update(ctx, reconciled)).To(Succeed())
+
+			result, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			expired := &corev1alpha1.PolicyException{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, expired)).To(Succeed())
+			active = meta.FindStatusCondition(expired.Status.Conditions, corev1alpha1.PolicyExceptionConditionActive)
+			Expect(active).NotTo(BeNil())
+			Expect(active.Status).To(Equal(metav1.ConditionFalse))
+			Expect(active.Reason).To(Equal(corev1alpha1.PolicyExceptionReasonExpired))
+		})
+	})
+})
@@ -82,6 +82,8 @@ var _ = Describe("SecurityBaseline Controller", func() {
 			reconciled := &corev1alpha1.SecurityBaseline{}
 			Expect(k8sClient.Get(ctx, typeNamespacedName, reconciled)).To(Succeed())
 			expectAvailableCondition(reconciled.Status.Conditions)
+			Expect(reconciled.Status.Compliance).NotTo(BeNil())
+			Expect(reconciled.Status.Compliance.ViolatingPods).To(BeZero())
 			resourceVersionAfterFirstReconcile := reconciled.ResourceVersion
 
 			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
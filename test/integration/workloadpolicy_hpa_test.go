@@ -123,6 +123,28 @@ var _ = Describe("WorkloadPolicy HPA Integration", func() {
 				"higher-priority policy must create an HPA")
 		})
 
+		It("resolves priority per Deployment among the policies whose podSelector matches it", func() {
+			high := integPolicy(testNs, "high-priority", 100, true)
+			high.Spec.PodSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "batch"}}
+			low := integPolicy(testNs, "low-priority", 1, true)
+			Expect(k8sClient.Create(testCtx, high)).To(Succeed())
+			Expect(k8sClient.Create(testCtx, low)).To(Succeed())
+			web := integDeployment(testNs, "web", nil)
+			batch := integDeployment(testNs, "batch", nil)
+			Expect(k8sClient.Create(testCtx, web)).To(Succeed())
+			Expect(k8sClient.Create(testCtx, batch)).To(Succeed())
+
+			reconcilePolicy(testCtx, reconciler, testNs, low.Name)
+			Expect(hpaExists(testCtx, testNs, web.Name)).To(BeTrue(),
+				"lower-priority policy must manage Deployments the higher-priority policy does not select")
+			Expect(hpaExists(testCtx, testNs, batch.Name)).To(BeFalse(),
+				"lower-priority policy must not manage Deployments selected by a higher-priority policy")
+
+			reconcilePolicy(testCtx, reconciler, testNs, high.Name)
+			Expect(fetchHPA(testCtx, testNs, batch.Name).Annotations).To(
+				HaveKeyWithValue("core.platform.f3nr1r.io/workload-policy", high.Name))
+		})
+
 		It("breaks priority ties by selecting the alphabetically earliest policy name", func() {
 			// Same priority: "alpha-policy" < "beta-policy" → alpha wins
 			alpha := integPolicy(testNs, "alpha-policy", 50, true)